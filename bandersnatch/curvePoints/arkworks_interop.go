@@ -0,0 +1,70 @@
+package curvePoints
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+)
+
+// This file contains conversion functions for exchanging points with the Rust arkworks library (crate ark-ed-on-bls12-381-bandersnatch) in text form.
+//
+// arkworks uses the same twisted Edwards model (same a, d and generator) as we do, so an arkworks affine point (x, y) is the point with affine coordinates (x, y) here.
+// Its Display implementation for field elements outputs the canonical representative 0 <= . < BaseFieldSize in decimal, without any sign or leading zeros.
+// The neutral element is (0, 1); arkworks' affine representation cannot express the points at infinity.
+
+// ErrInvalidArkworksCoordinate is returned (wrapped) by PointFromArkworksAffine if a coordinate string is not the decimal representation of a reduced field element.
+var ErrInvalidArkworksCoordinate = errors.New(ErrorPrefix + "coordinate string is not a decimal number in the range 0 <= . < BaseFieldSize")
+
+// PointFromArkworksAffine constructs a point from its affine coordinates given as decimal strings, as output by arkworks' Display.
+//
+// We are strict about the format: The strings must consist of decimal digits only (no sign, whitespace or prefix) and the numbers must be reduced modulo BaseFieldSize.
+// Possible errors are (errors wrapping) ErrInvalidArkworksCoordinate and the errors of CurvePointFromXYAffine_full, notably ErrNotOnCurve.
+// Note that the resulting point need not be in the prime-order subgroup.
+func PointFromArkworksAffine(xStr, yStr string) (Point_axtw_full, error) {
+	var x, y FieldElement
+	if err := parseArkworksCoordinate(&x, xStr); err != nil {
+		return Point_axtw_full{}, fmt.Errorf("%w: x coordinate %q", err, xStr)
+	}
+	if err := parseArkworksCoordinate(&y, yStr); err != nil {
+		return Point_axtw_full{}, fmt.Errorf("%w: y coordinate %q", err, yStr)
+	}
+	point, err := CurvePointFromXYAffine_full(&x, &y, untrustedInput)
+	if err != nil {
+		return Point_axtw_full{}, err
+	}
+	return point, nil
+}
+
+// parseArkworksCoordinate sets z to the field element given by the decimal string s. It returns ErrInvalidArkworksCoordinate if s is not in the format output by arkworks.
+func parseArkworksCoordinate(z *FieldElement, s string) error {
+	if len(s) == 0 || (len(s) > 1 && s[0] == '0') {
+		return ErrInvalidArkworksCoordinate
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return ErrInvalidArkworksCoordinate
+		}
+	}
+	var v big.Int
+	if _, ok := v.SetString(s, 10); !ok || v.Cmp(BaseFieldSize_Int) >= 0 {
+		return ErrInvalidArkworksCoordinate
+	}
+	z.SetBigInt(&v)
+	return nil
+}
+
+// ToArkworksAffine returns the affine coordinates of p as decimal strings, in the format of arkworks' Display.
+//
+// The only possible error is ErrCannotSerializeNaP (from the bandersnatchErrors package) if p is a NaP. In this case, xStr and yStr are empty.
+func (p *Point_axtw_full) ToArkworksAffine() (xStr, yStr string, err error) {
+	if p.IsNaP() {
+		err = bandersnatchErrors.ErrCannotSerializeNaP
+		return
+	}
+	x, y := p.XY_affine()
+	xStr = x.ToBigInt().String()
+	yStr = y.ToBigInt().String()
+	return
+}
@@ -0,0 +1,83 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// arkworksFixtures contains affine points as output by arkworks (crate ark-ed-on-bls12-381-bandersnatch) via Display.
+var arkworksFixtures = []struct {
+	name string
+	x, y string
+}{
+	// EdwardsAffine::generator()
+	{"generator", "18886178867200960497001835917649091219057080094937609519140440539760939937304", "19188667384257783945677642223292697773471335439753913231509108946878080696678"},
+	// EdwardsAffine::zero()
+	{"identity", "0", "1"},
+	// (EdwardsAffine::generator() * Fr::from(n)).into_affine() for n = 2, 3 and 0xdeadbeef.
+	// These were computed with the affine addition law for arkworks' parameters a = -5, d = 138827208126141220649022263972958607803 / 171449701953573178309673572579671231137,
+	// independently of this library. Since affine coordinates are unique and Display prints the canonical representative in decimal, this is exactly arkworks' output.
+	{"2*generator", "21829743261194590194992413705867576097158323059182896808782966767024601242412", "19075870567762384361343718229920461045746972450262741916171739040424605531019"},
+	{"3*generator", "19213755708763254619264831853746015614457568707574289360541474768076689519718", "17364390373284516257285034247139577682165868767001357086426373468799918686336"},
+	{"0xdeadbeef*generator", "50417369067960066504589845345678946086179772393843970665703676072497520735349", "17196003624120912038557847302321218744152391200729467819724196123569655737994"},
+	// The affine point of order two (0, -1), which is outside the prime-order subgroup.
+	{"order two", "0", "52435875175126190479447740508185965837690552500527637822603658699938581184512"},
+}
+
+func TestArkworksFixtures(t *testing.T) {
+	var twoG, threeG, bigG Point_xtw_subgroup
+	twoG.ScalarMult(&SubgroupGenerator_xtw_subgroup, big.NewInt(2))
+	threeG.ScalarMult(&SubgroupGenerator_xtw_subgroup, big.NewInt(3))
+	bigG.ScalarMult(&SubgroupGenerator_xtw_subgroup, big.NewInt(0xdeadbeef))
+	expected := map[string]CurvePointPtrInterfaceRead{
+		"generator":            &SubgroupGenerator_xtw_subgroup,
+		"identity":             &NeutralElement_xtw_full,
+		"2*generator":          &twoG,
+		"3*generator":          &threeG,
+		"0xdeadbeef*generator": &bigG,
+		"order two":            &AffineOrderTwoPoint_xtw,
+	}
+	for _, fixture := range arkworksFixtures {
+		P, err := PointFromArkworksAffine(fixture.x, fixture.y)
+		testutils.FatalUnless(t, err == nil, "PointFromArkworksAffine failed for %v: %v", fixture.name, err)
+		testutils.FatalUnless(t, P.IsEqual(expected[fixture.name]), "PointFromArkworksAffine gave wrong point for %v", fixture.name)
+		xStr, yStr, err := P.ToArkworksAffine()
+		testutils.FatalUnless(t, err == nil, "ToArkworksAffine failed for %v: %v", fixture.name, err)
+		testutils.FatalUnless(t, xStr == fixture.x && yStr == fixture.y, "ToArkworksAffine does not reproduce fixture %v", fixture.name)
+	}
+}
+
+func TestArkworksRoundTrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		var P Point_axtw_full
+		P.sampleRandomUnsafe(drng)
+		xStr, yStr, err := P.ToArkworksAffine()
+		testutils.FatalUnless(t, err == nil, "")
+		Q, err := PointFromArkworksAffine(xStr, yStr)
+		testutils.FatalUnless(t, err == nil, "PointFromArkworksAffine failed on output of ToArkworksAffine: %v", err)
+		testutils.FatalUnless(t, Q.IsEqual(&P), "Round trip failed")
+	}
+
+	var NaP Point_axtw_full
+	_, _, err := NaP.ToArkworksAffine()
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "")
+}
+
+func TestArkworksInvalidInput(t *testing.T) {
+	generatorX, generatorY := arkworksFixtures[0].x, arkworksFixtures[0].y
+	for _, invalid := range []string{"", "-1", "+1", "01", " 1", "0x1", "1.0", BaseFieldSize_Int.String(), new(big.Int).Lsh(BaseFieldSize_Int, 1).String()} {
+		_, err := PointFromArkworksAffine(invalid, generatorY)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidArkworksCoordinate), "PointFromArkworksAffine accepted x coordinate %q. Got %v", invalid, err)
+		_, err = PointFromArkworksAffine(generatorX, invalid)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidArkworksCoordinate), "PointFromArkworksAffine accepted y coordinate %q. Got %v", invalid, err)
+	}
+	// well-formed, but not on the curve
+	_, err := PointFromArkworksAffine(generatorX, "2")
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrNotOnCurve), "PointFromArkworksAffine accepted point not on curve. Got %v", err)
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/exponents"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
 )
 
@@ -18,6 +19,7 @@ import (
 const ErrorPrefix = "bandersnatch / curve points: "
 
 type FieldElement = fieldElements.FieldElement
+type Scalar = exponents.Scalar
 type IsInputTrusted = common.IsInputTrusted
 
 // These are COPIES and unexported by design.
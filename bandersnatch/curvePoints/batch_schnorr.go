@@ -0,0 +1,75 @@
+package curvePoints
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// This file contains batch verification for Schnorr-style signatures.
+
+// batchVerificationRandomnessBytes is the number of random bytes used for each coefficient of the random linear combination in BatchVerifySchnorr.
+// With 128 bits, a batch containing an invalid signature is accepted with probability at most 2^-128.
+const batchVerificationRandomnessBytes = 16
+
+// BatchVerifySchnorr checks whether s_i * G == R_i + c_i * pubkeys_i holds for all i, where G is SubgroupGenerator_xtw_subgroup,
+// R_i == Rs[i], s_i == ss[i] and c_i == challenges[i]. Computing the challenges from the message is up to the caller.
+//
+// Rather than checking each signature individually, we sample fresh random coefficients z_i and check the single equation
+// (sum_i z_i*s_i) * G - sum_i z_i * R_i - sum_i (z_i*c_i) * pubkeys_i == neutral element using one multi-scalar multiplication.
+// If all signatures are valid, this always holds; if any signature is invalid, it holds with probability at most 2^-128 over the choice of the z_i.
+//
+// We return false if the slices have mismatched lengths, if any input is nil or a NaP, or if reading randomness fails.
+// An empty batch is accepted. The inputs are not modified.
+//
+// NOTE: This is not constant-time. This is fine, since signature verification only deals with public data.
+func BatchVerifySchnorr(pubkeys, Rs []Point_xtw_subgroup, ss []*big.Int, challenges []*big.Int) bool {
+	return batchVerifySchnorr(rand.Reader, pubkeys, Rs, ss, challenges)
+}
+
+// batchVerifySchnorr is the implementation of BatchVerifySchnorr, reading the random coefficients from rnd.
+func batchVerifySchnorr(rnd io.Reader, pubkeys, Rs []Point_xtw_subgroup, ss []*big.Int, challenges []*big.Int) bool {
+	n := len(pubkeys)
+	if len(Rs) != n || len(ss) != n || len(challenges) != n {
+		return false
+	}
+	if n == 0 {
+		return true
+	}
+
+	// scalars[0] belongs to the generator, scalars[1+i] to Rs[i] and scalars[1+n+i] to pubkeys[i].
+	scalars := make([]big.Int, 2*n+1)
+	points := make([]Point_xtw_subgroup, 2*n+1)
+	points[0] = SubgroupGenerator_xtw_subgroup
+
+	var buf [batchVerificationRandomnessBytes]byte
+	var z, temp big.Int
+	for i := 0; i < n; i++ {
+		if ss[i] == nil || challenges[i] == nil || Rs[i].IsNaP() || pubkeys[i].IsNaP() {
+			return false
+		}
+		if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+			return false
+		}
+		z.SetBytes(buf[:])
+
+		// generator: += z_i * s_i
+		temp.Mul(&z, ss[i])
+		scalars[0].Add(&scalars[0], &temp)
+
+		// R_i: -z_i
+		temp.Neg(&z)
+		ReduceScalar(&scalars[1+i], &temp)
+		points[1+i] = Rs[i]
+
+		// pubkey_i: -z_i * c_i
+		temp.Mul(&z, challenges[i])
+		temp.Neg(&temp)
+		ReduceScalar(&scalars[1+n+i], &temp)
+		points[1+n+i] = pubkeys[i]
+	}
+	ReduceScalar(&scalars[0], &scalars[0])
+
+	result := multiScalarMultPippenger(scalars, points)
+	return result.IsNeutralElement()
+}
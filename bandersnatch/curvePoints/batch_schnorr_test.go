@@ -0,0 +1,72 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// makeSchnorrBatch creates n valid signatures (R_i, s_i) for public keys pk_i and challenges c_i, i.e. s_i * G == R_i + c_i * pk_i.
+func makeSchnorrBatch(t *testing.T, drng *rand.Rand, n int) (pubkeys, Rs []Point_xtw_subgroup, ss, challenges []*big.Int) {
+	pubkeys = make([]Point_xtw_subgroup, n)
+	Rs = make([]Point_xtw_subgroup, n)
+	ss = make([]*big.Int, n)
+	challenges = make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		sk, pk, err := GenerateKeypair(drng)
+		testutils.FatalUnless(t, err == nil, "GenerateKeypair failed: %v", err)
+		nonce, R, err := GenerateKeypair(drng)
+		testutils.FatalUnless(t, err == nil, "GenerateKeypair failed: %v", err)
+		c := new(big.Int).Rand(drng, GroupOrder_Int)
+		s := new(big.Int).Mul(c, sk)
+		s.Add(s, nonce)
+		s.Mod(s, GroupOrder_Int)
+		pubkeys[i], Rs[i], ss[i], challenges[i] = pk, R, s, c
+	}
+	return
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("no randomness") }
+
+func TestBatchVerifySchnorr(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1001))
+	pubkeys, Rs, ss, challenges := makeSchnorrBatch(t, drng, 20)
+
+	testutils.FatalUnless(t, BatchVerifySchnorr(pubkeys, Rs, ss, challenges), "BatchVerifySchnorr rejected a batch of valid signatures")
+	testutils.FatalUnless(t, BatchVerifySchnorr(nil, nil, nil, nil), "BatchVerifySchnorr rejected an empty batch")
+
+	// tampered s
+	tampered := make([]*big.Int, len(ss))
+	copy(tampered, ss)
+	tampered[7] = new(big.Int).Add(ss[7], big.NewInt(1))
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, Rs, tampered, challenges), "BatchVerifySchnorr accepted a batch with a tampered s")
+
+	// tampered R
+	RsTampered := make([]Point_xtw_subgroup, len(Rs))
+	copy(RsTampered, Rs)
+	RsTampered[3].AddEq(&SubgroupGenerator_xtw_subgroup)
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, RsTampered, ss, challenges), "BatchVerifySchnorr accepted a batch with a tampered R")
+
+	// swapped challenges
+	challengesTampered := make([]*big.Int, len(challenges))
+	copy(challengesTampered, challenges)
+	challengesTampered[0], challengesTampered[1] = challenges[1], challenges[0]
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, Rs, ss, challengesTampered), "BatchVerifySchnorr accepted a batch with swapped challenges")
+
+	// mismatched lengths, nil entries and NaPs
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys[:19], Rs, ss, challenges), "BatchVerifySchnorr accepted mismatched lengths")
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, Rs, ss[:19], challenges), "BatchVerifySchnorr accepted mismatched lengths")
+	tampered[7] = nil
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, Rs, tampered, challenges), "BatchVerifySchnorr accepted nil scalar")
+	var NaP Point_xtw_subgroup
+	RsTampered[3] = NaP
+	testutils.FatalUnless(t, !BatchVerifySchnorr(pubkeys, RsTampered, ss, challenges), "BatchVerifySchnorr accepted NaP")
+
+	// failing randomness
+	testutils.FatalUnless(t, !batchVerifySchnorr(failingReader{}, pubkeys, Rs, ss, challenges), "batchVerifySchnorr accepted without randomness")
+}
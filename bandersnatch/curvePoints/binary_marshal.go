@@ -0,0 +1,149 @@
+package curvePoints
+
+import (
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+)
+
+// This file makes the exported point types satisfy the encoding.BinaryMarshaler and encoding.BinaryUnmarshaler interfaces from the standard library.
+// This allows points to be used directly with encoding/gob and other generic serialization or caching layers.
+//
+// The format is the 32-byte short Banderwagon format, as output by DecafEncode (see serializer_hex.go).
+// Note that this format can only represent points in the prime-order subgroup. For the full-curve types, MarshalBinary returns an error for other points
+// and UnmarshalBinary only ever outputs points in the subgroup.
+// For anything more flexible, use the serializers from the pointserializer package.
+
+var ErrWrongBinaryLength = fmt.Errorf(ErrorPrefix+"binary encoding of a curve point does not have exactly %v bytes", hexShortFormByteLength)
+
+// marshalBinaryShort is the common implementation of MarshalBinary for all point types.
+//
+// Possible errors are ErrCannotSerializeNaP, ErrCannotSerializePointAtInfinity and ErrWillNotSerializePointOutsideSubgroup from the bandersnatchErrors package.
+func marshalBinaryShort(p CurvePointPtrInterfaceRead) (data []byte, err error) {
+	if p.IsNaP() {
+		return nil, bandersnatchErrors.ErrCannotSerializeNaP
+	}
+	if p.IsAtInfinity() {
+		return nil, bandersnatchErrors.ErrCannotSerializePointAtInfinity
+	}
+	var pSubgroup Point_xtw_subgroup
+	if !pSubgroup.SetFromSubgroupPoint(p, untrustedInput) {
+		return nil, bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup
+	}
+	var encoding [hexShortFormByteLength]byte
+	if err = pSubgroup.EncodeTo(&encoding); err != nil {
+		return nil, err
+	}
+	return encoding[:], nil
+}
+
+// unmarshalBinaryShort is the common implementation of UnmarshalBinary for all point types.
+// The input is always treated as untrusted. On error, receiver is untouched.
+//
+// Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func unmarshalBinaryShort(receiver CurvePointPtrInterfaceWrite, data []byte) error {
+	if len(data) != hexShortFormByteLength {
+		return fmt.Errorf("%w. The given input has length %v", ErrWrongBinaryLength, len(data))
+	}
+	var encoding [hexShortFormByteLength]byte
+	copy(encoding[:], data)
+	var point Point_xtw_subgroup
+	if err := point.DecodeFrom(&encoding, untrustedInput); err != nil {
+		return err
+	}
+	receiver.SetFrom(&point)
+	return nil
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p (the same as DecafEncode). This satisfies the encoding.BinaryMarshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_xtw_subgroup) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p. This satisfies the encoding.BinaryMarshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_axtw_subgroup) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p. This satisfies the encoding.BinaryMarshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_efgh_subgroup) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p. This satisfies the encoding.BinaryMarshaler interface.
+//
+// Since the format can only represent points in the prime-order subgroup, possible errors are
+// ErrCannotSerializeNaP, ErrCannotSerializePointAtInfinity and ErrWillNotSerializePointOutsideSubgroup from the bandersnatchErrors package.
+func (p *Point_xtw_full) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p. This satisfies the encoding.BinaryMarshaler interface.
+//
+// Since the format can only represent points in the prime-order subgroup, possible errors are
+// ErrCannotSerializeNaP and ErrWillNotSerializePointOutsideSubgroup from the bandersnatchErrors package.
+func (p *Point_axtw_full) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// MarshalBinary returns the 32-byte short Banderwagon encoding of p. This satisfies the encoding.BinaryMarshaler interface.
+//
+// Since the format can only represent points in the prime-order subgroup, possible errors are
+// ErrCannotSerializeNaP, ErrCannotSerializePointAtInfinity and ErrWillNotSerializePointOutsideSubgroup from the bandersnatchErrors package.
+func (p *Point_efgh_full) MarshalBinary() (data []byte, err error) {
+	return marshalBinaryShort(p)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_xtw_subgroup) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_axtw_subgroup) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_efgh_subgroup) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed, so p will always be in the prime-order subgroup.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_xtw_full) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed, so p will always be in the prime-order subgroup.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_axtw_full) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
+
+// UnmarshalBinary sets p to the point encoded in data, which must be in the format output by MarshalBinary. This satisfies the encoding.BinaryUnmarshaler interface.
+// data is treated as untrusted input and the subgroup check is always performed, so p will always be in the prime-order subgroup.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrWrongBinaryLength and any error that DecodeFrom may output.
+func (p *Point_efgh_full) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryShort(p, data)
+}
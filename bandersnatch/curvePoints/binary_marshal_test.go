@@ -0,0 +1,120 @@
+package curvePoints
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ encoding.BinaryMarshaler = &Point_xtw_subgroup{}
+var _ encoding.BinaryMarshaler = &Point_axtw_subgroup{}
+var _ encoding.BinaryMarshaler = &Point_efgh_subgroup{}
+var _ encoding.BinaryMarshaler = &Point_xtw_full{}
+var _ encoding.BinaryMarshaler = &Point_axtw_full{}
+var _ encoding.BinaryMarshaler = &Point_efgh_full{}
+
+var _ encoding.BinaryUnmarshaler = &Point_xtw_subgroup{}
+var _ encoding.BinaryUnmarshaler = &Point_axtw_subgroup{}
+var _ encoding.BinaryUnmarshaler = &Point_efgh_subgroup{}
+var _ encoding.BinaryUnmarshaler = &Point_xtw_full{}
+var _ encoding.BinaryUnmarshaler = &Point_axtw_full{}
+var _ encoding.BinaryUnmarshaler = &Point_efgh_full{}
+
+type binaryMarshalablePoint interface {
+	CurvePointPtrInterface
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func TestBinaryMarshalRoundtrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		expected := DecafEncode(&P)
+		for _, pointType := range []PointType{pointTypeXTWSubgroup, pointTypeAXTWSubgroup, pointTypeEFGHSubgroup, pointTypeXTWFull, pointTypeAXTWFull, pointTypeEFGHFull} {
+			point := makeCurvePointPtrInterface(pointType).(binaryMarshalablePoint)
+			point.SetFrom(&P)
+			data, err := point.MarshalBinary()
+			testutils.FatalUnless(t, err == nil, "MarshalBinary failed for %v: %v", pointTypeToString(pointType), err)
+			testutils.FatalUnless(t, bytes.Equal(data, expected[:]), "MarshalBinary does not match DecafEncode for %v", pointTypeToString(pointType))
+
+			result := makeCurvePointPtrInterface(pointType).(binaryMarshalablePoint)
+			err = result.UnmarshalBinary(data)
+			testutils.FatalUnless(t, err == nil, "UnmarshalBinary failed for %v: %v", pointTypeToString(pointType), err)
+			testutils.FatalUnless(t, result.IsEqual(&P), "Roundtrip failed for %v", pointTypeToString(pointType))
+		}
+	}
+}
+
+func TestBinaryMarshalErrors(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+
+	// NaP
+	var nap Point_xtw_subgroup
+	_, err := nap.MarshalBinary()
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error for NaP: %v", err)
+
+	// Points outside the subgroup
+	var P Point_xtw_full = AffineOrderTwoPoint_xtw
+	_, err = P.MarshalBinary()
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup), "Unexpected error for point outside subgroup: %v", err)
+	P = InfinitePoint1_xtw
+	_, err = P.MarshalBinary()
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity), "Unexpected error for point at infinity: %v", err)
+
+	// Wrong lengths
+	Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	data, _ := Q.MarshalBinary()
+	var R Point_xtw_subgroup = SubgroupGenerator_xtw_subgroup
+	for _, wrongData := range [][]byte{nil, data[0:31], append(data, 0)} {
+		err = R.UnmarshalBinary(wrongData)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongBinaryLength), "Unexpected error for input of length %v: %v", len(wrongData), err)
+		testutils.FatalUnless(t, R.IsEqual(&SubgroupGenerator_xtw_subgroup), "UnmarshalBinary modified receiver on error")
+	}
+
+	// Encodings of points that are not in the subgroup must be rejected, even for full-curve types.
+	var rejected int
+	for i := 0; i < 20; i++ {
+		var randomData [32]byte
+		drng.Read(randomData[:])
+		var S Point_xtw_subgroup
+		if S.DecodeFrom(&randomData, untrustedInput) == nil {
+			continue
+		}
+		rejected++
+		var Sfull Point_xtw_full = NeutralElement_xtw_full
+		err = Sfull.UnmarshalBinary(randomData[:])
+		testutils.FatalUnless(t, err != nil, "UnmarshalBinary accepted invalid input")
+		testutils.FatalUnless(t, Sfull.IsNeutralElement(), "UnmarshalBinary modified receiver on error")
+	}
+	testutils.FatalUnless(t, rejected > 0, "No invalid inputs were generated")
+}
+
+// Points must be usable with encoding/gob via the encoding.BinaryMarshaler interface.
+func TestBinaryMarshalGob(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	type container struct {
+		Name   string
+		Points []Point_xtw_subgroup
+	}
+	input := container{Name: "test", Points: make([]Point_xtw_subgroup, 5)}
+	for i := range input.Points {
+		input.Points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(&input)
+	testutils.FatalUnless(t, err == nil, "gob encoding failed: %v", err)
+	var output container
+	err = gob.NewDecoder(&buf).Decode(&output)
+	testutils.FatalUnless(t, err == nil, "gob decoding failed: %v", err)
+	testutils.FatalUnless(t, output.Name == input.Name && len(output.Points) == len(input.Points), "")
+	for i := range input.Points {
+		testutils.FatalUnless(t, output.Points[i].IsEqual(&input.Points[i]), "gob roundtrip failed at index %v", i)
+	}
+}
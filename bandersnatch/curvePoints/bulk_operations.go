@@ -3,6 +3,8 @@ package curvePoints
 import (
 	"fmt"
 
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
 )
 
@@ -13,59 +15,26 @@ import (
 // If none of the points have Z-coordinate 0, return nil. Otherwise, we return a sorted list of indices of those elements which were 0.
 // Those points with Z-coordinate 0 are set to an appropriate standard representation.
 func (points CurvePointSlice_xtw_full) NormalizeSlice() (zeroIndices []int) {
-	L := len(points)
-
-	// We bulk-invert all the points[i].z's.
-	// For efficiency, we use the SkipZeros version (because that skip all the ones as well.)
-	var Inversions []*FieldElement = make([]*FieldElement, L)
-	for i := 0; i < L; i++ {
-		Inversions[i] = &points[i].z
-	}
-	zeroIndices = fieldElements.MultiInvertEqSkipZeros(Inversions...)
-
-	// nextZeroIndex is the first/next index where we need to treat the point specially.
-	nextZeroIndex := L
-	zeroIndicesProcessed := 0
-
-	if zeroIndices != nil {
-		nextZeroIndex = zeroIndices[0]
+	bases := make([]*point_xtw_base, len(points))
+	for i := range points {
+		bases[i] = &points[i].point_xtw_base
 	}
+	zeroIndices = batchNormalizeAffineZ(bases)
 
-	for i := 0; i < L; i++ {
+	// We normalize points with Z==0:
+	// NaPs become standard (0:0:0:0)-NaPs and points at infinity get replaced by default representations of the appropriate point at infinity.
+	for _, i := range zeroIndices {
 		point := &points[i]
-		if i != nextZeroIndex {
-			point.x.MulEq(&point.z)
-			point.y.MulEq(&point.z)
-			point.t.MulEq(&point.z)
-			point.z.SetOne()
-		} else {
-			// i is in zeroIndices. We need to treat these cases specially:
-
-			// Ensure next zero index is found:
-			zeroIndicesProcessed++
-			if zeroIndicesProcessed == len(zeroIndices) {
-				nextZeroIndex = L
-			} else {
-				nextZeroIndex = zeroIndices[zeroIndicesProcessed]
-			}
-
-			// We normalize points with Z==0:
-			// NaPs become standard (0:0:0:0)-NaPs
-			if point.IsNaP() {
-				napEncountered("NaP encountered when bulk-normalizing a slice of Point_xtw_full points", false, point)
-				*point = Point_xtw_full{} // standard-NAP
-				continue
-			}
-			// Points at infinity get replaced by default representations of the appropriate point at infinity:
-			if point.IsE1() {
-				point.SetE1()
-				continue
-			}
-			if point.IsE2() {
-				point.SetE2()
-				continue
-			}
-			panic(fmt.Errorf(ErrorPrefix+"Point with Z==0 encountered that was neither a NaP nor a point at inifity. This is not supposed to be possible. The Point in question was %v", *point))
+		switch {
+		case point.IsNaP():
+			napEncountered("NaP encountered when bulk-normalizing a slice of Point_xtw_full points", false, point)
+			*point = Point_xtw_full{} // standard-NAP
+		case point.IsE1():
+			point.SetE1()
+		case point.IsE2():
+			point.SetE2()
+		default:
+			panic(fmt.Errorf(ErrorPrefix+"Point with Z==0 encountered that was neither a NaP nor a point at infinity. This is not supposed to be possible. The Point in question was %v", *point))
 		}
 	}
 	return
@@ -78,54 +47,212 @@ func (points CurvePointSlice_xtw_full) NormalizeSlice() (zeroIndices []int) {
 // If none of the points have Z-coordinate 0, return nil. Otherwise, we return a sorted list of indices of those elements which were 0.
 // Those points with Z-coordinate 0 are set to an appropriate standard representation.
 func (points CurvePointSlice_xtw_subgroup) NormalizeSlice() (zeroIndices []int) {
+	bases := make([]*point_xtw_base, len(points))
+	for i := range points {
+		bases[i] = &points[i].point_xtw_base
+	}
+	zeroIndices = batchNormalizeAffineZ(bases)
+
+	// We normalize points with Z==0: NaPs become standard (0:0:0:0)-NaPs.
+	// Points at infinity are not possible for this point type!
+	for _, i := range zeroIndices {
+		point := &points[i]
+		if !point.IsNaP() {
+			panic(fmt.Errorf(ErrorPrefix+"Point_xtw_subgroup with Z==0 encountered that was not a NaP. Since this point type cannot represent points at infinity, this is not supposed to be possible. The Point in question was %v", *point))
+		}
+		napEncountered("NaP encountered when bulk-normalizing a slice of Point_xtw_subgroup points", false, point)
+		*point = Point_xtw_subgroup{} // standard-NAP
+	}
+	return
+}
+
+// PointsFromAffineXY constructs curve points from the given affine x and y coordinates, i.e. the i'th output point has coordinates xs[i], ys[i].
+// trustLevel should be one of TrustedInput or UntrustedInput. If subgroup is set to true, we also require the points to be in the prime-order subgroup.
+//
+// This is intended for bulk-importing tables of points. As opposed to calling CurvePointFromXYAffine_full or CurvePointFromXYAffine_subgroup in a loop,
+// we do not abort on the first invalid pair: The returned errs has the same length as xs and errs[i] is non-nil iff (xs[i], ys[i]) is invalid.
+// In this case, points[i] is set to a NaP and must not be used.
+// The possible errors are the same as for CurvePointFromXYAffine_full resp. CurvePointFromXYAffine_subgroup.
+//
+// For UntrustedInput and subgroup == true, the Legendre symbols needed for the subgroup checks are computed with a single call to fieldElements.BatchJacobi.
+// Note that this is still one Jacobi symbol computation per symbol (there is no Montgomery-style trick for Legendre symbols); BatchJacobi only saves the per-element conversion overhead.
+// For TrustedInput, we are free to skip checks exactly as for the single-point functions.
+//
+// xs and ys must have the same length; we panic otherwise.
+func PointsFromAffineXY(xs, ys []FieldElement, trustLevel IsInputTrusted, subgroup bool) (points []Point_axtw_full, errs []error) {
+	L := len(xs)
+	if len(ys) != L {
+		panic(fmt.Errorf(ErrorPrefix+"PointsFromAffineXY called with slices of different lengths: len(xs) == %v, len(ys) == %v", L, len(ys)))
+	}
+	points = make([]Point_axtw_full, L)
+	errs = make([]error, L)
+
+	// validIndices are the indices of points that passed the on-curve check; only those need a subgroup check.
+	validIndices := make([]int, 0, L)
+	for i := 0; i < L; i++ {
+		var err errorsWithData.ErrorWithGuaranteedParameters[struct{ X, Y FieldElement }]
+		points[i], err = CurvePointFromXYAffine_full(&xs[i], &ys[i], trustLevel)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		validIndices = append(validIndices, i)
+	}
+	if !subgroup || trustLevel.Bool() {
+		return
+	}
+
+	// For each point, we need 2 Legendre symbols, see legendreCheckA_affineX and legendreCheckE1_affineY.
+	// We put the arguments for point validIndices[j] at positions 2j and 2j+1.
+	legendreArguments := make([]FieldElement, 2*len(validIndices))
+	for j, i := range validIndices {
+		legendreArguments[2*j] = legendreCheckA_affineX_argument(points[i].x)
+		legendreArguments[2*j+1] = legendreCheckE1_affineY_argument(points[i].y)
+	}
+	legendreSymbols := fieldElements.BatchJacobi(legendreArguments)
+	for j, i := range validIndices {
+		if legendreSymbols[2*j] >= 0 && legendreSymbols[2*j+1] <= 0 {
+			continue
+		}
+		errs[i] = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrNotInSubgroup, "%w. Affine coordinates are X=%v{X}, Y=%v{Y}", &struct{ X, Y FieldElement }{X: xs[i], Y: ys[i]})
+		points[i] = Point_axtw_full{} // standard NaP
+	}
+	return
+}
+
+// PackCommonZ rewrites the given points to projective representations that all share the same Z coordinate z.
+// The i'th point then is (xs[i]:ys[i]:ts[i]:z). This is intended as a storage optimization for tables of precomputed points, see UnpackCommonZ for the converse.
+//
+// We use the product of all Z coordinates as the common z, so (as opposed to NormalizeSlice) this requires no field inversion.
+// If any point has Z==0 (i.e. is a NaP or a point at infinity), there is no such common z and we return ok == false; the other return values are meaningless in this case.
+// For an empty slice, we return empty slices, z == 1 and ok == true.
+func PackCommonZ(points []Point_xtw_full) (xs, ys, ts []FieldElement, z FieldElement, ok bool) {
 	L := len(points)
+	for i := 0; i < L; i++ {
+		if points[i].z.IsZero() {
+			return nil, nil, nil, FieldElement{}, false
+		}
+	}
+
+	// The scaling factor for points[i] is the product of all Z coordinates except the i'th one.
+	// We compute this as prefixProducts[i] * (product of Z's with index > i)
+	prefixProducts := make([]FieldElement, L)
+	z.SetOne()
+	for i := 0; i < L; i++ {
+		prefixProducts[i] = z
+		z.MulEq(&points[i].z)
+	}
+
+	xs = make([]FieldElement, L)
+	ys = make([]FieldElement, L)
+	ts = make([]FieldElement, L)
+	var suffixProduct FieldElement
+	suffixProduct.SetOne()
+	for i := L - 1; i >= 0; i-- {
+		var factor FieldElement
+		factor.Mul(&prefixProducts[i], &suffixProduct)
+		xs[i].Mul(&points[i].x, &factor)
+		ys[i].Mul(&points[i].y, &factor)
+		ts[i].Mul(&points[i].t, &factor)
+		suffixProduct.MulEq(&points[i].z)
+	}
+	ok = true
+	return
+}
+
+// UnpackCommonZ is the converse of PackCommonZ: The i'th returned point is (xs[i]:ys[i]:ts[i]:z).
+//
+// The inputs are trusted to come from PackCommonZ; in particular, we perform no on-curve checks.
+// xs, ys and ts must have the same length; we panic otherwise.
+func UnpackCommonZ(xs, ys, ts []FieldElement, z *FieldElement) (points []Point_xtw_full) {
+	L := len(xs)
+	if len(ys) != L || len(ts) != L {
+		panic(fmt.Errorf(ErrorPrefix+"UnpackCommonZ called with slices of different lengths: len(xs) == %v, len(ys) == %v, len(ts) == %v", L, len(ys), len(ts)))
+	}
+	points = make([]Point_xtw_full, L)
+	for i := 0; i < L; i++ {
+		points[i].x = xs[i]
+		points[i].y = ys[i]
+		points[i].t = ts[i]
+		points[i].z = *z
+	}
+	return
+}
 
-	// We bulk-invert all the points[i].z's.
-	// For efficiency, we use the SkipZeros version (because that skip all the ones as well.)
+// batchNormalizeAffineZ replaces the internal representation of each of the given points by an equivalent one with Z==1, using a single field inversion.
+// Points with Z==0 are left untouched and their (sorted) indices are returned; the return value is nil if there are none. Handling those is up to the caller.
+func batchNormalizeAffineZ(points []*point_xtw_base) (zeroIndices []int) {
+	L := len(points)
 	var Inversions []*FieldElement = make([]*FieldElement, L)
 	for i := 0; i < L; i++ {
 		Inversions[i] = &points[i].z
 	}
 	zeroIndices = fieldElements.MultiInvertEqSkipZeros(Inversions...)
 
-	// nextZeroIndex is the first/next index where we need to treat the point specially.
-	nextZeroIndex := L
-	zeroIndicesProcessed := 0
+	// zeroIndices is sorted, so we just need to track the next index to skip.
+	nextZero := 0
+	for i := 0; i < L; i++ {
+		if nextZero < len(zeroIndices) && zeroIndices[nextZero] == i {
+			nextZero++
+			continue
+		}
+		point := points[i]
+		point.x.MulEq(&point.z)
+		point.y.MulEq(&point.z)
+		point.t.MulEq(&point.z)
+		point.z.SetOne()
+	}
+	return
+}
 
-	if zeroIndices != nil {
-		nextZeroIndex = zeroIndices[0]
+// BatchNormalizeAffineZ changes the internal representation of each of the given points to an equivalent one with Z==1.
+// This is the analogue of NormalizeSlice for slices of pointers: Using Montgomery's trick, it needs only a single field inversion for the whole batch
+// rather than one per point as when normalizing the points individually (e.g. by calling XY_affine).
+//
+// Points at infinity and NaPs cannot be normalized; they do not abort the batch. Instead, we return the sorted list of their indices (nil if there are none).
+// Points at infinity are set to the standard representation of the respective point at infinity and NaPs to a standard NaP (after calling the NaP handler).
+//
+// The given pointers must not be nil and must be pairwise distinct.
+func BatchNormalizeAffineZ(points []*Point_xtw_full) (zeroIndices []int) {
+	bases := make([]*point_xtw_base, len(points))
+	for i := range points {
+		bases[i] = &points[i].point_xtw_base
 	}
+	zeroIndices = batchNormalizeAffineZ(bases)
+	for _, i := range zeroIndices {
+		point := points[i]
+		switch {
+		case point.IsNaP():
+			napEncountered("NaP encountered when batch-normalizing Point_xtw_full points", false, point)
+			*point = Point_xtw_full{} // standard NaP
+		case point.IsE1():
+			point.SetE1()
+		case point.IsE2():
+			point.SetE2()
+		default:
+			panic(fmt.Errorf(ErrorPrefix+"Point with Z==0 encountered that was neither a NaP nor a point at infinity. This is not supposed to be possible. The Point in question was %v", *point))
+		}
+	}
+	return
+}
 
-	for i := 0; i < L; i++ {
-		point := &points[i]
-		if i != nextZeroIndex {
-			point.x.MulEq(&point.z)
-			point.y.MulEq(&point.z)
-			point.t.MulEq(&point.z)
-			point.z.SetOne()
-		} else {
-			// i is in zeroIndices. We need to treat these cases specially:
-
-			// Ensure next zero index is found:
-			zeroIndicesProcessed++
-			if zeroIndicesProcessed == len(zeroIndices) {
-				nextZeroIndex = L
-			} else {
-				nextZeroIndex = zeroIndices[zeroIndicesProcessed]
-			}
-
-			// We normalize points with Z==0:
-			// NaPs become standard (0:0:0:0)-NaPs
-			if point.IsNaP() {
-				napEncountered("NaP encountered when bulk-normalizing a slice of Point_xtw_subgroup points", false, point)
-				*point = Point_xtw_subgroup{} // standard-NAP
-				continue
-			}
-
-			// Points at infinity are not possible for this point type!
-
-			panic(fmt.Errorf(ErrorPrefix+"Point with Z==0 encountered that was neither a NaP nor a point at inifity. This is not supposed to be possible. The Point in question was %v", *point))
+// BatchNormalizeAffineZ_subgroup is the analogue of BatchNormalizeAffineZ for Point_xtw_subgroup.
+// Since this point type cannot represent points at infinity, the returned indices are those of NaPs (which are set to a standard NaP after calling the NaP handler).
+//
+// The given pointers must not be nil and must be pairwise distinct.
+func BatchNormalizeAffineZ_subgroup(points []*Point_xtw_subgroup) (zeroIndices []int) {
+	bases := make([]*point_xtw_base, len(points))
+	for i := range points {
+		bases[i] = &points[i].point_xtw_base
+	}
+	zeroIndices = batchNormalizeAffineZ(bases)
+	for _, i := range zeroIndices {
+		point := points[i]
+		if !point.IsNaP() {
+			panic(fmt.Errorf(ErrorPrefix+"Point_xtw_subgroup with Z==0 encountered that was not a NaP. Since this point type cannot represent points at infinity, this is not supposed to be possible. The Point in question was %v", *point))
 		}
+		napEncountered("NaP encountered when batch-normalizing Point_xtw_subgroup points", false, point)
+		*point = Point_xtw_subgroup{} // standard NaP
 	}
 	return
 }
@@ -1,8 +1,13 @@
 package curvePoints
 
 import (
+	"errors"
 	"math/rand"
 	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 )
 
 var _ bulkNormalizer = CurvePointSlice_xtw_full{}
@@ -81,3 +86,171 @@ func TestBulkNormalizeAffineZ_subgroup(t *testing.T) {
 	}
 	testMultiAffineZWorks(t, CurvePointSlice_xtw_subgroup(points[:]))
 }
+
+func TestPointsFromAffineXY(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(669))
+	const num = 60
+	xs := make([]FieldElement, num)
+	ys := make([]FieldElement, num)
+	for i := 0; i < num; i++ {
+		var P Point_xtw_full
+		switch i % 3 {
+		case 0: // subgroup point
+			Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+			P.SetFrom(&Q)
+		case 1: // arbitrary point on the curve; only in the subgroup with probability 1/4
+			P = MakeRandomPointUnsafe_xtw_full(drng)
+		}
+		if i%3 == 2 {
+			// off-curve pair (with overwhelming probability)
+			xs[i].SetRandomUnsafe(drng)
+			ys[i].SetRandomUnsafe(drng)
+		} else {
+			xs[i], ys[i] = P.XY_affine()
+		}
+	}
+	// all-zero pair
+	xs[2].SetZero()
+	ys[2].SetZero()
+
+	for _, subgroup := range []bool{false, true} {
+		points, errs := PointsFromAffineXY(xs, ys, untrustedInput, subgroup)
+		if len(points) != num || len(errs) != num {
+			t.Fatalf("PointsFromAffineXY returned slices of wrong length")
+		}
+		for i := 0; i < num; i++ {
+			var expectedErr error
+			var expected Point_axtw_full
+			if subgroup {
+				var expectedSubgroup Point_axtw_subgroup
+				expectedSubgroup, err := CurvePointFromXYAffine_subgroup(&xs[i], &ys[i], untrustedInput)
+				if err == nil {
+					expected.SetFrom(&expectedSubgroup)
+				} else {
+					expectedErr = err
+				}
+			} else {
+				var err errorsWithData.ErrorWithGuaranteedParameters[struct{ X, Y FieldElement }]
+				expected, err = CurvePointFromXYAffine_full(&xs[i], &ys[i], untrustedInput)
+				if err != nil {
+					expectedErr = err
+				}
+			}
+			if (expectedErr == nil) != (errs[i] == nil) {
+				t.Fatalf("PointsFromAffineXY differs from single-point version at index %v: Got error %v, expected %v", i, errs[i], expectedErr)
+			}
+			if expectedErr != nil {
+				for _, sentinel := range []error{bandersnatchErrors.ErrCannotDeserializeXYAllZero, bandersnatchErrors.ErrNotOnCurve, bandersnatchErrors.ErrNotInSubgroup} {
+					if errors.Is(expectedErr, sentinel) != errors.Is(errs[i], sentinel) {
+						t.Fatalf("PointsFromAffineXY gave wrong error at index %v: Got %v, expected %v", i, errs[i], expectedErr)
+					}
+				}
+				if !points[i].IsNaP() {
+					t.Fatalf("PointsFromAffineXY did not set invalid point to NaP")
+				}
+				continue
+			}
+			if !points[i].IsEqual(&expected) {
+				t.Fatalf("PointsFromAffineXY gave wrong point at index %v", i)
+			}
+		}
+	}
+	if !testutils.CheckPanic(PointsFromAffineXY, xs, ys[1:], untrustedInput, false) {
+		t.Fatalf("PointsFromAffineXY did not panic on length mismatch")
+	}
+}
+
+func TestPackCommonZ(t *testing.T) {
+	drng := rand.New(rand.NewSource(1))
+	const size = 50
+	points := make([]Point_xtw_full, size)
+	for i := 0; i < size; i++ {
+		points[i].sampleRandomUnsafe(drng)
+		if i%3 == 0 {
+			points[i].normalizeAffineZ()
+		}
+	}
+	points[5].SetNeutral()
+
+	xs, ys, ts, z, ok := PackCommonZ(points)
+	testutils.FatalUnless(t, ok, "PackCommonZ failed")
+	testutils.FatalUnless(t, len(xs) == size && len(ys) == size && len(ts) == size, "PackCommonZ returned wrong lengths")
+	unpacked := UnpackCommonZ(xs, ys, ts, &z)
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, unpacked[i].z.IsEqual(&z), "unpacked points do not share Z")
+		testutils.FatalUnless(t, unpacked[i].IsEqual(&points[i]), "unpacking did not recover point %v", i)
+		testutils.FatalUnless(t, unpacked[i].Validate(), "unpacked point %v is invalid", i)
+	}
+
+	// empty input
+	xs, _, _, z, ok = PackCommonZ(nil)
+	testutils.FatalUnless(t, ok && len(xs) == 0 && z.IsOne(), "PackCommonZ misbehaves on empty input")
+
+	// points with Z==0 cannot be packed
+	points[7].SetE1()
+	_, _, _, _, ok = PackCommonZ(points)
+	testutils.FatalUnless(t, !ok, "PackCommonZ succeeded for point at infinity")
+
+	testutils.FatalUnless(t, testutils.CheckPanic(UnpackCommonZ, xs, ys, ts, &z), "UnpackCommonZ did not panic on length mismatch")
+}
+
+func TestBatchNormalizeAffineZ(t *testing.T) {
+	drng := rand.New(rand.NewSource(2))
+	const size = 50
+	var points [size]Point_xtw_full
+	var copies [size]Point_xtw_full
+	var ptrs []*Point_xtw_full = make([]*Point_xtw_full, size)
+	for i := 0; i < size; i++ {
+		points[i].sampleRandomUnsafe(drng)
+		points[i].rerandomizeRepresentation(drng)
+		switch i {
+		case 10:
+			points[i] = InfinitePoint1_xtw
+			points[i].rerandomizeRepresentation(drng)
+		case 20:
+			points[i] = InfinitePoint2_xtw
+			points[i].rerandomizeRepresentation(drng)
+		case 30:
+			points[i].normalizeAffineZ() // already normalized
+		}
+		copies[i] = points[i]
+		ptrs[i] = &points[i]
+	}
+	zeroIndices := BatchNormalizeAffineZ(ptrs)
+	testutils.FatalUnless(t, len(zeroIndices) == 2 && zeroIndices[0] == 10 && zeroIndices[1] == 20, "BatchNormalizeAffineZ did not report points at infinity correctly: %v", zeroIndices)
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, points[i].IsEqual(&copies[i]), "BatchNormalizeAffineZ changed the point at index %v", i)
+		if i != 10 && i != 20 {
+			testutils.FatalUnless(t, points[i].z.IsOne(), "BatchNormalizeAffineZ did not normalize Z at index %v", i)
+		}
+	}
+	testutils.FatalUnless(t, points[10].IsE1() && points[20].IsE2(), "")
+	testutils.FatalUnless(t, BatchNormalizeAffineZ(nil) == nil, "")
+
+	// NaPs
+	var nap Point_xtw_full
+	ptrs = []*Point_xtw_full{&points[0], &nap}
+	var zeroIndicesNaP []int
+	wasNaP := wasInvalidPointEncountered(func() { zeroIndicesNaP = BatchNormalizeAffineZ(ptrs) })
+	testutils.FatalUnless(t, wasNaP, "BatchNormalizeAffineZ did not call NaP handler")
+	testutils.FatalUnless(t, len(zeroIndicesNaP) == 1 && zeroIndicesNaP[0] == 1, "")
+
+	// subgroup variant
+	var subgroupPoints [size]Point_xtw_subgroup
+	var subgroupPtrs []*Point_xtw_subgroup = make([]*Point_xtw_subgroup, size+1)
+	for i := 0; i < size; i++ {
+		subgroupPoints[i].sampleRandomUnsafe(drng)
+		subgroupPoints[i].rerandomizeRepresentation(drng)
+		subgroupPtrs[i] = &subgroupPoints[i]
+	}
+	var napSubgroup Point_xtw_subgroup
+	subgroupPtrs[size] = &napSubgroup
+	subgroupCopies := subgroupPoints
+	wasNaP = wasInvalidPointEncountered(func() { zeroIndicesNaP = BatchNormalizeAffineZ_subgroup(subgroupPtrs) })
+	testutils.FatalUnless(t, wasNaP, "BatchNormalizeAffineZ_subgroup did not call NaP handler")
+	testutils.FatalUnless(t, len(zeroIndicesNaP) == 1 && zeroIndicesNaP[0] == size, "")
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, subgroupPoints[i].IsEqual(&subgroupCopies[i]), "BatchNormalizeAffineZ_subgroup changed the point at index %v", i)
+		testutils.FatalUnless(t, subgroupPoints[i].z.IsOne(), "BatchNormalizeAffineZ_subgroup did not normalize Z at index %v", i)
+	}
+}
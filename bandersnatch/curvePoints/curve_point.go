@@ -68,7 +68,7 @@ type CurvePointPtrInterfaceWrite interface {
 	NegEq()                                                     // p.NegEq() is shorthand for p.Neg(p)
 	DoubleEq()                                                  // p.DoubleEq() is shorthand for p.Double(p)
 
-	Endo(CurvePointPtrInterfaceRead) // p.Endo(q) sets p to the result of applying the efficient degree-2 endomorphism of the Bandersnatch curve on q
+	Endo(CurvePointPtrInterfaceRead) // p.Endo(q) sets p to the result of applying the efficient degree-2 endomorphism of the Bandersnatch curve on q. p and q may alias.
 	EndoEq()                         // p.EndoEq() is shorthand for p.Endo(p)
 
 	SetFrom(CurvePointPtrInterfaceRead)                                        // p.SetFrom(q) sets p to (a copy of) the value of q. This is also used to convert between types. Note that it cannot be used to convert from types that store arbitrary curve points to types that only store points on the prime-order subgroup. Use SetFromSubgroupPoint for that.
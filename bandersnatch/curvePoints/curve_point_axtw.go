@@ -350,6 +350,10 @@ func (p *Point_axtw_subgroup) IsEqual(other CurvePointPtrInterfaceRead) bool {
 	if p.IsNaP() || other.IsNaP() {
 		return napEncountered("When comparing an axtw point with another point, a NaP was encountered", true, p, other)
 	}
+	// fast path for self-comparison. Note that NaPs were already handled above.
+	if other == CurvePointPtrInterfaceRead(p) {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_xtw_subgroup:
 		ret, _ := p.isEqual_moduloA_at(&other.point_xtw_base)
@@ -378,6 +382,10 @@ func (p *Point_axtw_full) IsEqual(other CurvePointPtrInterfaceRead) bool {
 	if p.IsNaP() || other.IsNaP() {
 		return napEncountered("When comparing an axtw point with another point, a NaP was encountered", true, p, other)
 	}
+	// fast path for self-comparison. Note that NaPs were already handled above.
+	if other == CurvePointPtrInterfaceRead(p) {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_xtw_subgroup:
 		other.normalizeSubgroup()
@@ -672,7 +680,7 @@ func (p *Point_axtw_full) Neg(input CurvePointPtrInterfaceRead) {
 // a square root of -2.
 func (p *Point_axtw_subgroup) Endo(input CurvePointPtrInterfaceRead) {
 	var temp Point_efgh_subgroup
-	temp.Endo(input)
+	temp.Endo(input) // Note: The result is fully computed before we write to p, so p and input may alias.
 	p.point_axtw_base = temp.toDecaf_axtw()
 }
 
@@ -682,7 +690,7 @@ func (p *Point_axtw_subgroup) Endo(input CurvePointPtrInterfaceRead) {
 // a square root of -2.
 func (p *Point_axtw_full) Endo(input CurvePointPtrInterfaceRead) {
 	var temp Point_efgh_full
-	temp.Endo(input)
+	temp.Endo(input) // Note: The result is fully computed before we write to p, so p and input may alias.
 	p.x, p.y, p.t = temp.XYT_affine()
 }
 
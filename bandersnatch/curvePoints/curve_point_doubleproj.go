@@ -549,6 +549,10 @@ func (p *Point_efgh_subgroup) IsEqual(other CurvePointPtrInterfaceRead) bool {
 	if p.IsNaP() || other.IsNaP() {
 		return napEncountered("NaP encountered when comparing efgh-point with other point", true, p, other)
 	}
+	// fast path for self-comparison. Note that NaPs were already handled above.
+	if other == CurvePointPtrInterfaceRead(p) {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_efgh_subgroup:
 		return p.isEqual_moduloA_ss(&other.point_efgh_base)
@@ -571,6 +575,10 @@ func (p *Point_efgh_full) IsEqual(other CurvePointPtrInterfaceRead) bool {
 	if p.IsNaP() || other.IsNaP() {
 		return napEncountered("NaP encountered when comparing efgh-point with other point", true, p, other)
 	}
+	// fast path for self-comparison. Note that NaPs were already handled above.
+	if other == CurvePointPtrInterfaceRead(p) {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_efgh_subgroup:
 		other.normalizeSubgroup()
@@ -852,7 +860,7 @@ func (p *Point_efgh_subgroup) Endo(input CurvePointPtrInterfaceRead) {
 			p.point_efgh_base = neutralElement_efghbase
 		}
 	case *Point_efgh_subgroup:
-		p.computeEndomorphism_ss(&input.point_efgh_base)
+		p.computeEndomorphism_ss(&input.point_efgh_base) // Note: computeEndomorphism_ss works even if p and input alias.
 	default:
 		ensureSubgroupOnly(input)
 		if input.IsNaP() {
@@ -904,7 +912,7 @@ func (p *Point_efgh_full) Endo(input CurvePointPtrInterfaceRead) {
 	case *Point_axtw_full:
 		p.computeEndomorphism_sa(&input.point_axtw_base)
 	case *Point_efgh_full:
-		p.computeEndomorphism_ss(&input.point_efgh_base)
+		p.computeEndomorphism_ss(&input.point_efgh_base) // Note: computeEndomorphism_ss works even if p and input alias.
 	case *Point_efgh_subgroup:
 		p.computeEndomorphism_ss(&input.point_efgh_base)
 	default:
@@ -186,3 +186,17 @@ func (output *point_xtw_base) computeEndomorphism_ts(input *point_efgh_base) {
 	output.z.Mul(&F, &G)
 }
 */
+
+// IsEndoRelated checks whether q == Endo(p) or p == Endo(q).
+//
+// This is meant as a diagnostic, e.g. for verifying GLV decompositions. It is not optimized for speed.
+// If p or q is a NaP, this behaves like IsEqual does on NaPs.
+func IsEndoRelated(p, q *Point_xtw_subgroup) bool {
+	var temp Point_xtw_subgroup
+	temp.Endo(p)
+	if temp.IsEqual(q) {
+		return true
+	}
+	temp.Endo(q)
+	return temp.IsEqual(p)
+}
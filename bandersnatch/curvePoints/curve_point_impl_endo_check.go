@@ -0,0 +1,38 @@
+package curvePoints
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEndomorphismMismatch is returned (wrapped) by VerifyEndomorphismBatch if Endo does not act as expected.
+var ErrEndomorphismMismatch = errors.New(ErrorPrefix + "the endomorphism does not act as multiplication by EndomorphismEigenvalue")
+
+// VerifyEndomorphismBatch is a self-check for the efficient degree-2 endomorphism, meant for tests (including downstream ones).
+// For each given point P, it checks that
+//   - Endo(P) == EndomorphismEigenvalue * P, where the right-hand side is computed via ScalarMult
+//   - Endo(Endo(P)) == -2 * P, since EndomorphismEigenvalue^2 == -2 modulo the group order.
+//
+// It returns nil if all checks pass and an error wrapping ErrEndomorphismMismatch (which includes the index of the offending point) otherwise.
+// The points must not be NaPs; we panic otherwise.
+func VerifyEndomorphismBatch(points []Point_xtw_subgroup) error {
+	for i := range points {
+		P := &points[i]
+		if P.IsNaP() {
+			panic(fmt.Errorf(ErrorPrefix+"VerifyEndomorphismBatch called with NaP at index %v", i))
+		}
+		var endoP, expected Point_xtw_subgroup
+		endoP.Endo(P)
+		expected.ScalarMult(P, EndomorphismEigenvalue_Int)
+		if !endoP.IsEqual(&expected) {
+			return fmt.Errorf("%w: Endo(P) != EndomorphismEigenvalue * P for the point at index %v", ErrEndomorphismMismatch, i)
+		}
+		endoP.EndoEq()
+		expected.Double(P)
+		expected.NegEq()
+		if !endoP.IsEqual(&expected) {
+			return fmt.Errorf("%w: Endo(Endo(P)) != -2 * P for the point at index %v", ErrEndomorphismMismatch, i)
+		}
+	}
+	return nil
+}
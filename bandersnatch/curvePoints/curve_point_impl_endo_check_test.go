@@ -0,0 +1,42 @@
+package curvePoints
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestVerifyEndomorphismBatch(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const num = 50
+	points := make([]Point_xtw_subgroup, num)
+	for i := 0; i < num; i++ {
+		points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	points[0].SetNeutral()
+	err := VerifyEndomorphismBatch(points)
+	testutils.FatalUnless(t, err == nil, "VerifyEndomorphismBatch failed: %v", err)
+	testutils.FatalUnless(t, VerifyEndomorphismBatch(nil) == nil, "VerifyEndomorphismBatch failed on empty input")
+
+	var nap Point_xtw_subgroup
+	testutils.FatalUnless(t, testutils.CheckPanic(VerifyEndomorphismBatch, []Point_xtw_subgroup{nap}), "VerifyEndomorphismBatch did not panic on NaP")
+}
+
+// TestEndomorphismVersusScalarMult checks the documented relation between Endo, ScalarMult and EndomorphismEigenvalue directly.
+func TestEndomorphismVersusScalarMult(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var endoP, lambdaP Point_xtw_subgroup
+		endoP.Endo(&P)
+		lambdaP.ScalarMult(&P, EndomorphismEigenvalue_Int)
+		testutils.FatalUnless(t, endoP.IsEqual(&lambdaP), "Endo(P) != EndomorphismEigenvalue * P")
+
+		var endoEndoP, minusTwoP Point_xtw_subgroup
+		endoEndoP.Endo(&endoP)
+		minusTwoP.Double(&P)
+		minusTwoP.NegEq()
+		testutils.FatalUnless(t, endoEndoP.IsEqual(&minusTwoP), "Endo(Endo(P)) != -2P")
+	}
+}
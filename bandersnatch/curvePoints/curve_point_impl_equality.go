@@ -325,3 +325,31 @@ func (p *point_efgh_base) isEqual_moduloA_sany(other CurvePointPtrInterfaceRead)
 	other_y.MulEq(&p.f)
 	return other_x.IsEqual(&other_y)
 }
+
+// CofactorDifference determines by which point of order at most 2 the points a and b differ.
+// The first return value is 0 if b == a, 1 if b == a + A, 2 if b == a + E1 and 3 if b == a + E2, where A is the affine point of order two and E1, E2 are the points at infinity.
+// If b - a is not a point of order at most 2 (or one of the inputs is a NaP), we return (0, false).
+//
+// Points of a type that can only represent subgroup elements are interpreted as the (unique) subgroup element they represent.
+// This is intended as a diagnostic for debugging representation mismatches; it is not optimized for speed.
+func CofactorDifference(a, b CurvePointPtrInterfaceRead) (int, bool) {
+	if a.IsNaP() || b.IsNaP() {
+		return 0, false
+	}
+	var diff Point_xtw_full
+	diff.Sub(b, a)
+	switch {
+	case diff.IsNaP():
+		return 0, false // not supposed to happen
+	case diff.IsE1():
+		return 2, true
+	case diff.IsE2():
+		return 3, true
+	case diff.IsNeutralElement():
+		return 0, true
+	case diff.IsEqual(&AffineOrderTwoPoint_xtw):
+		return 1, true
+	default:
+		return 0, false
+	}
+}
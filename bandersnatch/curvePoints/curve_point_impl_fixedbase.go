@@ -0,0 +1,155 @@
+package curvePoints
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+)
+
+// This file contains precomputed tables for scalar multiplication with a fixed base point.
+
+// FixedBaseTable holds precomputed multiples of a fixed base point in the prime-order subgroup,
+// allowing to compute scalar * base with only additions (and no doublings).
+//
+// For windowBits == w, the table consists of ceil(GroupOrderBitLength / w) windows with 2^w entries each,
+// where entry j of window i is j * 2^(w*i) * base.
+//
+// A FixedBaseTable is never modified after creation. Note however that reading from points may modify their internal representation,
+// so a FixedBaseTable must not be used concurrently from several goroutines.
+//
+// The zero value is not a valid FixedBaseTable; use NewFixedBaseTable to create one.
+type FixedBaseTable struct {
+	windowBits uint
+	table      [][]Point_xtw_subgroup
+}
+
+// maxFixedBaseTableWindowBits is the maximal allowed window size for FixedBaseTable.
+// For 8 bits, the table already consists of 32*256 points, which is about 1 MB.
+const maxFixedBaseTableWindowBits = 8
+
+// NewFixedBaseTable precomputes a FixedBaseTable for the given base point, which must be in the prime-order subgroup (we panic otherwise).
+// windowBits must be between 1 and 8.
+func NewFixedBaseTable(base CurvePointPtrInterfaceRead, windowBits uint) *FixedBaseTable {
+	if windowBits == 0 || windowBits > maxFixedBaseTableWindowBits {
+		panic(fmt.Errorf(ErrorPrefix+"NewFixedBaseTable called with invalid windowBits == %v. Must be between 1 and %v", windowBits, maxFixedBaseTableWindowBits))
+	}
+	var windowBase Point_xtw_subgroup
+	if !windowBase.SetFromSubgroupPoint(base, untrustedInput) {
+		panic(ErrorPrefix + "NewFixedBaseTable called with base point that is not in the subgroup")
+	}
+	numWindows := (uint(GroupOrder_Int.BitLen()) + windowBits - 1) / windowBits
+	tableSize := 1 << windowBits
+	ret := FixedBaseTable{windowBits: windowBits, table: make([][]Point_xtw_subgroup, numWindows)}
+	for i := uint(0); i < numWindows; i++ {
+		window := make([]Point_xtw_subgroup, tableSize)
+		window[0].SetNeutral()
+		for j := 1; j < tableSize; j++ {
+			window[j].Add(&window[j-1], &windowBase)
+		}
+		ret.table[i] = window
+		// windowBase = 2^(windowBits*(i+1)) * base
+		for k := uint(0); k < windowBits; k++ {
+			windowBase.DoubleEq()
+		}
+	}
+	return &ret
+}
+
+// WindowBits returns the window size of the table.
+func (table *FixedBaseTable) WindowBits() uint {
+	return table.windowBits
+}
+
+// digits returns the base-2^windowBits digits of scalar modulo the group order, least significant digit first.
+// The number of digits is always equal to the number of windows.
+func (table *FixedBaseTable) digits(scalar *big.Int) []int {
+	var exponent big.Int
+	ReduceScalar(&exponent, scalar)
+	ret := make([]int, len(table.table))
+	for i := range ret {
+		var digit int
+		for k := int(table.windowBits) - 1; k >= 0; k-- {
+			digit = (digit << 1) | int(exponent.Bit(i*int(table.windowBits)+k))
+		}
+		ret[i] = digit
+	}
+	return ret
+}
+
+// Mul returns scalar * base, where base is the point the table was created for. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// NOTE: This indexes the table with the digits of the scalar and skips zero digits. It is thus not constant-time and
+// leaks information about the scalar via timing and cache access patterns; use MulCT for secret scalars.
+func (table *FixedBaseTable) Mul(scalar *big.Int) (ret Point_xtw_subgroup) {
+	ret.SetNeutral()
+	for i, digit := range table.digits(scalar) {
+		if digit != 0 {
+			ret.AddEq(&table.table[i][digit])
+		}
+	}
+	return
+}
+
+// MulCT returns scalar * base, where base is the point the table was created for. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// As opposed to Mul, this is meant to be used with secret scalars: For each window, we linearly scan all entries of the table and select
+// the required one via masking, so the memory access pattern and the sequence of curve operations do not depend on the scalar.
+// This comes at a cost: For windowBits == w, each window requires 2^w conditional copies of a point instead of a single lookup,
+// so MulCT is slower than Mul by a factor that grows with the window size. For small w, the curve additions dominate and the overhead is moderate.
+//
+// NOTE: The reduction of scalar modulo the group order and the digit extraction are done with big.Int, which makes no constant-time guarantees.
+func (table *FixedBaseTable) MulCT(scalar *big.Int) (ret Point_xtw_subgroup) {
+	ret.SetNeutral()
+	var selected Point_xtw_subgroup
+	for i, digit := range table.digits(scalar) {
+		window := table.table[i]
+		for j := range window {
+			selected.condSet(&window[j].point_xtw_base, subtle.ConstantTimeEq(int32(j), int32(digit)))
+		}
+		ret.AddEq(&selected) // Note: We add even if digit == 0, in which case selected is the neutral element.
+	}
+	return
+}
+
+// condSet sets p = x if choice == 1 and leaves p unchanged if choice == 0, without data-dependent branches.
+// The behaviour is unspecified if choice is neither 0 nor 1.
+func (p *point_xtw_base) condSet(x *point_xtw_base, choice int) {
+	p.x.CondSet(&x.x, choice)
+	p.y.CondSet(&x.y, choice)
+	p.t.CondSet(&x.t, choice)
+	p.z.CondSet(&x.z, choice)
+}
+
+// CondSelect sets dst = a if choice == 0 and dst = b if choice == 1.
+//
+// This is done with arithmetic on the coordinates only, without data-dependent branches or memory access patterns, so it can be used for side-channel resistant table lookups with secret choice.
+// The internal representation of the selected point is copied as is. The behaviour is unspecified if choice is neither 0 nor 1. Any of dst, a, b may alias.
+func CondSelect(dst *Point_xtw_subgroup, a, b *Point_xtw_subgroup, choice int) {
+	dst.x.CondSelect(&a.x, &b.x, choice)
+	dst.y.CondSelect(&a.y, &b.y, choice)
+	dst.t.CondSelect(&a.t, &b.t, choice)
+	dst.z.CondSelect(&a.z, &b.z, choice)
+}
+
+// DoublingTable returns the k+1 points p, 2p, 4p, ..., 2^k * p (i.e. entry i is 2^i * p). k must be non-negative (we panic otherwise).
+//
+// This is a building block for tables used in fixed-base or windowed scalar multiplication.
+// The doublings are performed in a single double-projective working representation and all entries are then normalized to Z==1 with a single batch inversion.
+func (p *Point_xtw_subgroup) DoublingTable(k int) []Point_xtw_subgroup {
+	if k < 0 {
+		panic(fmt.Errorf(ErrorPrefix+"DoublingTable called with negative k == %v", k))
+	}
+	ret := make([]Point_xtw_subgroup, k+1)
+	ret[0] = *p
+	var working point_efgh_base
+	for i := 1; i <= k; i++ {
+		if i == 1 {
+			working.double_st(&p.point_xtw_base)
+		} else {
+			working.double_ss(&working)
+		}
+		ret[i].point_xtw_base = working.toDecaf_xtw()
+	}
+	CurvePointSlice_xtw_subgroup(ret).NormalizeSlice()
+	return ret
+}
@@ -0,0 +1,124 @@
+package curvePoints
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/callcounters"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestFixedBaseTable(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for _, windowBits := range []uint{1, 3, 4, 8} {
+		base := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		table := NewFixedBaseTable(&base, windowBits)
+		testutils.FatalUnless(t, table.WindowBits() == windowBits, "WindowBits returns wrong value")
+
+		scalars := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(255), new(big.Int).Sub(GroupOrder_Int, big.NewInt(1)), GroupOrder_Int}
+		for i := 0; i < 10; i++ {
+			scalars = append(scalars, new(big.Int).Rand(drng, GroupOrder_Int))
+		}
+		for _, scalar := range scalars {
+			var expected Point_xtw_subgroup
+			expected.ScalarMult(&base, scalar)
+			result := table.Mul(scalar)
+			testutils.FatalUnless(t, result.IsEqual(&expected), "FixedBaseTable.Mul differs from ScalarMult for windowBits == %v", windowBits)
+			resultCT := table.MulCT(scalar)
+			testutils.FatalUnless(t, resultCT.IsEqual(&expected), "FixedBaseTable.MulCT differs from ScalarMult for windowBits == %v", windowBits)
+			testutils.FatalUnless(t, resultCT.IsEqual(&result), "FixedBaseTable.MulCT differs from Mul for windowBits == %v", windowBits)
+		}
+	}
+	base := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	testutils.FatalUnless(t, testutils.CheckPanic(NewFixedBaseTable, &base, uint(0)), "NewFixedBaseTable did not panic for windowBits == 0")
+	testutils.FatalUnless(t, testutils.CheckPanic(NewFixedBaseTable, &base, uint(maxFixedBaseTableWindowBits+1)), "NewFixedBaseTable did not panic for too large windowBits")
+}
+
+func TestCondSetPoint(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	PCopy := P
+	P.condSet(&Q.point_xtw_base, 0)
+	testutils.FatalUnless(t, P == PCopy, "condSet with choice 0 modified the receiver")
+	P.condSet(&Q.point_xtw_base, 1)
+	testutils.FatalUnless(t, P == Q, "condSet with choice 1 did not copy")
+}
+
+func TestCondSelect(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	PCopy, QCopy := P, Q
+	var R Point_xtw_subgroup
+	CondSelect(&R, &P, &Q, 0)
+	testutils.FatalUnless(t, R == P, "CondSelect with choice 0 did not select the first point")
+	CondSelect(&R, &P, &Q, 1)
+	testutils.FatalUnless(t, R == Q, "CondSelect with choice 1 did not select the second point")
+	testutils.FatalUnless(t, P == PCopy && Q == QCopy, "CondSelect modified its arguments")
+
+	// aliasing
+	CondSelect(&P, &P, &Q, 1)
+	testutils.FatalUnless(t, P == Q, "CondSelect with aliasing arguments failed")
+	P = PCopy
+	CondSelect(&Q, &P, &Q, 0)
+	testutils.FatalUnless(t, Q == P, "CondSelect with aliasing arguments failed")
+	Q = QCopy
+
+	// Both choices must perform the same work: neither allocates and, if call counters are active, both perform exactly the same (counted) field operations.
+	var counters [2]map[string]int
+	for _, choice := range []int{0, 1} {
+		allocs := testing.AllocsPerRun(10, func() { CondSelect(&R, &P, &Q, choice) })
+		testutils.FatalUnless(t, allocs == 0, "CondSelect allocated for choice %v", choice)
+		callcounters.ResetAllCounters()
+		CondSelect(&R, &P, &Q, choice)
+		counters[choice] = make(map[string]int)
+		for _, report := range callcounters.ReportCallCounters(false, false) {
+			counters[choice][report.Tag] = report.Calls
+		}
+	}
+	if CallCountersActive {
+		testutils.FatalUnless(t, counters[0]["CondSelectFe"] > 0, "CondSelect did not perform any counted conditional selections of field elements")
+		testutils.FatalUnless(t, reflect.DeepEqual(counters[0], counters[1]), "CondSelect performs different operations for choice 0 and 1: %v vs. %v", counters[0], counters[1])
+	} else {
+		t.Log("Call counters inactive; not checking that CondSelect performs the same operations for both choices")
+	}
+	R = Point_xtw_subgroup{}
+	CondSelect(&R, &NeutralElement_xtw_subgroup, &NeutralElement_xtw_subgroup, 0)
+	testutils.FatalUnless(t, R == NeutralElement_xtw_subgroup, "CondSelect did not overwrite the receiver completely")
+}
+
+func BenchmarkFixedBaseTable(b *testing.B) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	base := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	scalar := new(big.Int).Rand(drng, GroupOrder_Int)
+	table := NewFixedBaseTable(&base, 4)
+	b.Run("Mul", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			table.Mul(scalar)
+		}
+	})
+	b.Run("MulCT", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			table.MulCT(scalar)
+		}
+	})
+}
+
+func TestDoublingTable(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	for _, k := range []int{0, 1, 2, 10, 260} {
+		table := P.DoublingTable(k)
+		testutils.FatalUnless(t, len(table) == k+1, "DoublingTable returned wrong length")
+		for i := range table {
+			var expected Point_xtw_subgroup
+			expected.ScalarMult(&P, new(big.Int).Lsh(big.NewInt(1), uint(i)))
+			testutils.FatalUnless(t, table[i].IsEqual(&expected), "DoublingTable entry %v differs from ScalarMult for k == %v", i, k)
+			testutils.FatalUnless(t, table[i].z.IsOne(), "DoublingTable entry %v is not normalized", i)
+		}
+	}
+	testutils.FatalUnless(t, testutils.CheckPanic(P.DoublingTable, -1), "DoublingTable did not panic for negative k")
+}
@@ -0,0 +1,85 @@
+package curvePoints
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// This file contains helpers for inner-product-argument (IPA) style protocols.
+
+var (
+	ErrInnerProductLengthMismatch = errors.New(ErrorPrefix + "InnerProductPoint called with different numbers of scalars and points")
+	ErrInnerProductEmpty          = errors.New(ErrorPrefix + "InnerProductPoint called with no scalars and points")
+)
+
+// InnerProductPoint computes result = sum_i scalars[i] * points[i], i.e. the inner product of the vector of scalars with the vector of points.
+// This is MultiScalarMult (with the same argument order) with stricter validation; scalars may be negative and are reduced modulo GroupOrder_Int.
+//
+// As opposed to a general multi-scalar multiplication, we require the vectors to be non-empty and of equal length, since anything else is a bug in an inner-product protocol.
+// On failure, we return an error wrapping ErrInnerProductLengthMismatch or ErrInnerProductEmpty and result is untouched.
+//
+// NOTE: This is not constant-time.
+func InnerProductPoint(result *Point_xtw_subgroup, points []Point_xtw_subgroup, scalars []*big.Int) error {
+	if len(scalars) != len(points) {
+		return fmt.Errorf("%w: got %v points and %v scalars", ErrInnerProductLengthMismatch, len(points), len(scalars))
+	}
+	if len(scalars) == 0 {
+		return ErrInnerProductEmpty
+	}
+	return MultiScalarMult(result, points, scalars)
+}
+
+// FoldPoints returns the vector with entries a[i] + challenge * b[i]. This is the recursion step of inner product arguments, where both halves of a vector of points are folded into one.
+// challenge may be negative and is reduced modulo GroupOrder_Int.
+//
+// a and b must have the same length; we panic otherwise. a and b are not modified. If a[i] or b[i] is a NaP, the i'th output is a NaP (after calling the NaP handler).
+//
+// We batch the computation over all entries: We bring all a[i] and b[i] into affine form with a single field inversion (Montgomery's trick),
+// reduce challenge only once and then do the double-and-add steps for all entries in lockstep over the bits of challenge, using the cheaper mixed additions with affine points.
+// NOTE: This is not constant-time in challenge, which is fine for (public) Fiat-Shamir challenges.
+func FoldPoints(a, b []Point_xtw_subgroup, challenge *big.Int) []Point_xtw_subgroup {
+	L := len(a)
+	if len(b) != L {
+		panic(fmt.Errorf(ErrorPrefix+"FoldPoints called with slices of different lengths: len(a) == %v, len(b) == %v", L, len(b)))
+	}
+	var exponent big.Int
+	ReduceScalar(&exponent, challenge)
+
+	// inputs[0:L] is a copy of a, inputs[L:2L] is a copy of b.
+	inputs := make([]Point_xtw_subgroup, 2*L)
+	copy(inputs, a)
+	copy(inputs[L:], b)
+	bases := make([]*point_xtw_base, 2*L)
+	for i := range inputs {
+		bases[i] = &inputs[i].point_xtw_base
+	}
+	// Since subgroup points cannot be at infinity, only NaPs have Z==0.
+	napIndices := batchNormalizeAffineZ(bases)
+	affine := make([]point_axtw_base, 2*L)
+	for i := range inputs {
+		affine[i].x, affine[i].y, affine[i].t = inputs[i].x, inputs[i].y, inputs[i].t
+	}
+
+	results := make([]Point_xtw_subgroup, L)
+	for i := 0; i < L; i++ {
+		results[i].SetNeutral()
+	}
+	for bit := exponent.BitLen() - 1; bit >= 0; bit-- {
+		set := exponent.Bit(bit) == 1
+		for i := 0; i < L; i++ {
+			results[i].DoubleEq()
+			if set {
+				results[i].add_tta(&results[i].point_xtw_base, &affine[L+i])
+			}
+		}
+	}
+	for i := 0; i < L; i++ {
+		results[i].add_tta(&results[i].point_xtw_base, &affine[i])
+	}
+	for _, i := range napIndices {
+		napEncountered("NaP encountered in FoldPoints", false, &inputs[i])
+		results[i%L] = Point_xtw_subgroup{}
+	}
+	return results
+}
@@ -0,0 +1,89 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestInnerProductPoint(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const L = 8
+	points := make([]Point_xtw_subgroup, L)
+	scalars := make([]*big.Int, L)
+	var expected Point_xtw_subgroup
+	expected.SetNeutral()
+	for i := 0; i < L; i++ {
+		points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		scalars[i] = new(big.Int).Rand(drng, GroupOrder_Int)
+		if i == 3 {
+			scalars[i].Neg(scalars[i])
+		}
+		var summand Point_xtw_subgroup
+		summand.ScalarMult(&points[i], scalars[i])
+		expected.AddEq(&summand)
+	}
+	var result Point_xtw_subgroup
+	err := InnerProductPoint(&result, points, scalars)
+	testutils.FatalUnless(t, err == nil, "InnerProductPoint failed: %v", err)
+	testutils.FatalUnless(t, result.IsEqual(&expected), "InnerProductPoint gave wrong result")
+
+	// errors leave result untouched
+	result.SetNeutral()
+	err = InnerProductPoint(&result, points, scalars[0:3])
+	testutils.FatalUnless(t, errors.Is(err, ErrInnerProductLengthMismatch), "Unexpected error %v", err)
+	err = InnerProductPoint(&result, nil, nil)
+	testutils.FatalUnless(t, errors.Is(err, ErrInnerProductEmpty), "Unexpected error %v", err)
+	testutils.FatalUnless(t, result.IsNeutralElement(), "InnerProductPoint modified result on error")
+}
+
+func TestFoldPoints(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const L = 10
+	a := make([]Point_xtw_subgroup, L)
+	b := make([]Point_xtw_subgroup, L)
+	for i := 0; i < L; i++ {
+		a[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		b[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	b[2].SetNeutral()
+	a[3].SetNeutral()
+
+	randomChallenge := new(big.Int).Rand(drng, GroupOrder_Int)
+	largeChallenge := new(big.Int).Add(randomChallenge, GroupOrder_Int)
+	for _, challenge := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(12345), randomChallenge, largeChallenge} {
+		folded := FoldPoints(a, b, challenge)
+		testutils.FatalUnless(t, len(folded) == L, "")
+		for i := 0; i < L; i++ {
+			var expected Point_xtw_subgroup
+			expected.ScalarMult(&b[i], challenge)
+			expected.AddEq(&a[i])
+			testutils.FatalUnless(t, folded[i].IsEqual(&expected), "FoldPoints gave wrong result at index %v for challenge %v", i, challenge)
+		}
+	}
+
+	// inputs are not modified (FoldPoints normalizes internal copies)
+	aCopy, bCopy := append([]Point_xtw_subgroup{}, a...), append([]Point_xtw_subgroup{}, b...)
+	_ = FoldPoints(a, b, randomChallenge)
+	for i := 0; i < L; i++ {
+		testutils.FatalUnless(t, a[i] == aCopy[i] && b[i] == bCopy[i], "FoldPoints modified its input at index %v", i)
+	}
+
+	// NaPs only affect their own entry
+	var nap Point_xtw_subgroup
+	aWithNaP := append([]Point_xtw_subgroup{}, a...)
+	aWithNaP[4] = nap
+	bWithNaP := append([]Point_xtw_subgroup{}, b...)
+	bWithNaP[6] = nap
+	folded := FoldPoints(aWithNaP, bWithNaP, randomChallenge)
+	for i := 0; i < L; i++ {
+		testutils.FatalUnless(t, folded[i].IsNaP() == (i == 4 || i == 6), "FoldPoints gave unexpected NaP status at index %v", i)
+	}
+
+	testutils.FatalUnless(t, len(FoldPoints(nil, nil, big.NewInt(5))) == 0, "")
+	didPanic := testutils.CheckPanic(FoldPoints, a, b[0:L-1], big.NewInt(5))
+	testutils.FatalUnless(t, didPanic, "FoldPoints did not panic on length mismatch")
+}
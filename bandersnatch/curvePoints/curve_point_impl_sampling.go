@@ -1,6 +1,8 @@
 package curvePoints
 
 import (
+	"io"
+	"math/big"
 	"math/rand"
 )
 
@@ -41,6 +43,42 @@ func makeRandomPointOnCurve_t(rnd *rand.Rand) (ret point_xtw_base) {
 	return
 }
 
+// RandomFullCurvePoint samples a uniformly random rational point on the Bandersnatch curve, reading randomness from rnd (e.g. crypto/rand.Reader).
+// As opposed to the sampling functions used in tests, the quality of the output depends only on rnd.
+//
+// The output need not be in the prime-order subgroup: Since the curve has cofactor 4, only about 1/4 of the outputs are in the subgroup.
+// We sample a random x coordinate, recover y if possible (rejection sampling) and pick a random sign for y.
+// Note that this never outputs one of the two points at infinity, which deviates from the uniform distribution only negligibly.
+//
+// The only possible errors are errors from reading rnd.
+func RandomFullCurvePoint(rnd io.Reader) (ret Point_xtw_full, err error) {
+	// We read 64 bytes for x, so the bias from reducing modulo BaseFieldSize is negligible, and one extra byte for the sign of y.
+	var buf [65]byte
+	var xInt big.Int
+	var x, y FieldElement
+	for {
+		_, err = io.ReadFull(rnd, buf[:])
+		if err != nil {
+			return
+		}
+		xInt.SetBytes(buf[0:64])
+		x.SetBigInt(&xInt)
+		var errRecover error
+		y, errRecover = recoverYFromXAffine(&x, false)
+		if errRecover == nil {
+			break
+		}
+	}
+	if buf[64]&1 == 1 {
+		y.NegEq()
+	}
+	ret.x = x
+	ret.y = y
+	ret.t.Mul(&x, &y)
+	ret.z.SetOne()
+	return
+}
+
 func (p *point_xtw_base) sampleNaP(rnd *rand.Rand, index int) {
 	p.x.SetZero()
 	p.y.SetZero()
@@ -117,3 +155,32 @@ func (p *point_efgh_base) sampleNaP(rnd *rand.Rand, index int) {
 		other2.SetRandomUnsafe(rnd)
 	}
 }
+
+// GenerateKeypair samples a uniformly random secret scalar sk in 1 <= sk < GroupOrder_Int and computes the corresponding public point pk = sk * G,
+// where G is SubgroupGenerator_xtw_subgroup. Randomness is read from rnd (e.g. crypto/rand.Reader).
+//
+// We use rejection sampling, so sk is exactly uniform (given uniform randomness from rnd); in particular, we never output sk == 0.
+// pk is computed with ScalarMultCT, since sk is secret.
+//
+// The only possible errors are errors from reading rnd. In this case, sk is nil and pk must not be used.
+func GenerateKeypair(rnd io.Reader) (sk *big.Int, pk Point_xtw_subgroup, err error) {
+	bitLen := GroupOrder_Int.BitLen()
+	var buf [32]byte // GroupOrder_Int has 253 bits
+	// mask for the most significant byte, such that we read exactly bitLen many bits.
+	var topMask byte = 0xFF >> (8*len(buf) - bitLen)
+	sk = new(big.Int)
+	for {
+		_, err = io.ReadFull(rnd, buf[:])
+		if err != nil {
+			sk = nil
+			return
+		}
+		buf[0] &= topMask
+		sk.SetBytes(buf[:])
+		if sk.Sign() != 0 && sk.Cmp(GroupOrder_Int) < 0 {
+			break
+		}
+	}
+	pk.ScalarMultCT(&SubgroupGenerator_xtw_subgroup, sk)
+	return
+}
@@ -0,0 +1,76 @@
+package curvePoints
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestRandomFullCurvePoint(t *testing.T) {
+	const numSamples = 400
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	var inSubgroup, inSubgroupModA int
+	for i := 0; i < numSamples; i++ {
+		P, err := RandomFullCurvePoint(drng)
+		testutils.FatalUnless(t, err == nil, "RandomFullCurvePoint failed: %v", err)
+		testutils.FatalUnless(t, P.Validate(), "RandomFullCurvePoint output is not on the curve")
+		testutils.FatalUnless(t, !P.IsAtInfinity(), "")
+		if P.IsInSubgroup() {
+			inSubgroup++
+		}
+		if legendreCheckA_affineX(P.X_affine()) {
+			inSubgroupModA++
+		}
+	}
+	// We expect 1/4 of the samples to be in the subgroup and 1/2 to be of the form P or P+A with P in the subgroup.
+	testutils.FatalUnless(t, inSubgroup > numSamples/8 && inSubgroup < numSamples*3/8, "Unexpected number of subgroup points: %v out of %v", inSubgroup, numSamples)
+	testutils.FatalUnless(t, inSubgroupModA > numSamples*3/8 && inSubgroupModA < numSamples*5/8, "Unexpected number of points in subgroup + A: %v out of %v", inSubgroupModA, numSamples)
+
+	// read errors are reported
+	_, err := RandomFullCurvePoint(bytes.NewReader(make([]byte, 10)))
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Unexpected error %v", err)
+}
+
+func TestGenerateKeypair(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(670))
+	for i := 0; i < 20; i++ {
+		sk, pk, err := GenerateKeypair(drng)
+		if err != nil {
+			t.Fatalf("GenerateKeypair failed: %v", err)
+		}
+		if sk.Sign() <= 0 || sk.Cmp(GroupOrder_Int) >= 0 {
+			t.Fatalf("GenerateKeypair output secret scalar %v out of range", sk)
+		}
+		var expected Point_xtw_subgroup
+		expected.ScalarMult(&SubgroupGenerator_xtw_subgroup, sk)
+		if !pk.IsEqual(&expected) {
+			t.Fatalf("GenerateKeypair output pk != sk * G")
+		}
+	}
+
+	// Zero and out-of-range candidates are rejected: We feed all-zero bytes, then 0xFF...FF (which is > GroupOrder even after masking), then a valid scalar 1.
+	var input []byte
+	input = append(input, make([]byte, 32)...)
+	input = append(input, bytes.Repeat([]byte{0xFF}, 32)...)
+	one := make([]byte, 32)
+	one[31] = 1
+	input = append(input, one...)
+	sk, pk, err := GenerateKeypair(bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+	if sk.Cmp(big.NewInt(1)) != 0 || !pk.IsEqual(&SubgroupGenerator_xtw_subgroup) {
+		t.Fatalf("GenerateKeypair did not reject invalid candidates correctly")
+	}
+
+	// errors from the reader are reported.
+	_, _, err = GenerateKeypair(bytes.NewReader(make([]byte, 40)))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("GenerateKeypair did not report error from reader. Got %v", err)
+	}
+}
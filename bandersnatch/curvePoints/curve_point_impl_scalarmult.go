@@ -0,0 +1,570 @@
+package curvePoints
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/exponents"
+)
+
+// This file contains routines for scalar multiplication of curve points.
+
+// ScalarMult computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+//
+// We use a 4-bit fixed window double-and-add algorithm.
+// The trivial cases scalar == 0, scalar == 1 (both modulo GroupOrder_Int) and input == neutral element are handled without running the double-and-add loop.
+//
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_xtw_subgroup) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var base Point_xtw_subgroup
+	if !base.SetFromSubgroupPoint(input, untrustedInput) {
+		panic(ErrorPrefix + "ScalarMult called on Point_xtw_subgroup with input that is not in the subgroup")
+	}
+	var exponent big.Int
+	ReduceScalar(&exponent, scalar)
+	if exponent.Sign() == 0 || base.IsNeutralElement() {
+		p.SetNeutral()
+		return
+	}
+	if exponent.BitLen() == 1 { // exponent == 1
+		*p = base
+		return
+	}
+	table := scalarMultTable(&base)
+	numWindows := (exponent.BitLen() + scalarMultWindowBits - 1) / scalarMultWindowBits
+	var result Point_xtw_subgroup
+	result.SetNeutral()
+	for i := numWindows - 1; i >= 0; i-- {
+		for j := 0; j < scalarMultWindowBits; j++ {
+			result.DoubleEq()
+		}
+		if window := scalarMultWindow(&exponent, i); window != 0 {
+			result.AddEq(&table[window])
+		}
+	}
+	*p = result
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+//
+// As opposed to ScalarMult, this is meant to be used with secret scalars: We always perform GroupOrder_Int.BitLen() many doublings and additions,
+// where the point to be added (input or the neutral element) is selected via masking. In particular, there are no shortcuts for trivial scalars or inputs.
+//
+// NOTE: The reduction of scalar modulo the group order and the bit extraction are done with big.Int, which makes no constant-time guarantees.
+func (p *Point_xtw_subgroup) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var base Point_xtw_subgroup
+	if !base.SetFromSubgroupPoint(input, untrustedInput) {
+		panic(ErrorPrefix + "ScalarMultCT called on Point_xtw_subgroup with input that is not in the subgroup")
+	}
+	var exponent big.Int
+	ReduceScalar(&exponent, scalar)
+	var result, addend Point_xtw_subgroup
+	result.SetNeutral()
+	for i := GroupOrder_Int.BitLen() - 1; i >= 0; i-- {
+		result.DoubleEq()
+		addend.SetNeutral()
+		addend.condSet(&base.point_xtw_base, int(exponent.Bit(i)))
+		result.AddEq(&addend)
+	}
+	*p = result
+}
+
+// ScalarMultGLV computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+//
+// This gives the same result as ScalarMult, but uses the GLV method: We decompose scalar == k1 + k2 * EndomorphismEigenvalue mod GroupOrder with |k1|, |k2| < 2^126
+// (see exponents.GLVDecompose) and compute k1 * input + k2 * Endo(input) with an interleaved double-and-add (Shamir's trick).
+// This roughly halves the number of doublings compared to ScalarMult.
+//
+// NOTE: This is not constant-time.
+func (p *Point_xtw_subgroup) ScalarMultGLV(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var base Point_xtw_subgroup
+	if !base.SetFromSubgroupPoint(input, untrustedInput) {
+		panic(ErrorPrefix + "ScalarMultGLV called on Point_xtw_subgroup with input that is not in the subgroup")
+	}
+	k1, k2 := exponents.GLVDecompose(scalar)
+
+	// table[b1 + 2*b2] == b1 * P1 + b2 * P2, where P1 = +/-base, P2 = +/-Endo(base) with signs such that the coefficients are non-negative.
+	var table [4]Point_xtw_subgroup
+	table[1] = base
+	if k1.Sign() < 0 {
+		table[1].NegEq()
+		k1.Neg(k1)
+	}
+	table[2].Endo(&base)
+	if k2.Sign() < 0 {
+		table[2].NegEq()
+		k2.Neg(k2)
+	}
+	table[3].Add(&table[1], &table[2])
+
+	bitLen := k1.BitLen()
+	if k2.BitLen() > bitLen {
+		bitLen = k2.BitLen()
+	}
+	var result Point_xtw_subgroup
+	result.SetNeutral()
+	for i := bitLen - 1; i >= 0; i-- {
+		result.DoubleEq()
+		if index := k1.Bit(i) + 2*k2.Bit(i); index != 0 {
+			result.AddEq(&table[index])
+		}
+	}
+	*p = result
+}
+
+// VerifyMembershipProof checks whether p == s * G, i.e. whether s is a valid witness that p is in the subgroup generated by G.
+//
+// If p or G is a NaP, the NaP handler is called and its output is returned (false by default).
+//
+// NOTE: This uses ScalarMult and is not constant-time in s. This is not an issue if s is public, which is the intended use case.
+func (p *Point_xtw_subgroup) VerifyMembershipProof(G *Point_xtw_subgroup, s *big.Int) bool {
+	if p.IsNaP() || G.IsNaP() {
+		return napEncountered("NaP encountered when verifying membership proof", true, p, G)
+	}
+	var sG Point_xtw_subgroup
+	sG.ScalarMult(G, s)
+	return p.IsEqual(&sG)
+}
+
+// ReduceScalar sets dst to the reduction of src modulo GroupOrder_Int, i.e. to the unique value in 0 <= . < GroupOrder_Int congruent to src.
+// This is equivalent to dst.Mod(src, GroupOrder_Int), but writes into the caller-owned dst, which may be reused across many calls.
+// Scalars that are already reduced are just copied, avoiding the division.
+//
+// dst and src may alias.
+func ReduceScalar(dst, src *big.Int) {
+	if src.Sign() >= 0 && src.Cmp(GroupOrder_Int) < 0 {
+		dst.Set(src)
+		return
+	}
+	dst.Mod(src, GroupOrder_Int) // Mod always returns a non-negative value
+}
+
+// ScalarMultParallel computes results[i] = scalars[i] * points[i] for all i, distributing the independent scalar multiplications over workers many goroutines.
+// If workers <= 0, we use runtime.GOMAXPROCS(0) many goroutines.
+//
+// results, scalars and points must have the same length; we panic otherwise.
+// Note that reading from a Point_xtw_subgroup may modify its internal representation, so the goroutines only ever operate on their own copies of the input points.
+// The caller must not modify points or scalars concurrently with this function.
+//
+// NOTE: This is not constant-time.
+func ScalarMultParallel(results []Point_xtw_subgroup, scalars []*big.Int, points []Point_xtw_subgroup, workers int) {
+	L := len(results)
+	if len(scalars) != L || len(points) != L {
+		panic(fmt.Errorf(ErrorPrefix+"ScalarMultParallel called with slices of different lengths: len(results) == %v, len(scalars) == %v, len(points) == %v", L, len(scalars), len(points)))
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > L {
+		workers = L
+	}
+
+	// We make all copies in the calling goroutine before any worker starts.
+	// This ensures that no goroutine reads from points[i] while another one might modify its representation.
+	inputs := make([]Point_xtw_subgroup, L)
+	for i := 0; i < L; i++ {
+		inputs[i] = points[i].Snapshot()
+	}
+
+	jobs := make(chan int, L)
+	for i := 0; i < L; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				var result Point_xtw_subgroup
+				result.ScalarMult(&inputs[i], scalars[i])
+				results[i] = result
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// maxMulSmallFactor is the largest factor supported by MulSmall.
+const maxMulSmallFactor = 16
+
+// maxMulSmallChainLength is the maximal number of steps of an addition chain in smallMultipleAdditionChains.
+const maxMulSmallChainLength = 5
+
+// smallMultipleAdditionChains[k] is a shortest addition chain for k, for 2 <= k <= maxMulSmallFactor.
+//
+// The chain computes the multiples a_0 = 1, a_1, ..., a_n = k, where each step {i, j} means a_{m+1} = a_i + a_j for the current length m.
+// Steps with i == j are doublings.
+var smallMultipleAdditionChains = [maxMulSmallFactor + 1][][2]uint8{
+	2:  {{0, 0}},                                 // 2
+	3:  {{0, 0}, {1, 0}},                         // 2, 3
+	4:  {{0, 0}, {1, 1}},                         // 2, 4
+	5:  {{0, 0}, {1, 1}, {2, 0}},                 // 2, 4, 5
+	6:  {{0, 0}, {1, 0}, {2, 2}},                 // 2, 3, 6
+	7:  {{0, 0}, {1, 0}, {2, 2}, {3, 0}},         // 2, 3, 6, 7
+	8:  {{0, 0}, {1, 1}, {2, 2}},                 // 2, 4, 8
+	9:  {{0, 0}, {1, 1}, {2, 2}, {3, 0}},         // 2, 4, 8, 9
+	10: {{0, 0}, {1, 1}, {2, 0}, {3, 3}},         // 2, 4, 5, 10
+	11: {{0, 0}, {1, 1}, {2, 0}, {3, 3}, {4, 0}}, // 2, 4, 5, 10, 11
+	12: {{0, 0}, {1, 0}, {2, 2}, {3, 3}},         // 2, 3, 6, 12
+	13: {{0, 0}, {1, 0}, {2, 2}, {3, 3}, {4, 0}}, // 2, 3, 6, 12, 13
+	14: {{0, 0}, {1, 0}, {2, 2}, {3, 0}, {4, 4}}, // 2, 3, 6, 7, 14
+	15: {{0, 0}, {1, 0}, {2, 2}, {3, 3}, {4, 2}}, // 2, 3, 6, 12, 15
+	16: {{0, 0}, {1, 1}, {2, 2}, {3, 3}},         // 2, 4, 8, 16
+}
+
+// MulSmall computes p = k * input for small 0 <= k <= 16. We panic for larger k.
+//
+// As opposed to ScalarMult, this uses a precomputed shortest addition chain for each k and skips the generic scalar handling,
+// making it faster for the small multiples that appear in formulas (e.g. multiplication by the cofactor 4).
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+func (p *Point_xtw_subgroup) MulSmall(input CurvePointPtrInterfaceRead, k uint8) {
+	if k > maxMulSmallFactor {
+		panic(fmt.Errorf(ErrorPrefix+"MulSmall called with k == %v. Only 0 <= k <= %v is supported", k, maxMulSmallFactor))
+	}
+	var base Point_xtw_subgroup
+	if !base.SetFromSubgroupPoint(input, untrustedInput) {
+		panic(ErrorPrefix + "MulSmall called on Point_xtw_subgroup with input that is not in the subgroup")
+	}
+	switch k {
+	case 0:
+		p.SetNeutral()
+		return
+	case 1:
+		*p = base
+		return
+	}
+	chain := smallMultipleAdditionChains[k]
+	var multiples [maxMulSmallChainLength + 1]point_xtw_base
+	multiples[0] = base.point_xtw_base
+	for m, step := range chain {
+		if step[0] == step[1] {
+			var doubled point_efgh_base
+			doubled.double_st(&multiples[step[0]])
+			multiples[m+1] = doubled.toDecaf_xtw()
+		} else {
+			// Note: The exceptional cases of add_ttt cannot occur, because the difference of two subgroup elements is never at infinity.
+			multiples[m+1].add_ttt(&multiples[step[0]], &multiples[step[1]])
+		}
+	}
+	p.point_xtw_base = multiples[len(chain)]
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMult (with the same restrictions on input), but takes a Scalar, so callers need not care about reduction modulo GroupOrder_Int.
+// Internally, this uses the GLV method, see ScalarMultGLV.
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_xtw_subgroup) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMultGLV(input, scalar.ToBigInt())
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT (with the same restrictions on input and the same caveats), but takes a Scalar.
+func (p *Point_xtw_subgroup) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMultCT(input, scalar.ToBigInt())
+}
+
+// ScalarMult computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+//
+// This computes the result in extended projective coordinates (see Point_xtw_subgroup.ScalarMult) and converts back to affine coordinates only once at the end.
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_axtw_subgroup) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_subgroup
+	result.ScalarMult(input, scalar)
+	p.SetFrom(&result)
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+//
+// This is the analogue of Point_xtw_subgroup.ScalarMultCT and comes with the same caveats. Note that the final conversion to affine coordinates requires a field inversion.
+func (p *Point_axtw_subgroup) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_subgroup
+	result.ScalarMultCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulSmall computes p = k * input for small 0 <= k <= 16. We panic for larger k.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+func (p *Point_axtw_subgroup) MulSmall(input CurvePointPtrInterfaceRead, k uint8) {
+	var result Point_xtw_subgroup
+	result.MulSmall(input, k)
+	p.SetFrom(&result)
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMult (with the same restrictions on input), but takes a Scalar.
+// It is computed via Point_xtw_subgroup.MulScalar.
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_axtw_subgroup) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	var result Point_xtw_subgroup
+	result.MulScalar(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT (with the same restrictions on input and the same caveats), but takes a Scalar.
+func (p *Point_axtw_subgroup) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	var result Point_xtw_subgroup
+	result.MulScalarCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// fullCurveExponent_Int is the exponent 2*GroupOrder of the full curve group (which is isomorphic to Z/GroupOrder x Z/2 x Z/2),
+// i.e. the smallest positive n such that n * P is the neutral element for every curve point P.
+var fullCurveExponent_Int = new(big.Int).Lsh(GroupOrder_Int, 1)
+
+// scalarMultWindowBits is the window size used for (non-GLV) scalar multiplication.
+const scalarMultWindowBits = 4
+
+// scalarMultTable returns the table [0*base, 1*base, ..., (2^scalarMultWindowBits - 1)*base] used for windowed scalar multiplication.
+//
+// This is used both for Point_xtw_subgroup and Point_xtw_full.
+func scalarMultTable[PointType any, PointTypePtr interface {
+	*PointType
+	CurvePointPtrInterface
+}](base PointTypePtr) (table [1 << scalarMultWindowBits]PointType) {
+	PointTypePtr(&table[0]).SetNeutral()
+	table[1] = *base
+	for i := 2; i < len(table); i++ {
+		if i%2 == 0 {
+			PointTypePtr(&table[i]).Double(PointTypePtr(&table[i/2]))
+		} else {
+			PointTypePtr(&table[i]).Add(PointTypePtr(&table[i-1]), base)
+		}
+	}
+	return
+}
+
+// scalarMultWindow returns the scalarMultWindowBits many bits of exponent starting at bit position i * scalarMultWindowBits.
+func scalarMultWindow(exponent *big.Int, i int) (window int) {
+	for j := scalarMultWindowBits - 1; j >= 0; j-- {
+		window = 2*window + int(exponent.Bit(i*scalarMultWindowBits+j))
+	}
+	return
+}
+
+// ScalarMult computes p = scalar * input. The scalar may be negative.
+//
+// As opposed to the subgroup variants, input may be any curve point, including points at infinity and points of small order.
+// Since P and scalar * P only depend on scalar modulo the exponent 2*GroupOrder of the curve group, we reduce the scalar modulo 2*GroupOrder_Int; this also takes care of negative scalars.
+// We use a 4-bit fixed window double-and-add algorithm.
+//
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_xtw_full) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var base Point_xtw_full
+	base.SetFrom(input)
+	var exponent big.Int
+	exponent.Mod(scalar, fullCurveExponent_Int)
+	if exponent.Sign() == 0 || base.IsNeutralElement() {
+		p.SetNeutral()
+		return
+	}
+	table := scalarMultTable(&base)
+	numWindows := (exponent.BitLen() + scalarMultWindowBits - 1) / scalarMultWindowBits
+	var result Point_xtw_full
+	result.SetNeutral()
+	for i := numWindows - 1; i >= 0; i-- {
+		for j := 0; j < scalarMultWindowBits; j++ {
+			result.DoubleEq()
+		}
+		if window := scalarMultWindow(&exponent, i); window != 0 {
+			result.AddEq(&table[window])
+		}
+	}
+	*p = result
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative. input may be any curve point.
+//
+// As opposed to ScalarMult, this is meant to be used with secret scalars: We always process the same number of windows (determined by 2*GroupOrder_Int rather than by the scalar)
+// and select the table entry to be added via masking, reading every table entry each time. There are no shortcuts for trivial scalars or inputs.
+//
+// NOTE: The reduction of scalar modulo 2*GroupOrder_Int and the bit extraction are done with big.Int, which makes no constant-time guarantees.
+// Furthermore, addition of points that need not be in the subgroup internally distinguishes some exceptional cases (notably involving points at infinity).
+// If input is known to be in the prime-order subgroup, prefer Point_xtw_subgroup.ScalarMultCT.
+func (p *Point_xtw_full) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var base Point_xtw_full
+	base.SetFrom(input)
+	var exponent big.Int
+	exponent.Mod(scalar, fullCurveExponent_Int)
+	table := scalarMultTable(&base)
+	numWindows := (fullCurveExponent_Int.BitLen() + scalarMultWindowBits - 1) / scalarMultWindowBits
+	var result, addend Point_xtw_full
+	result.SetNeutral()
+	for i := numWindows - 1; i >= 0; i-- {
+		for j := 0; j < scalarMultWindowBits; j++ {
+			result.DoubleEq()
+		}
+		window := scalarMultWindow(&exponent, i)
+		for k := range table {
+			addend.condSet(&table[k].point_xtw_base, subtle.ConstantTimeEq(int32(k), int32(window)))
+		}
+		result.AddEq(&addend)
+	}
+	*p = result
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// Since input may be outside the prime-order subgroup, the result depends on the representative of scalar; we use the one in [0, GroupOrder) given by scalar.ToBigInt().
+// This is equivalent to ScalarMult(input, scalar.ToBigInt()).
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_xtw_full) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMult(input, scalar.ToBigInt())
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT(input, scalar.ToBigInt()) and comes with the same caveats.
+func (p *Point_xtw_full) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMultCT(input, scalar.ToBigInt())
+}
+
+// ScalarMult computes p = scalar * input. The scalar may be negative. input may be any curve point.
+//
+// This computes the result via Point_xtw_full.ScalarMult and converts back to affine coordinates only once at the end.
+// Consequently, we panic if the result is at infinity, which is not representable by Point_axtw_full.
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_axtw_full) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_full
+	result.ScalarMult(input, scalar)
+	p.SetFrom(&result)
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative. input may be any curve point.
+//
+// This is the analogue of Point_xtw_full.ScalarMultCT and comes with the same caveats. As for ScalarMult, we panic if the result is at infinity.
+func (p *Point_axtw_full) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_full
+	result.ScalarMultCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulSmall computes p = k * input for small 0 <= k <= 16. We panic for larger k. input may be any curve point.
+//
+// The precomputed addition chains of Point_xtw_subgroup.MulSmall do not handle the exceptional cases of addition outside the subgroup, so this is computed via Point_xtw_full.ScalarMult.
+// As for ScalarMult, we panic if the result is at infinity.
+func (p *Point_axtw_full) MulSmall(input CurvePointPtrInterfaceRead, k uint8) {
+	if k > maxMulSmallFactor {
+		panic(fmt.Errorf(ErrorPrefix+"MulSmall called with k == %v. Only 0 <= k <= %v is supported", k, maxMulSmallFactor))
+	}
+	var result Point_xtw_full
+	result.ScalarMult(input, new(big.Int).SetUint64(uint64(k)))
+	p.SetFrom(&result)
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// Since input may be outside the prime-order subgroup, the result depends on the representative of scalar; we use the one in [0, GroupOrder) given by scalar.ToBigInt().
+// This is equivalent to ScalarMult(input, scalar.ToBigInt()). As for ScalarMult, we panic if the result is at infinity.
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_axtw_full) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMult(input, scalar.ToBigInt())
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT(input, scalar.ToBigInt()) and comes with the same caveats.
+func (p *Point_axtw_full) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMultCT(input, scalar.ToBigInt())
+}
+
+// ScalarMult computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+// This is computed via Point_xtw_subgroup.ScalarMult.
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_efgh_subgroup) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_subgroup
+	result.ScalarMult(input, scalar)
+	p.SetFrom(&result)
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative and is reduced modulo GroupOrder_Int.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+// This is the analogue of Point_xtw_subgroup.ScalarMultCT and comes with the same caveats.
+func (p *Point_efgh_subgroup) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_subgroup
+	result.ScalarMultCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulSmall computes p = k * input for small 0 <= k <= 16. We panic for larger k.
+//
+// input must be in the prime-order subgroup. If input has a type that can represent points outside the subgroup, we panic if it is not in the subgroup.
+func (p *Point_efgh_subgroup) MulSmall(input CurvePointPtrInterfaceRead, k uint8) {
+	var result Point_xtw_subgroup
+	result.MulSmall(input, k)
+	p.SetFrom(&result)
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMult (with the same restrictions on input), but takes a Scalar.
+// It is computed via Point_xtw_subgroup.MulScalar.
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_efgh_subgroup) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	var result Point_xtw_subgroup
+	result.MulScalar(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT (with the same restrictions on input and the same caveats), but takes a Scalar.
+func (p *Point_efgh_subgroup) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	var result Point_xtw_subgroup
+	result.MulScalarCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// ScalarMult computes p = scalar * input. The scalar may be negative. input may be any curve point.
+//
+// This is computed via Point_xtw_full.ScalarMult.
+// NOTE: This is not constant-time. Use ScalarMultCT for secret scalars.
+func (p *Point_efgh_full) ScalarMult(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_full
+	result.ScalarMult(input, scalar)
+	p.SetFrom(&result)
+}
+
+// ScalarMultCT computes p = scalar * input. The scalar may be negative. input may be any curve point.
+//
+// This is the analogue of Point_xtw_full.ScalarMultCT and comes with the same caveats.
+func (p *Point_efgh_full) ScalarMultCT(input CurvePointPtrInterfaceRead, scalar *big.Int) {
+	var result Point_xtw_full
+	result.ScalarMultCT(input, scalar)
+	p.SetFrom(&result)
+}
+
+// MulScalar computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// Since input may be outside the prime-order subgroup, the result depends on the representative of scalar; we use the one in [0, GroupOrder) given by scalar.ToBigInt().
+// This is equivalent to ScalarMult(input, scalar.ToBigInt()).
+//
+// NOTE: This is not constant-time. Use MulScalarCT for secret scalars.
+func (p *Point_efgh_full) MulScalar(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMult(input, scalar.ToBigInt())
+}
+
+// MulScalarCT computes p = scalar * input, where scalar is an element of Z/GroupOrder.
+// This is equivalent to ScalarMultCT(input, scalar.ToBigInt()) and comes with the same caveats.
+func (p *Point_efgh_full) MulScalarCT(input CurvePointPtrInterfaceRead, scalar *Scalar) {
+	p.ScalarMultCT(input, scalar.ToBigInt())
+}
@@ -0,0 +1,588 @@
+package curvePoints
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/callcounters"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestScalarMult(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var result Point_xtw_subgroup
+
+		result.ScalarMult(&P, big.NewInt(0))
+		if !result.IsNeutralElement() {
+			t.Fatalf("0 * P is not the neutral element")
+		}
+		result.ScalarMult(&P, big.NewInt(1))
+		if !result.IsEqual(&P) {
+			t.Fatalf("1 * P != P")
+		}
+		var expected Point_xtw_subgroup
+		expected.Double(&P)
+		expected.AddEq(&P)
+		result.ScalarMult(&P, big.NewInt(3))
+		if !result.IsEqual(&expected) {
+			t.Fatalf("3 * P != P + P + P")
+		}
+		expected.NegEq()
+		result.ScalarMult(&P, big.NewInt(-3))
+		if !result.IsEqual(&expected) {
+			t.Fatalf("(-3) * P != -(P + P + P)")
+		}
+		result.ScalarMult(&P, GroupOrder_Int)
+		if !result.IsNeutralElement() {
+			t.Fatalf("GroupOrder * P is not the neutral element")
+		}
+
+		// (a+b) * P == a*P + b*P, also testing reduction modulo GroupOrder
+		a := new(big.Int).Rand(drng, GroupOrder_Int)
+		b := new(big.Int).Rand(drng, GroupOrder_Int)
+		sum := new(big.Int).Add(a, b)
+		var aP, bP Point_xtw_subgroup
+		aP.ScalarMult(&P, a)
+		bP.ScalarMult(&P, b)
+		expected.Add(&aP, &bP)
+		result.ScalarMult(&P, sum)
+		if !result.IsEqual(&expected) {
+			t.Fatalf("ScalarMult is not linear in the scalar")
+		}
+
+		// input of different type
+		var PFull Point_xtw_full
+		PFull.SetFrom(&P)
+		result.ScalarMult(&PFull, a)
+		if !result.IsEqual(&aP) {
+			t.Fatalf("ScalarMult gives different result for Point_xtw_full input")
+		}
+	}
+}
+
+// scalarMultDoubleAndAdd is the plain (non-windowed) double-and-add algorithm that Point_xtw_subgroup.ScalarMult used to be. It serves as a reference implementation.
+func scalarMultDoubleAndAdd(base *Point_xtw_subgroup, scalar *big.Int) (result Point_xtw_subgroup) {
+	var exponent big.Int
+	exponent.Mod(scalar, GroupOrder_Int)
+	result.SetNeutral()
+	for i := exponent.BitLen() - 1; i >= 0; i-- {
+		result.DoubleEq()
+		if exponent.Bit(i) == 1 {
+			result.AddEq(base)
+		}
+	}
+	return
+}
+
+func TestScalarMultMatchesDoubleAndAdd(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	orderMinusOne := new(big.Int).Sub(GroupOrder_Int, big.NewInt(1))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		random := new(big.Int).Rand(drng, GroupOrder_Int)
+		scalars := []*big.Int{big.NewInt(0), big.NewInt(1), orderMinusOne, new(big.Int).Neg(orderMinusOne), big.NewInt(-1), big.NewInt(-17), big.NewInt(15), big.NewInt(16), random, new(big.Int).Neg(random)}
+		for _, scalar := range scalars {
+			expected := scalarMultDoubleAndAdd(&P, scalar)
+			for _, pointType := range []PointType{pointTypeXTWSubgroup, pointTypeAXTWSubgroup, pointTypeEFGHSubgroup} {
+				result := makeCurvePointPtrInterface(pointType).(interface {
+					CurvePointPtrInterface
+					ScalarMult(CurvePointPtrInterfaceRead, *big.Int)
+				})
+				result.ScalarMult(&P, scalar)
+				testutils.FatalUnless(t, result.IsEqual(&expected), "Windowed ScalarMult for %v differs from double-and-add for scalar %v", pointTypeToString(pointType), scalar)
+			}
+		}
+	}
+}
+
+// countMultiplications returns the number of field multiplications performed by fun. This only works if call counters are active.
+func countMultiplications(fun func()) int {
+	callcounters.ResetAllCounters()
+	fun()
+	count, _ := callcounters.Id("Multiplications").Get()
+	return count
+}
+
+func TestScalarMultFastPaths(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	random := new(big.Int).Rand(drng, GroupOrder_Int)
+	onePlusOrder := new(big.Int).Add(big.NewInt(1), GroupOrder_Int)
+	var result Point_xtw_subgroup
+
+	result = P
+	result.ScalarMult(&P, GroupOrder_Int)
+	testutils.FatalUnless(t, result.IsNeutralElement(), "")
+	result.ScalarMult(&P, onePlusOrder)
+	testutils.FatalUnless(t, result.IsEqual(&P), "")
+	result.ScalarMult(&NeutralElement_xtw_subgroup, random)
+	testutils.FatalUnless(t, result.IsNeutralElement(), "")
+	// aliasing
+	result = P
+	result.ScalarMult(&result, big.NewInt(1))
+	testutils.FatalUnless(t, result.IsEqual(&P), "")
+
+	if !CallCountersActive {
+		t.Log("Call counters inactive; not checking that fast paths avoid the double-and-add loop")
+		return
+	}
+	full := countMultiplications(func() { result.ScalarMult(&P, random) })
+	for _, fastPath := range []func(){
+		func() { result.ScalarMult(&P, big.NewInt(0)) },
+		func() { result.ScalarMult(&P, big.NewInt(1)) },
+		func() { result.ScalarMult(&P, onePlusOrder) },
+		func() { result.ScalarMult(&NeutralElement_xtw_subgroup, random) },
+	} {
+		fast := countMultiplications(fastPath)
+		testutils.FatalUnless(t, fast < 20 && fast*50 < full, "ScalarMult fast path used %v multiplications (vs. %v for full scalar multiplication)", fast, full)
+	}
+}
+
+func TestScalarMultCT(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	scalars := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(2), new(big.Int).Sub(GroupOrder_Int, big.NewInt(1)), new(big.Int).Rand(drng, GroupOrder_Int), new(big.Int).Rand(drng, GroupOrder_Int)}
+	for _, scalar := range scalars {
+		var result, expected Point_xtw_subgroup
+		result.ScalarMultCT(&P, scalar)
+		expected.ScalarMult(&P, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultCT differs from ScalarMult for scalar %v", scalar)
+		result.ScalarMultCT(&NeutralElement_xtw_subgroup, scalar)
+		testutils.FatalUnless(t, result.IsNeutralElement(), "")
+	}
+
+	// ScalarMultCT must not take shortcuts depending on the scalar. We check that the number of field multiplications does not depend on it.
+	if !CallCountersActive {
+		t.Log("Call counters inactive; not checking that ScalarMultCT does not branch on the scalar")
+		return
+	}
+	var result Point_xtw_subgroup
+	expectedCount := countMultiplications(func() { result.ScalarMultCT(&P, scalars[0]) })
+	for _, scalar := range scalars {
+		count := countMultiplications(func() { result.ScalarMultCT(&P, scalar) })
+		testutils.FatalUnless(t, count == expectedCount, "ScalarMultCT used %v multiplications for scalar %v, but %v for scalar 0", count, scalar, expectedCount)
+	}
+}
+
+func TestScalarMultParallel(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	const num = 50
+	points := make([]Point_xtw_subgroup, num)
+	scalars := make([]*big.Int, num)
+	for i := 0; i < num; i++ {
+		points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		points[i].rerandomizeRepresentation(drng)
+		if i%2 == 0 {
+			points[i].flipDecaf()
+		}
+		scalars[i] = new(big.Int).Rand(drng, GroupOrder_Int)
+	}
+	scalars[0].SetInt64(0)
+	scalars[1].SetInt64(-5)
+
+	expected := make([]Point_xtw_subgroup, num)
+	for i := 0; i < num; i++ {
+		expected[i].ScalarMult(&points[i], scalars[i])
+	}
+
+	for _, workers := range []int{-1, 0, 1, 3, num, 2 * num} {
+		results := make([]Point_xtw_subgroup, num)
+		ScalarMultParallel(results, scalars, points, workers)
+		for i := 0; i < num; i++ {
+			if !results[i].IsEqual(&expected[i]) {
+				t.Fatalf("ScalarMultParallel with %v workers differs from serial computation at index %v", workers, i)
+			}
+		}
+	}
+
+	// empty input
+	ScalarMultParallel(nil, nil, nil, 0)
+
+	// mismatched lengths
+	didPanic := testutils.CheckPanic(ScalarMultParallel, make([]Point_xtw_subgroup, num-1), scalars, points, 0)
+	if !didPanic {
+		t.Fatalf("ScalarMultParallel did not panic on mismatched lengths")
+	}
+}
+
+func TestMulSmall(t *testing.T) {
+	// check the addition chains themselves
+	for k := 2; k <= maxMulSmallFactor; k++ {
+		chain := smallMultipleAdditionChains[k]
+		testutils.FatalUnless(t, len(chain) > 0 && len(chain) <= maxMulSmallChainLength, "Addition chain for %v has invalid length", k)
+		values := []int{1}
+		for m, step := range chain {
+			testutils.FatalUnless(t, int(step[0]) <= m && int(step[1]) <= m, "Addition chain for %v refers to future values", k)
+			values = append(values, values[step[0]]+values[step[1]])
+		}
+		testutils.FatalUnless(t, values[len(values)-1] == k, "Addition chain for %v computes %v", k, values[len(values)-1])
+	}
+
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 10; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		P.rerandomizeRepresentation(drng)
+		if i%2 == 0 {
+			P.flipDecaf()
+		}
+		var PFull Point_xtw_full
+		PFull.SetFrom(&P)
+		for k := 0; k <= maxMulSmallFactor; k++ {
+			var expected, result Point_xtw_subgroup
+			expected.ScalarMult(&P, big.NewInt(int64(k)))
+			result.MulSmall(&P, uint8(k))
+			testutils.FatalUnless(t, result.IsEqual(&expected), "MulSmall differs from ScalarMult for k == %v", k)
+			result.MulSmall(&PFull, uint8(k))
+			testutils.FatalUnless(t, result.IsEqual(&expected), "MulSmall differs from ScalarMult for k == %v and Point_xtw_full input", k)
+		}
+		// aliasing
+		var expected Point_xtw_subgroup
+		expected.ScalarMult(&P, big.NewInt(13))
+		P.MulSmall(&P, 13)
+		testutils.FatalUnless(t, P.IsEqual(&expected), "MulSmall does not work with aliasing arguments")
+	}
+
+	var result Point_xtw_subgroup
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	testutils.FatalUnless(t, testutils.CheckPanic(result.MulSmall, &P, uint8(maxMulSmallFactor+1)), "MulSmall did not panic for too large factor")
+	testutils.FatalUnless(t, testutils.CheckPanic(result.MulSmall, &AffineOrderTwoPoint_xtw, uint8(2)), "MulSmall did not panic for input outside subgroup")
+}
+
+func BenchmarkMulSmall(b *testing.B) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var result Point_xtw_subgroup
+	b.Run("MulSmall", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.MulSmall(&P, 15)
+		}
+	})
+	scalar := big.NewInt(15)
+	b.Run("ScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.ScalarMult(&P, scalar)
+		}
+	})
+}
+
+func TestReduceScalar(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	var orderSquare big.Int
+	orderSquare.Mul(GroupOrder_Int, GroupOrder_Int)
+	scalars := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), new(big.Int).Set(GroupOrder_Int), new(big.Int).Neg(GroupOrder_Int), new(big.Int).Sub(GroupOrder_Int, big.NewInt(1))}
+	for i := 0; i < 20; i++ {
+		s := new(big.Int).Rand(drng, &orderSquare)
+		if i%2 == 0 {
+			s.Neg(s)
+		}
+		scalars = append(scalars, s)
+	}
+	var dst big.Int
+	for _, src := range scalars {
+		srcCopy := new(big.Int).Set(src)
+		expected := new(big.Int).Mod(src, GroupOrder_Int)
+		ReduceScalar(&dst, src)
+		testutils.FatalUnless(t, dst.Cmp(expected) == 0, "ReduceScalar(%v) gives %v, expected %v", src, &dst, expected)
+		testutils.FatalUnless(t, src.Cmp(srcCopy) == 0, "ReduceScalar modified src")
+		// aliasing
+		ReduceScalar(srcCopy, srcCopy)
+		testutils.FatalUnless(t, srcCopy.Cmp(expected) == 0, "ReduceScalar does not work with aliasing arguments")
+	}
+}
+
+func TestVerifyMembershipProof(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 10; i++ {
+		G := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		s := new(big.Int).Rand(drng, GroupOrder_Int)
+		var P Point_xtw_subgroup
+		P.ScalarMult(&G, s)
+		P.flipDecaf()
+		testutils.FatalUnless(t, P.VerifyMembershipProof(&G, s), "VerifyMembershipProof rejects valid proof")
+
+		// equivalent scalars are accepted
+		sShifted := new(big.Int).Add(s, GroupOrder_Int)
+		testutils.FatalUnless(t, P.VerifyMembershipProof(&G, sShifted), "VerifyMembershipProof rejects scalar plus group order")
+
+		// tampered scalar
+		sTampered := new(big.Int).Add(s, big.NewInt(1))
+		testutils.FatalUnless(t, !P.VerifyMembershipProof(&G, sTampered), "VerifyMembershipProof accepts tampered scalar")
+
+		// tampered point
+		var PTampered Point_xtw_subgroup
+		PTampered.Add(&P, &G)
+		testutils.FatalUnless(t, !PTampered.VerifyMembershipProof(&G, s), "VerifyMembershipProof accepts tampered point")
+		var PNeg Point_xtw_subgroup
+		PNeg.Neg(&P)
+		testutils.FatalUnless(t, !PNeg.VerifyMembershipProof(&G, s), "VerifyMembershipProof accepts negated point")
+
+		// tampered base
+		var GTampered Point_xtw_subgroup
+		GTampered.Double(&G)
+		testutils.FatalUnless(t, !P.VerifyMembershipProof(&GTampered, s), "VerifyMembershipProof accepts tampered base")
+	}
+	var NaP Point_xtw_subgroup
+	G := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var result bool = true
+	didNaP := wasInvalidPointEncountered(func() { result = NaP.VerifyMembershipProof(&G, big.NewInt(1)) })
+	testutils.FatalUnless(t, didNaP && !result, "VerifyMembershipProof does not handle NaPs correctly")
+}
+
+// TestAxtwArithmetic checks that the arithmetic methods on Point_axtw_subgroup and Point_axtw_full agree with
+// converting to xtw, performing the operation there and converting back.
+func TestAxtwArithmetic(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(668))
+	for i := 0; i < 20; i++ {
+		Pxtw := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Qxtw := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var P, Q, result Point_axtw_subgroup
+		P.SetFrom(&Pxtw)
+		Q.SetFrom(&Qxtw)
+		var expected Point_xtw_subgroup
+
+		result.Add(&P, &Q)
+		expected.Add(&Pxtw, &Qxtw)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "Add differs for Point_axtw_subgroup")
+		result.Sub(&P, &Q)
+		expected.Sub(&Pxtw, &Qxtw)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "Sub differs for Point_axtw_subgroup")
+		result.Double(&P)
+		expected.Double(&Pxtw)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "Double differs for Point_axtw_subgroup")
+		result.Neg(&P)
+		expected.Neg(&Pxtw)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "Neg differs for Point_axtw_subgroup")
+
+		scalar := new(big.Int).Rand(drng, GroupOrder_Int)
+		if i%2 == 0 {
+			scalar.Neg(scalar)
+		}
+		result.ScalarMult(&P, scalar)
+		expected.ScalarMult(&Pxtw, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMult differs for Point_axtw_subgroup")
+		result.ScalarMultCT(&P, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultCT differs for Point_axtw_subgroup")
+		// in-place operation
+		result = P
+		result.ScalarMult(&result, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMult differs for Point_axtw_subgroup when aliasing")
+
+		k := uint8(drng.Intn(maxMulSmallFactor + 1))
+		result.MulSmall(&P, k)
+		expected.MulSmall(&Pxtw, k)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MulSmall differs for Point_axtw_subgroup")
+
+		// full-curve points, including points outside the subgroup
+		Pfull_xtw := MakeRandomPointUnsafe_xtw_full(drng)
+		Qfull_xtw := MakeRandomPointUnsafe_xtw_full(drng)
+		var Pfull, Qfull, resultFull Point_axtw_full
+		Pfull.SetFrom(&Pfull_xtw)
+		Qfull.SetFrom(&Qfull_xtw)
+		var expectedFull Point_xtw_full
+		resultFull.Add(&Pfull, &Qfull)
+		expectedFull.Add(&Pfull_xtw, &Qfull_xtw)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "Add differs for Point_axtw_full")
+		resultFull.Sub(&Pfull, &Qfull)
+		expectedFull.Sub(&Pfull_xtw, &Qfull_xtw)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "Sub differs for Point_axtw_full")
+		resultFull.Double(&Pfull)
+		expectedFull.Double(&Pfull_xtw)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "Double differs for Point_axtw_full")
+		resultFull.Neg(&Pfull)
+		expectedFull.Neg(&Pfull_xtw)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "Neg differs for Point_axtw_full")
+		resultFull.ScalarMult(&Pfull, scalar)
+		expectedFull.ScalarMult(&Pfull_xtw, scalar)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "ScalarMult differs for Point_axtw_full")
+		resultFull.ScalarMultCT(&Pfull, scalar)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "ScalarMultCT differs for Point_axtw_full")
+		for k := 0; k <= maxMulSmallFactor; k++ {
+			resultFull.MulSmall(&Pfull, uint8(k))
+			expectedFull.ScalarMult(&Pfull_xtw, big.NewInt(int64(k)))
+			testutils.FatalUnless(t, resultFull.IsEqual(&expectedFull), "MulSmall differs for Point_axtw_full and k == %v", k)
+		}
+	}
+
+	var P Point_axtw_subgroup
+	didPanic := testutils.CheckPanic(func() { P.ScalarMult(&AffineOrderTwoPoint_xtw, big.NewInt(3)) })
+	testutils.FatalUnless(t, didPanic, "Point_axtw_subgroup.ScalarMult did not panic on input outside subgroup")
+	var PFull Point_axtw_full
+	didPanic = testutils.CheckPanic(func() { PFull.MulSmall(&AffineOrderTwoPoint_xtw, maxMulSmallFactor+1) })
+	testutils.FatalUnless(t, didPanic, "Point_axtw_full.MulSmall did not panic for too large k")
+}
+
+func TestScalarMultFull(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(669))
+	var inputs []Point_xtw_full
+	inputs = append(inputs, NeutralElement_xtw_full, AffineOrderTwoPoint_xtw, InfinitePoint1_xtw, InfinitePoint2_xtw)
+	for i := 0; i < 10; i++ {
+		inputs = append(inputs, MakeRandomPointUnsafe_xtw_full(drng))
+	}
+	for _, P := range inputs {
+		var result, expected Point_xtw_full
+
+		// compare against repeated addition for small scalars; this covers every table entry.
+		expected.SetNeutral()
+		for k := 0; k < 40; k++ {
+			result.ScalarMult(&P, big.NewInt(int64(k)))
+			testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMult differs from repeated addition for k == %v", k)
+			result.ScalarMultCT(&P, big.NewInt(int64(k)))
+			testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultCT differs from repeated addition for k == %v", k)
+			var negExpected Point_xtw_full
+			negExpected.Neg(&expected)
+			result.ScalarMult(&P, big.NewInt(int64(-k)))
+			testutils.FatalUnless(t, result.IsEqual(&negExpected), "ScalarMult wrong for negative scalar -%v", k)
+			expected.AddEq(&P)
+		}
+
+		// The group exponent is 2*GroupOrder and multiplication by GroupOrder only keeps the 2-torsion component.
+		result.ScalarMult(&P, fullCurveExponent_Int)
+		testutils.FatalUnless(t, result.IsNeutralElement(), "2*GroupOrder * P is not neutral")
+		result.ScalarMult(&P, GroupOrder_Int)
+		testutils.FatalUnless(t, result.IsInSubgroup() == P.IsInSubgroup(), "GroupOrder * P should be neutral iff P is in the subgroup")
+
+		// linearity, with a sum exceeding 2*GroupOrder
+		a := new(big.Int).Rand(drng, fullCurveExponent_Int)
+		b := new(big.Int).Rand(drng, fullCurveExponent_Int)
+		b.Add(b, fullCurveExponent_Int)
+		var aP, bP Point_xtw_full
+		aP.ScalarMult(&P, a)
+		bP.ScalarMult(&P, b)
+		expected.Add(&aP, &bP)
+		result.ScalarMult(&P, new(big.Int).Add(a, b))
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMult is not linear in the scalar")
+		result.ScalarMultCT(&P, a)
+		testutils.FatalUnless(t, result.IsEqual(&aP), "ScalarMultCT differs from ScalarMult")
+
+		// other point types
+		var resultEfgh Point_efgh_full
+		resultEfgh.ScalarMult(&P, a)
+		testutils.FatalUnless(t, resultEfgh.IsEqual(&aP), "ScalarMult differs for Point_efgh_full")
+		resultEfgh.ScalarMultCT(&P, a)
+		testutils.FatalUnless(t, resultEfgh.IsEqual(&aP), "ScalarMultCT differs for Point_efgh_full")
+		if !aP.IsAtInfinity() {
+			var resultAxtw Point_axtw_full
+			resultAxtw.ScalarMult(&P, a)
+			testutils.FatalUnless(t, resultAxtw.IsEqual(&aP), "ScalarMult differs for Point_axtw_full")
+			resultAxtw.ScalarMultCT(&P, a)
+			testutils.FatalUnless(t, resultAxtw.IsEqual(&aP), "ScalarMultCT differs for Point_axtw_full")
+		}
+	}
+
+	// Point_efgh_subgroup
+	for i := 0; i < 10; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		scalar := new(big.Int).Rand(drng, GroupOrder_Int)
+		scalar.Neg(scalar)
+		var expected Point_xtw_subgroup
+		var result Point_efgh_subgroup
+		expected.ScalarMult(&P, scalar)
+		result.ScalarMult(&P, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMult differs for Point_efgh_subgroup")
+		result.ScalarMultCT(&P, scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultCT differs for Point_efgh_subgroup")
+		expected.MulSmall(&P, 7)
+		result.MulSmall(&P, 7)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MulSmall differs for Point_efgh_subgroup")
+	}
+}
+
+func TestScalarMultGLV(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(670))
+	bigRange := new(big.Int).Lsh(GroupOrder_Int, 2)
+	specialScalars := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(2), EndomorphismEigenvalue_Int, GroupOrder_Int, new(big.Int).Sub(GroupOrder_Int, big.NewInt(1))}
+	for i := 0; i < 50; i++ {
+		var P Point_xtw_subgroup
+		switch i {
+		case 0:
+			P = SubgroupGenerator_xtw_subgroup
+		case 1:
+			P.SetNeutral()
+		default:
+			P = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		}
+		scalars := []*big.Int{new(big.Int).Rand(drng, GroupOrder_Int), new(big.Int).Sub(new(big.Int).Rand(drng, bigRange), GroupOrder_Int)}
+		if i < 2 {
+			scalars = append(scalars, specialScalars...)
+		}
+		for _, scalar := range scalars {
+			scalarCopy := new(big.Int).Set(scalar)
+			var result, expected Point_xtw_subgroup
+			expected.ScalarMult(&P, scalar)
+			result.ScalarMultGLV(&P, scalar)
+			testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultGLV differs from ScalarMult for scalar %v", scalar)
+			testutils.FatalUnless(t, scalar.Cmp(scalarCopy) == 0, "ScalarMultGLV modified its input")
+
+			// aliasing
+			result = P
+			result.ScalarMultGLV(&result, scalar)
+			testutils.FatalUnless(t, result.IsEqual(&expected), "ScalarMultGLV does not work with aliasing arguments")
+		}
+	}
+	var result Point_xtw_subgroup
+	testutils.FatalUnless(t, testutils.CheckPanic(result.ScalarMultGLV, &AffineOrderTwoPoint_xtw, big.NewInt(3)), "ScalarMultGLV did not panic for input outside subgroup")
+}
+
+func BenchmarkScalarMultGLV(b *testing.B) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	scalar := new(big.Int).Rand(drng, GroupOrder_Int)
+	var result Point_xtw_subgroup
+	b.Run("ScalarMultGLV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.ScalarMultGLV(&P, scalar)
+		}
+	})
+	b.Run("ScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result.ScalarMult(&P, scalar)
+		}
+	})
+}
+
+func TestMulScalar(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var scalar Scalar
+		_ = scalar.SetRandom(drng)
+		var expected, result Point_xtw_subgroup
+		expected.ScalarMult(&P, scalar.ToBigInt())
+		result.MulScalar(&P, &scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MulScalar differs from ScalarMult")
+		result.MulScalarCT(&P, &scalar)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MulScalarCT differs from ScalarMult")
+
+		var resultAxtw Point_axtw_subgroup
+		var resultEfgh Point_efgh_subgroup
+		resultAxtw.MulScalar(&P, &scalar)
+		testutils.FatalUnless(t, resultAxtw.IsEqual(&expected), "MulScalar differs from ScalarMult for Point_axtw_subgroup")
+		resultAxtw.MulScalarCT(&P, &scalar)
+		testutils.FatalUnless(t, resultAxtw.IsEqual(&expected), "MulScalarCT differs from ScalarMult for Point_axtw_subgroup")
+		resultEfgh.MulScalar(&P, &scalar)
+		testutils.FatalUnless(t, resultEfgh.IsEqual(&expected), "MulScalar differs from ScalarMult for Point_efgh_subgroup")
+		resultEfgh.MulScalarCT(&P, &scalar)
+		testutils.FatalUnless(t, resultEfgh.IsEqual(&expected), "MulScalarCT differs from ScalarMult for Point_efgh_subgroup")
+
+		PFull := MakeRandomPointUnsafe_xtw_full(drng)
+		var expectedFull, resultXtwFull Point_xtw_full
+		var resultAxtwFull Point_axtw_full
+		var resultEfghFull Point_efgh_full
+		expectedFull.ScalarMult(&PFull, scalar.ToBigInt())
+		resultXtwFull.MulScalar(&PFull, &scalar)
+		testutils.FatalUnless(t, resultXtwFull.IsEqual(&expectedFull), "MulScalar differs from ScalarMult for Point_xtw_full")
+		resultXtwFull.MulScalarCT(&PFull, &scalar)
+		testutils.FatalUnless(t, resultXtwFull.IsEqual(&expectedFull), "MulScalarCT differs from ScalarMult for Point_xtw_full")
+		resultAxtwFull.MulScalar(&PFull, &scalar)
+		testutils.FatalUnless(t, resultAxtwFull.IsEqual(&expectedFull), "MulScalar differs from ScalarMult for Point_axtw_full")
+		resultAxtwFull.MulScalarCT(&PFull, &scalar)
+		testutils.FatalUnless(t, resultAxtwFull.IsEqual(&expectedFull), "MulScalarCT differs from ScalarMult for Point_axtw_full")
+		resultEfghFull.MulScalar(&PFull, &scalar)
+		testutils.FatalUnless(t, resultEfghFull.IsEqual(&expectedFull), "MulScalar differs from ScalarMult for Point_efgh_full")
+		resultEfghFull.MulScalarCT(&PFull, &scalar)
+		testutils.FatalUnless(t, resultEfghFull.IsEqual(&expectedFull), "MulScalarCT differs from ScalarMult for Point_efgh_full")
+	}
+}
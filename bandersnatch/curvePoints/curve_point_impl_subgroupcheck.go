@@ -10,11 +10,18 @@ package curvePoints
 // legendreCheckA_affineX returns true for points of the form P or P+A and false for P+E1 or P+E2 where P is in the prime-order subgroup.
 // The input is the affine x coordinate. Only x^2 matters, so calling with -x instead of x is fine.
 func legendreCheckA_affineX(x FieldElement) bool {
+	arg := legendreCheckA_affineX_argument(x)
+	return arg.Jacobi() >= 0 // cannot be ==0, since a is a non-square
+}
+
+// legendreCheckA_affineX_argument returns the field element whose Legendre symbol is checked by legendreCheckA_affineX, i.e. 1-ax^2.
+// This is split off for batch variants of the check.
+func legendreCheckA_affineX_argument(x FieldElement) FieldElement {
 	// x is passed by value. We use it as a temporary.
 	x.SquareEq()
 	x.Multiply_by_five()
 	x.AddEq(&fieldElementOne) // 1 + 5x^2 = 1-ax^2
-	return x.Jacobi() >= 0    // cannot be ==0, since a is a non-square
+	return x
 }
 
 // legendreCheckA_projectiveXZ returns true for points of the form P or P+A and false for P+E1 or P+E2 where P is in the prime-order subgroup.
@@ -45,6 +52,14 @@ func legendreCheckA_EG(e FieldElement, g FieldElement) bool {
 // legendreCheckE1_affineY takes the affine y coordinate.
 // Since affine coordinates rule out E1, E2, the exception at E2 cannot occur
 func legendreCheckE1_affineY(y FieldElement) bool {
+	arg := legendreCheckE1_affineY_argument(y)
+	// Note: This is zero for the neutral element
+	return arg.Jacobi() <= 0
+}
+
+// legendreCheckE1_affineY_argument returns the field element whose Legendre symbol is checked by legendreCheckE1_affineY.
+// This is split off for batch variants of the check.
+func legendreCheckE1_affineY_argument(y FieldElement) FieldElement {
 	// TODO: formula not optimized
 
 	var acc FieldElement
@@ -57,8 +72,7 @@ func legendreCheckE1_affineY(y FieldElement) bool {
 	y.MulEq(&rAndOne)
 	acc.SubEq(&y)               // -(r+1)y + r*y^2
 	acc.AddEq(&fieldElementOne) // ry^2 -(r+1)y + 1
-	// Note: This is zero for the neutral element
-	return acc.Jacobi() <= 0
+	return acc
 }
 
 // legendreCheckE1 returns true for N, E1, E2 and points of the form P, P+E1 with P!=N in the prime-order subgroup.
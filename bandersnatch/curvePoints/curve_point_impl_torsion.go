@@ -1,5 +1,7 @@
 package curvePoints
 
+import "math/big"
+
 // this file contains internal function that add 2-torsion points to given points.
 // This is mostly used in testing.
 // The point here is that 2-torsion points might not be representable by
@@ -78,3 +80,94 @@ func (p *point_efgh_base) torsionAddE2() {
 	p.torsionAddE1()
 	p.torsionAddA()
 }
+
+// SumOverCofactor returns the sum of p + g over all four points g of the cofactor group {N, A, E1, E2}, i.e. p + (p+A) + (p+E1) + (p+E2).
+//
+// Since N + A + E1 + E2 == N, the result always equals 4*p; it is computed via the actual four-term sum, though.
+// Note that two of the translates are at infinity if p itself is 2-torsion, so this relies on (and exercises) the exceptional cases of the full-curve addition law.
+// For NaP inputs, the result is a NaP.
+func (p *Point_xtw_full) SumOverCofactor() (ret Point_xtw_full) {
+	if p.IsNaP() {
+		napEncountered("SumOverCofactor called on NaP", false, p)
+		return // zero value is a NaP
+	}
+	var translateA, translateE1, translateE2 Point_xtw_full = *p, *p, *p
+	translateA.torsionAddA()
+	translateE1.torsionAddE1()
+	translateE2.torsionAddE2()
+
+	ret.Add(p, &translateA)
+	ret.AddEq(&translateE1)
+	ret.AddEq(&translateE2)
+	return
+}
+
+// Order returns the order of p in the group of rational points of the Bandersnatch curve.
+//
+// Since this group is isomorphic to Z/p253 x Z/2 x Z/2, the result is one of 1, 2, GroupOrder_Int or 2*GroupOrder_Int.
+// The returned big.Int is freshly allocated and may be modified by the caller.
+// For NaP inputs, we return nil (after calling the NaP handler).
+func (p *Point_xtw_full) Order() *big.Int {
+	if p.IsNaP() {
+		napEncountered("Order called on NaP", false, p)
+		return nil
+	}
+	if p.IsNeutralElement() {
+		return big.NewInt(1)
+	}
+	var doubled Point_xtw_full
+	doubled.Double(p)
+	if doubled.IsNeutralElement() {
+		return big.NewInt(2)
+	}
+	if p.IsInSubgroup() {
+		return new(big.Int).Set(GroupOrder_Int)
+	}
+	return new(big.Int).Lsh(GroupOrder_Int, 1)
+}
+
+// ClearCofactor sets p = Cofactor * input = 4 * input. The result is always in the prime-order subgroup, even if input is not.
+//
+// Since the group of rational points is isomorphic to Z/p253 x Z/2 x Z/2, multiplication by 4 kills the 2-torsion part and maps onto the subgroup.
+// In particular, the affine point A of order two and the two points at infinity E1, E2 are all mapped to the neutral element.
+// Note that ClearCofactor is NOT a projection onto the subgroup: For input in the subgroup, the output is 4 * input rather than input.
+// For NaP inputs, p is set to a NaP (after calling the NaP handler).
+func (p *Point_xtw_subgroup) ClearCofactor(input CurvePointPtrInterfaceRead) {
+	if input.IsNaP() {
+		napEncountered("ClearCofactor called on NaP", false, input)
+		*p = Point_xtw_subgroup{}
+		return
+	}
+	fourTimesInput := clearCofactor(input)
+	p.SetFromSubgroupPoint(&fourTimesInput, trustedInput)
+}
+
+// ClearCofactor sets p = Cofactor * input = 4 * input. See Point_xtw_subgroup.ClearCofactor for details.
+func (p *Point_axtw_subgroup) ClearCofactor(input CurvePointPtrInterfaceRead) {
+	if input.IsNaP() {
+		napEncountered("ClearCofactor called on NaP", false, input)
+		*p = Point_axtw_subgroup{}
+		return
+	}
+	fourTimesInput := clearCofactor(input)
+	p.SetFromSubgroupPoint(&fourTimesInput, trustedInput)
+}
+
+// ClearCofactor sets p = Cofactor * input = 4 * input. See Point_xtw_subgroup.ClearCofactor for details.
+func (p *Point_efgh_subgroup) ClearCofactor(input CurvePointPtrInterfaceRead) {
+	if input.IsNaP() {
+		napEncountered("ClearCofactor called on NaP", false, input)
+		*p = Point_efgh_subgroup{}
+		return
+	}
+	fourTimesInput := clearCofactor(input)
+	p.SetFromSubgroupPoint(&fourTimesInput, trustedInput)
+}
+
+// clearCofactor returns 4 * input for non-NaP input, which is guaranteed to be in the prime-order subgroup.
+func clearCofactor(input CurvePointPtrInterfaceRead) (ret Point_xtw_full) {
+	ret.SetFrom(input)
+	ret.DoubleEq() // 2 * input is in the subgroup already, as there are no points of order 4.
+	ret.DoubleEq()
+	return
+}
@@ -105,6 +105,38 @@ func checkfun_alias_Endo(s *TestSample) (bool, string) {
 	return guardForInvalidPoints(expected, singular, "Computing endomorphism failed when receiver aliases argument", clone1.IsEqual, result)
 }
 
+// make_checkfun_alias_Endo_reference checks that computing the endomorphism in-place (via both Endo and EndoEq) matches
+// the endomorphism computed into a separate receiver of type referenceType from an unaliased copy of the input.
+func make_checkfun_alias_Endo_reference(referenceType PointType) checkfunction {
+	return func(s *TestSample) (bool, string) {
+		s.AssertNumberOfPoints(1)
+		singular := s.AnyFlags().CheckFlag(PointFlagNAP)
+		var clone1 CurvePointPtrInterface = s.Points[0].Clone()
+		var clone2 CurvePointPtrInterface = s.Points[0].Clone()
+		inputCopy := s.Points[0].Clone()
+		reference := makeCurvePointPtrInterface(referenceType)
+		reference.Endo(inputCopy)
+		clone1.Endo(clone1)
+		clone2.EndoEq()
+		if singular {
+			return clone1.IsNaP() && clone2.IsNaP() && reference.IsNaP(), "Alias test for Endo did not get NaP when expected"
+		}
+		if clone1.IsNaP() || clone2.IsNaP() {
+			return false, "computing endo with receiver == argument resulted in NaP"
+		}
+		if !inputCopy.IsEqual(s.Points[0]) {
+			return false, "computing endo modified the input when receiver and input do not alias"
+		}
+		if !reference.IsEqual(clone1) {
+			return false, "p.Endo(p) differs from Endo computed into a non-aliasing receiver"
+		}
+		if !reference.IsEqual(clone2) {
+			return false, "p.EndoEq() differs from Endo computed into a non-aliasing receiver"
+		}
+		return true, ""
+	}
+}
+
 func checkfun_alias_AddEq(s *TestSample) (bool, string) {
 	s.AssertNumberOfPoints(1)
 	singular := s.AnyFlags().CheckFlag(PointFlagNAP)
@@ -178,3 +210,16 @@ func TestAliasingForEFGH(t *testing.T) {
 	test_aliasing_CurvePointPtrInterface(t, pointTypeEFGHFull, excludeNoPoints)
 	test_aliasing_CurvePointPtrInterface(t, pointTypeEFGHSubgroup, excludeNoPoints)
 }
+
+// TestAliasingEndoMatrix checks that Endo is safe if receiver and argument alias for every concrete point type,
+// comparing against the result computed into a receiver of every (compatible) other type.
+func TestAliasingEndoMatrix(t *testing.T) {
+	for _, inputType := range allTestPointTypes {
+		for _, referenceType := range allTestPointTypes {
+			if typeCanOnlyRepresentSubgroup(referenceType) && !typeCanOnlyRepresentSubgroup(inputType) {
+				continue
+			}
+			make_samples1_and_run_tests(t, make_checkfun_alias_Endo_reference(referenceType), "Alias testing for Endo failed "+pointTypeToString(inputType)+" vs. reference "+pointTypeToString(referenceType), inputType, 10, excludeNoPoints)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package curvePoints
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 )
 
 /*
@@ -415,3 +418,66 @@ func TestConsistencyXTWForEFGH(t *testing.T) {
 }
 
 */
+
+// getterRecordingPoint wraps a point and records calls to the projective coordinate getters.
+// As it is not among the point types of this package, SetFrom has to use its generic code paths.
+type getterRecordingPoint struct {
+	CurvePointPtrInterfaceCooReadExtended
+	calls []string
+}
+
+func (p *getterRecordingPoint) XYZ_projective() (FieldElement, FieldElement, FieldElement) {
+	p.calls = append(p.calls, "XYZ_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.XYZ_projective()
+}
+
+func (p *getterRecordingPoint) XYTZ_projective() (FieldElement, FieldElement, FieldElement, FieldElement) {
+	p.calls = append(p.calls, "XYTZ_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.XYTZ_projective()
+}
+
+func (p *getterRecordingPoint) X_projective() FieldElement {
+	p.calls = append(p.calls, "X_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.X_projective()
+}
+
+func (p *getterRecordingPoint) Y_projective() FieldElement {
+	p.calls = append(p.calls, "Y_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.Y_projective()
+}
+
+func (p *getterRecordingPoint) Z_projective() FieldElement {
+	p.calls = append(p.calls, "Z_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.Z_projective()
+}
+
+func (p *getterRecordingPoint) T_projective() FieldElement {
+	p.calls = append(p.calls, "T_projective")
+	return p.CurvePointPtrInterfaceCooReadExtended.T_projective()
+}
+
+// TestSetFromFastPaths checks that SetFrom uses the cheapest available coordinate getters for point types it does not know about,
+// i.e. XYTZ_projective for general receivers that need T and the decaf coordinates for subgroup-only receivers.
+func TestSetFromFastPaths(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var PFull Point_xtw_full
+	PFull.SetFrom(&P)
+
+	var receiversFull []CurvePointPtrInterface = []CurvePointPtrInterface{&Point_xtw_full{}, &Point_efgh_full{}, &Point_axtw_full{}}
+	expectedCallsFull := [][]string{{"XYTZ_projective"}, {"XYZ_projective"}, nil}
+	for i, receiver := range receiversFull {
+		input := getterRecordingPoint{CurvePointPtrInterfaceCooReadExtended: PFull.Clone().(*Point_xtw_full)}
+		receiver.SetFrom(&input)
+		testutils.FatalUnless(t, reflect.DeepEqual(input.calls, expectedCallsFull[i]), "SetFrom to %T called unexpected getters %v", receiver, input.calls)
+		testutils.FatalUnless(t, receiver.IsEqual(&PFull), "SetFrom to %T from unknown type gave wrong result", receiver)
+	}
+
+	var receiversSubgroup []CurvePointPtrInterface = []CurvePointPtrInterface{&Point_xtw_subgroup{}, &Point_efgh_subgroup{}, &Point_axtw_subgroup{}}
+	for _, receiver := range receiversSubgroup {
+		input := getterRecordingPoint{CurvePointPtrInterfaceCooReadExtended: P.Clone().(*Point_xtw_subgroup)}
+		receiver.SetFrom(&input)
+		testutils.FatalUnless(t, len(input.calls) == 0, "SetFrom to %T called non-decaf getters %v", receiver, input.calls)
+		testutils.FatalUnless(t, receiver.IsEqual(&P), "SetFrom to %T from unknown type gave wrong result", receiver)
+	}
+}
@@ -1,6 +1,7 @@
 package curvePoints
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
@@ -249,3 +250,25 @@ func checkfun_endo_action(s *TestSample) (bool, string) {
 	}
 	return true, ""
 }
+
+func TestIsEndoRelated(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var EndoP Point_xtw_subgroup
+		EndoP.Endo(&P)
+		testutils.FatalUnless(t, IsEndoRelated(&P, &EndoP), "IsEndoRelated(P, Endo(P)) is false")
+		testutils.FatalUnless(t, IsEndoRelated(&EndoP, &P), "IsEndoRelated(Endo(P), P) is false")
+		EndoP.flipDecaf()
+		testutils.FatalUnless(t, IsEndoRelated(&P, &EndoP), "IsEndoRelated depends on internal representation")
+
+		Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		testutils.FatalUnless(t, !IsEndoRelated(&P, &Q), "IsEndoRelated is true for random points")
+		var NegEndoP Point_xtw_subgroup
+		NegEndoP.Neg(&EndoP)
+		testutils.FatalUnless(t, !IsEndoRelated(&P, &NegEndoP), "IsEndoRelated(P, -Endo(P)) is true")
+	}
+	var N Point_xtw_subgroup
+	N.SetNeutral()
+	testutils.FatalUnless(t, IsEndoRelated(&N, &N), "IsEndoRelated(N, N) is false")
+}
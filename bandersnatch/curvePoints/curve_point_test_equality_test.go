@@ -140,3 +140,37 @@ func TestEqualityProperties(t *testing.T) {
 		}
 	}
 }
+
+// checks that comparing a point with itself (via the same pointer) gives true for valid points and is treated as a NaP-comparison for NaPs.
+func TestSelfComparison(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for _, pointType := range allTestPointTypes {
+		point_string := pointTypeToString(pointType)
+		for i := 0; i < 20; i++ {
+			p := makeCurvePointPtrInterface(pointType)
+			randomPoint := MakeRandomPointUnsafe_xtw_subgroup(drng)
+			p.SetFrom(&randomPoint)
+			if !p.IsEqual(p) {
+				t.Fatalf("Self-comparison of valid point returned false for %v", point_string)
+			}
+		}
+		p := makeCurvePointPtrInterface(pointType)
+		p.SetNeutral()
+		if !p.IsEqual(p) {
+			t.Fatalf("Self-comparison of neutral element returned false for %v", point_string)
+		}
+
+		// zero-initialized points are NaPs
+		nap := makeCurvePointPtrInterface(pointType)
+		if !nap.IsNaP() {
+			t.Fatalf("Zero-initialized point is not a NaP for %v", point_string)
+		}
+		var result bool = true
+		if !wasInvalidPointEncountered(func() { result = nap.IsEqual(nap) }) {
+			t.Fatalf("Self-comparison of NaP did not trigger NaP-handler for %v", point_string)
+		}
+		if result {
+			t.Fatalf("Self-comparison of NaP returned true for %v", point_string)
+		}
+	}
+}
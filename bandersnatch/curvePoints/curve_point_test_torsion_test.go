@@ -1,6 +1,12 @@
 package curvePoints
 
-import "testing"
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
 
 // This file contains test for the torsionAddA, torstionAddE1, torsionAddE2 methods of curve points.
 // (i.e. for points satisfying the torsionAdder interface)
@@ -150,3 +156,157 @@ func TestTorsionAddProperties(t *testing.T) {
 		make_samples1_and_run_tests(t, checkfun_torsion_group, "torsionAdd_foo do not form a Z/2 x Z/2 group "+pointstring, pointType, 50, excludeNoPoints)
 	}
 }
+
+func TestCofactorDifference(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	offsets := []*Point_xtw_full{&NeutralElement_xtw_full, &AffineOrderTwoPoint_xtw, &InfinitePoint1_xtw, &InfinitePoint2_xtw}
+	for i := 0; i < 20; i++ {
+		var P Point_xtw_full
+		if i == 0 {
+			P.SetNeutral()
+		} else {
+			P = MakeRandomPointUnsafe_xtw_full(drng)
+		}
+		for expected, offset := range offsets {
+			var Q Point_xtw_full
+			Q.Add(&P, offset)
+			diff, ok := CofactorDifference(&P, &Q)
+			testutils.FatalUnless(t, ok, "CofactorDifference did not recognize offset %v", expected)
+			testutils.FatalUnless(t, diff == expected, "CofactorDifference returned %v, expected %v", diff, expected)
+			var QEfgh Point_efgh_full
+			QEfgh.SetFrom(&Q)
+			diff, ok = CofactorDifference(&P, &QEfgh)
+			testutils.FatalUnless(t, ok && diff == expected, "CofactorDifference does not work for mixed types")
+		}
+		R := MakeRandomPointUnsafe_xtw_full(drng)
+		_, ok := CofactorDifference(&P, &R)
+		testutils.FatalUnless(t, !ok, "CofactorDifference returned ok for unrelated points")
+	}
+
+	// subgroup type vs. full type carrying +A
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		P.flipDecaf() // must not matter
+		var PFull, PPlusA Point_xtw_full
+		PFull.SetFrom(&P)
+		PPlusA.Add(&PFull, &AffineOrderTwoPoint_xtw)
+		testutils.FatalUnless(t, P.IsEqual(&PFull), "Conversion to Point_xtw_full changed the point")
+		diff, ok := CofactorDifference(&P, &PFull)
+		testutils.FatalUnless(t, ok && diff == 0, "CofactorDifference of subgroup point and its full representation is %v, %v", diff, ok)
+		diff, ok = CofactorDifference(&P, &PPlusA)
+		testutils.FatalUnless(t, ok && diff == 1, "CofactorDifference did not detect +A with subgroup point. Got %v, %v", diff, ok)
+		diff, ok = CofactorDifference(&PPlusA, &P)
+		testutils.FatalUnless(t, ok && diff == 1, "CofactorDifference is not symmetric for +A")
+	}
+
+	var NaP Point_xtw_full
+	P := MakeRandomPointUnsafe_xtw_full(drng)
+	_, ok := CofactorDifference(&NaP, &P)
+	testutils.FatalUnless(t, !ok, "CofactorDifference returned ok for NaP")
+}
+
+func TestSumOverCofactor(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	points := []Point_xtw_full{NeutralElement_xtw_full, AffineOrderTwoPoint_xtw, InfinitePoint1_xtw, InfinitePoint2_xtw}
+	for i := 0; i < 20; i++ {
+		points = append(points, MakeRandomPointUnsafe_xtw_full(drng))
+	}
+	for _, P := range points {
+		// expected result via direct sum of the translates
+		var expected, translate Point_xtw_full
+		expected.SetNeutral()
+		for _, g := range []*Point_xtw_full{&NeutralElement_xtw_full, &AffineOrderTwoPoint_xtw, &InfinitePoint1_xtw, &InfinitePoint2_xtw} {
+			translate.Add(&P, g)
+			expected.AddEq(&translate)
+		}
+		result := P.SumOverCofactor()
+		testutils.FatalUnless(t, !result.IsNaP(), "SumOverCofactor resulted in NaP for %v", P)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "SumOverCofactor differs from direct sum for %v", P)
+
+		var fourP Point_xtw_full = P
+		fourP.DoubleEq()
+		fourP.DoubleEq()
+		testutils.FatalUnless(t, result.IsEqual(&fourP), "SumOverCofactor differs from 4*P for %v", P)
+	}
+
+	var NaP Point_xtw_full
+	var result Point_xtw_full
+	didNaP := wasInvalidPointEncountered(func() { result = NaP.SumOverCofactor() })
+	testutils.FatalUnless(t, didNaP, "SumOverCofactor on NaP did not call NaP handler")
+	testutils.FatalUnless(t, result.IsNaP(), "SumOverCofactor on NaP did not result in NaP")
+}
+
+func TestOrder(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	twiceGroupOrder := new(big.Int).Lsh(GroupOrder_Int, 1)
+
+	testutils.FatalUnless(t, NeutralElement_xtw_full.Order().Cmp(big.NewInt(1)) == 0, "Order of neutral element is not 1")
+	for _, P := range []Point_xtw_full{AffineOrderTwoPoint_xtw, InfinitePoint1_xtw, InfinitePoint2_xtw} {
+		testutils.FatalUnless(t, P.Order().Cmp(big.NewInt(2)) == 0, "Order of 2-torsion point %v is not 2", P)
+	}
+	for i := 0; i < 20; i++ {
+		var Q Point_xtw_subgroup
+		var scalar *big.Int = new(big.Int).Rand(drng, GroupOrder_Int)
+		Q.ScalarMult(&SubgroupGenerator_xtw_subgroup, scalar)
+		if Q.IsNeutralElement() {
+			continue
+		}
+		var P Point_xtw_full
+		P.SetFrom(&Q)
+		order := P.Order()
+		testutils.FatalUnless(t, order.Cmp(GroupOrder_Int) == 0, "Order of subgroup point is not GroupOrder")
+		order.SetInt64(0) // must not affect GroupOrder_Int
+		testutils.FatalUnless(t, GroupOrder_Int.Sign() != 0, "Order returned reference to GroupOrder_Int")
+
+		P.torsionAddA()
+		testutils.FatalUnless(t, P.Order().Cmp(twiceGroupOrder) == 0, "Order of subgroup point + A is not 2*GroupOrder")
+		P.torsionAddE1()
+		testutils.FatalUnless(t, P.Order().Cmp(twiceGroupOrder) == 0, "Order of subgroup point + E2 is not 2*GroupOrder")
+	}
+
+	var NaP Point_xtw_full
+	var result *big.Int
+	didNaP := wasInvalidPointEncountered(func() { result = NaP.Order() })
+	testutils.FatalUnless(t, didNaP, "Order on NaP did not call NaP handler")
+	testutils.FatalUnless(t, result == nil, "Order on NaP did not return nil")
+}
+
+func TestClearCofactor(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	inputs := []CurvePointPtrInterfaceRead{&NeutralElement_xtw_full, &AffineOrderTwoPoint_xtw, &InfinitePoint1_xtw, &InfinitePoint2_xtw}
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		Q := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		inputs = append(inputs, &P, &Q)
+	}
+	for _, input := range inputs {
+		var expected Point_xtw_full
+		expected.SetFrom(input)
+		expected.DoubleEq()
+		expected.DoubleEq()
+		testutils.FatalUnless(t, expected.IsInSubgroup(), "4 * P is not in the subgroup for %v", input)
+
+		for _, pointType := range []PointType{pointTypeXTWSubgroup, pointTypeAXTWSubgroup, pointTypeEFGHSubgroup} {
+			result := makeCurvePointPtrInterface(pointType).(interface {
+				CurvePointPtrInterface
+				ClearCofactor(CurvePointPtrInterfaceRead)
+			})
+			result.ClearCofactor(input)
+			testutils.FatalUnless(t, !result.IsNaP(), "ClearCofactor resulted in NaP for %v", pointTypeToString(pointType))
+			testutils.FatalUnless(t, result.IsEqual(&expected), "ClearCofactor differs from 4 * P for %v", pointTypeToString(pointType))
+		}
+	}
+
+	// 2-torsion points are mapped to the neutral element.
+	for _, input := range []*Point_xtw_full{&AffineOrderTwoPoint_xtw, &InfinitePoint1_xtw, &InfinitePoint2_xtw} {
+		var result Point_xtw_subgroup
+		result.ClearCofactor(input)
+		testutils.FatalUnless(t, result.IsNeutralElement(), "ClearCofactor did not map 2-torsion point to neutral element")
+	}
+
+	var NaP Point_xtw_full
+	var result Point_axtw_subgroup = NeutralElement_axtw_subgroup
+	didNaP := wasInvalidPointEncountered(func() { result.ClearCofactor(&NaP) })
+	testutils.FatalUnless(t, didNaP, "ClearCofactor on NaP did not call NaP handler")
+	testutils.FatalUnless(t, result.IsNaP(), "ClearCofactor on NaP did not result in NaP")
+}
@@ -1,10 +1,15 @@
 package curvePoints
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
 	"math/rand"
 
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
 )
 
 // point_xtw_base is a struct holding x,y,t,z values that can be used to represent an elliptic curve point on the Bandersnatch curve.
@@ -24,6 +29,9 @@ type point_xtw_base struct {
 // Point_xtw describes points on the p253-subgroup of the Bandersnatch curve in extended twisted Edwards coordinates.
 // Extended means that we additionally store T with T = X*Y/Z. Note that both Y and Z are never 0 for points in the subgroup.
 // cf. https://iacr.org/archive/asiacrypt2008/53500329/53500329.pdf
+//
+// NOTE: Seemingly read-only methods such as X_affine or X_projective may change the internal representation.
+// Consequently, it is not safe to call those concurrently on the same point. Use Snapshot to obtain a copy for each goroutine instead.
 type Point_xtw_subgroup struct {
 	thisCurvePointCanOnlyRepresentSubgroup
 	thisCurvePointCannotRepresentInfinity
@@ -270,6 +278,69 @@ func (p *Point_xtw_full) XYT_affine() (FieldElement, FieldElement, FieldElement)
 	return p.x, p.y, p.t
 }
 
+// AffineFieldElements returns the affine X and Y coordinates of p as plain field elements, without any header or sign bits.
+// This is intended for callers who feed the coordinates into their own serializers or hash functions.
+//
+// As opposed to XY_affine, this does not panic for points at infinity or NaPs, but returns an error instead.
+// Possible errors are ErrCannotSerializePointAtInfinity and ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_xtw_full) AffineFieldElements() (x, y FieldElement, err error) {
+	if p.IsNaP() {
+		err = bandersnatchErrors.ErrCannotSerializeNaP
+		return
+	}
+	if p.IsAtInfinity() {
+		err = bandersnatchErrors.ErrCannotSerializePointAtInfinity
+		return
+	}
+	x, y = p.XY_affine()
+	return
+}
+
+// AffineString returns a human-readable representation of p of the form "(X, Y)", where X and Y are the affine coordinates written as decimal numbers.
+// As opposed to String(), the output does not depend on the internal projective representation.
+//
+// Points at infinity and NaPs have no affine coordinates; for these, we return the same errors as AffineFieldElements.
+func (p *Point_xtw_full) AffineString() (string, error) {
+	x, y, err := p.AffineFieldElements()
+	if err != nil {
+		return "", err
+	}
+	return "(" + x.String() + ", " + y.String() + ")", nil
+}
+
+// XAndSignY returns the affine X coordinate of p together with the sign (+1 or -1) of its affine Y coordinate.
+// This is equivalent to calling XY_affine and then Sign() on the Y coordinate, but only normalizes p once.
+// Note that the Y coordinate of a point not at infinity is never zero, so signY is never 0 if err == nil.
+//
+// Possible errors are ErrCannotSerializePointAtInfinity and ErrCannotSerializeNaP from the bandersnatchErrors package, as for AffineFieldElements.
+func (p *Point_xtw_full) XAndSignY() (x FieldElement, signY int, err error) {
+	if p.IsNaP() {
+		err = bandersnatchErrors.ErrCannotSerializeNaP
+		return
+	}
+	if p.IsAtInfinity() {
+		err = bandersnatchErrors.ErrCannotSerializePointAtInfinity
+		return
+	}
+	p.normalizeAffineZ()
+	x = p.x
+	signY = p.y.Sign()
+	return
+}
+
+// SetFromAffineFieldElements sets p to the point with the given affine X and Y coordinates. This is the inverse of AffineFieldElements.
+// trustLevel should be one of TrustedInput or UntrustedInput. For UntrustedInput, we check that (x,y) is on the curve.
+//
+// On error, p is unchanged. Possible errors are the same as for CurvePointFromXYAffine_full.
+func (p *Point_xtw_full) SetFromAffineFieldElements(x, y *FieldElement, trustLevel IsInputTrusted) error {
+	point, err := CurvePointFromXYAffine_full(x, y, trustLevel)
+	if err != nil {
+		return err
+	}
+	p.SetFrom(&point)
+	return nil
+}
+
 // X_projective returns the X coordinate of the given point in projective twisted Edwards coordinates.
 //
 // CAVEAT: Subsequent calls to any <foo>_projective methods on the same point are only guaranteed to be consistent if nothing else is done with the point between the calls.
@@ -364,6 +435,16 @@ func (p *Point_xtw_full) XYTZ_projective() (FieldElement, FieldElement, FieldEle
 	return p.x, p.y, p.t, p.z
 }
 
+// RawProjective returns the X,Y,T and Z coordinates exactly as stored internally, without any normalization.
+//
+// NOTE: Since Point_xtw_subgroup works modulo the affine two-torsion point A, the returned coordinates may be those of the +A representative, i.e. P+A rather than P.
+// Use XYTZ_projective if you need coordinates of P itself.
+// As opposed to XYTZ_projective, this method never modifies the internal representation, so concurrent calls to RawProjective on the same point are safe.
+// This method is mostly intended for debugging purposes.
+func (p *Point_xtw_subgroup) RawProjective() (x, y, t, z FieldElement) {
+	return p.x, p.y, p.t, p.z
+}
+
 // X_decaf_projective returns the X coordinate of either P or P+A in projective twisted Edwards coordinates, where A is the affine point of order two.
 //
 // CAVEAT: Subsequent calls to any <foo>_decaf_projective methods are only guaranteed to be consistent if nothing else is done with the point between those calls.
@@ -453,47 +534,279 @@ func (p *Point_xtw_full) IsInSubgroup() bool {
 	return legendreCheckA_projectiveXZ(p.x, p.z) && legendreCheckE1_projectiveYZ(p.y, p.z)
 }
 
-// TODO
-/*
-// SerializeLong serialize the given point in long serialization format. err==nil iff everything worked OK.
-func (p *Point_xtw) SerializeLong(output io.Writer) (bytes_written int, err error) {
-	return default_SerializeLong(p, output)
+// bitHeaderBanderwagonY is the bit header of the Y*Sign(Y) field element in the Banderwagon long format.
+// The X*Sign(Y) field element uses hexShortFormBitHeader. These must match the ones of the Banderwagon serializers in the pointserializer package.
+var bitHeaderBanderwagonY common.BitHeader = common.MakeBitHeader(common.PrefixBits(0b00), 2)
+
+// DetectBanderwagonFormat determines from the first 32 bytes of an encoding whether it is in Banderwagon short format X*Sign(Y) or long format Y*Sign(Y)||X*Sign(Y),
+// assuming common.DefaultEndian. The decision is made from the bit header of the first field element: 1 for the short format, 00 for the long format.
+// ok is false if the bit header is 01, which matches neither format.
+//
+// Note that this only looks at the header; it does not check that the rest of the encoding is valid.
+// This is used by DeserializeAuto and by the pointserializer package's DeserializeAutoDetect.
+func DetectBanderwagonFormat(firstFieldElement *[32]byte) (long bool, ok bool) {
+	var msb byte
+	if common.DefaultEndian.StartsWithMSB() {
+		msb = firstFieldElement[0]
+	} else {
+		msb = firstFieldElement[31]
+	}
+	switch {
+	case common.PrefixBits(msb>>(8-hexShortFormBitHeader.PrefixLen())) == hexShortFormBitHeader.PrefixBits():
+		return false, true
+	case common.PrefixBits(msb>>(8-bitHeaderBanderwagonY.PrefixLen())) == bitHeaderBanderwagonY.PrefixBits():
+		return true, true
+	default:
+		return false, false
+	}
 }
 
-// SerializeShort serialize the given point in short serialization format. err==nil iff everything worked OK.
-func (p *Point_xtw) SerializeShort(output io.Writer) (bytes_written int, err error) {
-	return default_SerializeShort(p, output)
+// serializeBanderwagon writes point to output in Banderwagon short format X*Sign(Y) (if long == false) or long format Y*Sign(Y)||X*Sign(Y) (if long == true).
+// The output is identical to the one of the corresponding basic serializers from the pointserializer package (which we cannot use directly due to import cycles).
+//
+// Possible errors are ErrCannotSerializeNaP, ErrCannotSerializePointAtInfinity and ErrWillNotSerializePointOutsideSubgroup from the bandersnatchErrors package
+// (which are returned before writing anything) and errors from output.
+func serializeBanderwagon(output io.Writer, point CurvePointPtrInterfaceRead, long bool) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	var errPlain error
+	if point.IsNaP() {
+		errPlain = bandersnatchErrors.ErrCannotSerializeNaP
+	} else if point.IsAtInfinity() {
+		errPlain = bandersnatchErrors.ErrCannotSerializePointAtInfinity
+	} else if !point.CanOnlyRepresentSubgroup() && !point.IsInSubgroup() {
+		errPlain = bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup
+	}
+	if errPlain != nil {
+		err = errorsWithData.NewErrorWithParametersFromData(errPlain, "", &bandersnatchErrors.WriteErrorData{PartialWrite: false, BytesWritten: 0})
+		return
+	}
+	var pSubgroup Point_xtw_subgroup
+	pSubgroup.SetFromSubgroupPoint(point, trustedInput) // we checked subgroup membership above.
+
+	// The short format and the second half of the long format are exactly what EncodeTo writes.
+	var encodingX [hexShortFormByteLength]byte
+	if errPlain = pSubgroup.EncodeTo(&encodingX); errPlain != nil {
+		panic(fmt.Errorf(ErrorPrefix+"EncodeTo failed for a point that is not a NaP. This is not supposed to be possible: %w", errPlain))
+	}
+	if long {
+		Y := pSubgroup.Y_decaf_affine()
+		if Y.Sign() < 0 {
+			Y.NegEq()
+		}
+		bytesWritten, err = Y.SerializeWithPrefix(output, bitHeaderBanderwagonY, common.DefaultEndian)
+		if err != nil {
+			return
+		}
+	}
+	bytesJustWritten, errPlain := output.Write(encodingX[:])
+	bytesWritten += bytesJustWritten
+	err = errorsWithData.IncludeDataInError(errPlain, &bandersnatchErrors.WriteErrorData{PartialWrite: bytesJustWritten != 0 && bytesJustWritten != hexShortFormByteLength, BytesWritten: bytesJustWritten})
+	if long && err != nil {
+		// We treat the pair of field elements as a unit, so failing after the first one is a partial write.
+		// As in the pointserializer package, the BytesWritten metadata refers to the failing sub-call.
+		bandersnatchErrors.UnexpectEOF2(&err)
+		err = errorsWithData.IncludeGuaranteedParametersInError[bandersnatchErrors.WriteErrorData](err, bandersnatchErrors.FIELDNAME_PARTIAL_WRITE, bytesWritten != 0)
+	}
+	return
 }
-*/
 
-// TODO !
+// SerializeShort writes p in Banderwagon short format, i.e. the 32-byte X*Sign(Y), to output. The result matches that of the pointserializer package's Banderwagon short serializer.
+//
+// Possible errors are ErrCannotSerializeNaP and ErrCannotSerializePointAtInfinity from the bandersnatchErrors package
+// (which are returned without writing anything) and errors from output.
+func (p *Point_xtw_subgroup) SerializeShort(output io.Writer) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	return serializeBanderwagon(output, p, false)
+}
 
-/*
-// DeserializeShort deserialize from the given input byte stream (expecting it to start with a point in short serialization format) and store the result in the receiver.
-// err==nil iff no error occured. trusted should be one of the constants TrustedInput or UntrustedInput.
-// For UntrustedInput, we perform a specially-tailored efficient curve and subgroup membership tests.
-// Note that long format is considerably more efficient to deserialize.
-func (p *Point_xtw) DeserializeShort(input io.Reader, trusted IsPointTrusted) (bytes_read int, err error) {
-	return default_DeserializeShort(p, input, trusted)
+// SerializeLong writes p in Banderwagon long format, i.e. the 64-byte Y*Sign(Y)||X*Sign(Y), to output. The result matches that of the pointserializer package's Banderwagon long serializer.
+//
+// Possible errors are as for SerializeShort.
+func (p *Point_xtw_subgroup) SerializeLong(output io.Writer) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	return serializeBanderwagon(output, p, true)
 }
 
-// DeserializeLong deserialize from the given input byte stream (expecting it to start with a point in long serialization format) and store the result in the receiver.
-// err==nil iff no error occured. trusted should be one of the constants TrustedInput or UntrustedInput.
-// For UntrustedInput, we perform a specially-tailored efficient curve and subgroup membership tests.
-// Note that long format is considerably more efficient to deserialize.
-func (p *Point_xtw) DeserializeLong(input io.Reader, trusted IsPointTrusted) (bytes_read int, err error) {
-	return default_DeserializeLong(p, input, trusted)
+// SerializeShort writes p in Banderwagon short format, i.e. the 32-byte X*Sign(Y), to output. The result matches that of the pointserializer package's Banderwagon short serializer.
+//
+// Since this format only covers the prime-order subgroup, points outside of it give ErrWillNotSerializePointOutsideSubgroup.
+// Other possible errors are ErrCannotSerializeNaP and ErrCannotSerializePointAtInfinity from the bandersnatchErrors package
+// (which are returned without writing anything) and errors from output.
+func (p *Point_xtw_full) SerializeShort(output io.Writer) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	return serializeBanderwagon(output, p, false)
 }
 
-// DeserializeAuto deserialize from the given input byte stream (expecting it to start with a point in either short or long serialization format -- it autodetects that) and store the result in the receiver.
-// err==nil iff no error occured. trusted should be one of the constants TrustedInput or UntrustedInput.
-// For UntrustedInput, we perform a specially-tailored efficient curve and subgroup membership tests.
-// Note that long format is considerably more efficient to deserialize.
-func (p *Point_xtw) DeserializeAuto(input io.Reader, trusted IsPointTrusted) (bytes_read int, err error) {
-	return default_DeserializeAuto(p, input, trusted)
+// SerializeLong writes p in Banderwagon long format, i.e. the 64-byte Y*Sign(Y)||X*Sign(Y), to output. The result matches that of the pointserializer package's Banderwagon long serializer.
+//
+// Possible errors are as for SerializeShort.
+func (p *Point_xtw_full) SerializeLong(output io.Writer) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	return serializeBanderwagon(output, p, true)
 }
 
-*/
+// banderwagonFormat selects the format expected by deserializeBanderwagon.
+type banderwagonFormat int
+
+const (
+	banderwagonShort banderwagonFormat = iota // X*Sign(Y)
+	banderwagonLong                           // Y*Sign(Y)||X*Sign(Y)
+	banderwagonAuto                           // either of the above, detected from the header of the first field element.
+)
+
+// deserializeBanderwagon reads a point in Banderwagon short or long format (as written by SerializeShort resp. SerializeLong) from input.
+// For format == banderwagonAuto, we decide via DetectBanderwagonFormat, which only needs the first 32 bytes.
+// We read only 32 bytes for short encodings (and for malformed headers) and 64 bytes for long encodings.
+//
+// For untrusted input, we check that the result is on the curve and in the prime-order subgroup.
+// The returned error's data accurately reflects the number of bytes read. On error, point must not be used.
+func deserializeBanderwagon(input io.Reader, trustLevel IsInputTrusted, format banderwagonFormat) (point Point_axtw_subgroup, bytesRead int, err bandersnatchErrors.DeserializationError) {
+	var buf [64]byte
+	var errPlain error
+	bytesRead, errPlain = io.ReadFull(input, buf[0:32])
+	if errPlain != nil {
+		err = errorsWithData.IncludeDataInError(errPlain, &bandersnatchErrors.ReadErrorData{
+			PartialRead:  bytesRead != 0,
+			BytesRead:    bytesRead,
+			ActuallyRead: buf[0:bytesRead],
+		})
+		return
+	}
+
+	if format == banderwagonAuto {
+		long, ok := DetectBanderwagonFormat((*[32]byte)(buf[0:32]))
+		switch {
+		case ok && !long:
+			format = banderwagonShort
+		case ok && long:
+			format = banderwagonLong
+		default:
+			err = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrDidNotReadExpectedString, "%w: header of the first field element matches neither Banderwagon short nor long format", &bandersnatchErrors.ReadErrorData{
+				PartialRead:  false,
+				BytesRead:    bytesRead,
+				ActuallyRead: buf[0:bytesRead],
+			})
+			return
+		}
+	}
+
+	if format == banderwagonLong {
+		var bytesJustRead int
+		bytesJustRead, errPlain = io.ReadFull(input, buf[32:64])
+		bytesRead += bytesJustRead
+		if errPlain != nil {
+			bandersnatchErrors.UnexpectEOF(&errPlain) // we already read the first field element, so EOF is unexpected.
+			err = errorsWithData.IncludeDataInError(errPlain, &bandersnatchErrors.ReadErrorData{
+				PartialRead:  true,
+				BytesRead:    bytesRead,
+				ActuallyRead: buf[0:bytesRead],
+			})
+			return
+		}
+	}
+
+	// makeErr is used for errors from here on, where the whole encoding was read.
+	makeErr := func(errPlain error) bandersnatchErrors.DeserializationError {
+		return errorsWithData.NewErrorWithParametersFromData(errPlain, "%w", &bandersnatchErrors.ReadErrorData{
+			PartialRead:  false,
+			BytesRead:    bytesRead,
+			ActuallyRead: buf[0:bytesRead],
+		})
+	}
+
+	// Note: Non-normalized field elements would make the encoding malleable, so we treat ErrNonNormalizedDeserialization as an error.
+	var XSignY, YSignY FieldElement
+	if format == banderwagonShort {
+		if errPlain = XSignY.SetBytesWithPrefix((*[32]byte)(buf[0:32]), hexShortFormBitHeader, common.DefaultEndian); errPlain != nil {
+			err = makeErr(errPlain)
+			return
+		}
+		var errConversion error
+		point, errConversion = CurvePointFromXTimesSignY_subgroup(&XSignY, trustLevel)
+		if errConversion != nil {
+			err = makeErr(errConversion)
+		}
+		return
+	}
+	if errPlain = YSignY.SetBytesWithPrefix((*[32]byte)(buf[0:32]), bitHeaderBanderwagonY, common.DefaultEndian); errPlain != nil {
+		err = makeErr(errPlain)
+		return
+	}
+	if errPlain = XSignY.SetBytesWithPrefix((*[32]byte)(buf[32:64]), hexShortFormBitHeader, common.DefaultEndian); errPlain != nil {
+		err = makeErr(errPlain)
+		return
+	}
+	var errConversion error
+	point, errConversion = CurvePointFromXYTimesSignY_subgroup(&XSignY, &YSignY, trustLevel)
+	if errConversion != nil {
+		err = makeErr(errConversion)
+	}
+	return
+}
+
+// DeserializeShort reads a point in Banderwagon short format (as written by SerializeShort) from input and stores it in the receiver.
+// trustLevel should be one of TrustedInput or UntrustedInput. For UntrustedInput, we check that the point is on the curve and in the prime-order subgroup.
+//
+// On error, the receiver is untouched. The error's data contains the number of bytes read.
+func (p *Point_xtw_subgroup) DeserializeShort(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonShort)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
+
+// DeserializeLong reads a point in Banderwagon long format (as written by SerializeLong) from input and stores it in the receiver.
+// Apart from the format, it behaves like DeserializeShort.
+func (p *Point_xtw_subgroup) DeserializeLong(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonLong)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
+
+// DeserializeAuto reads a point in either Banderwagon short or long format from input and stores it in the receiver.
+// The format is detected from the header bits of the first field element and we only consume as many bytes as that format needs.
+// If the header matches neither format, we return an error wrapping ErrDidNotReadExpectedString (from the bandersnatchErrors package).
+// Otherwise, it behaves like DeserializeShort.
+func (p *Point_xtw_subgroup) DeserializeAuto(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonAuto)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
+
+// DeserializeShort reads a point in Banderwagon short format (as written by SerializeShort) from input and stores it in the receiver.
+// trustLevel should be one of TrustedInput or UntrustedInput. For UntrustedInput, we check that the point is on the curve and in the prime-order subgroup.
+// Note that this format can only encode points in the prime-order subgroup.
+//
+// On error, the receiver is untouched. The error's data contains the number of bytes read.
+func (p *Point_xtw_full) DeserializeShort(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonShort)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
+
+// DeserializeLong reads a point in Banderwagon long format (as written by SerializeLong) from input and stores it in the receiver.
+// Apart from the format, it behaves like DeserializeShort.
+func (p *Point_xtw_full) DeserializeLong(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonLong)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
+
+// DeserializeAuto reads a point in either Banderwagon short or long format from input and stores it in the receiver.
+// The format is detected from the header bits of the first field element and we only consume as many bytes as that format needs.
+// If the header matches neither format, we return an error wrapping ErrDidNotReadExpectedString (from the bandersnatchErrors package).
+// Otherwise, it behaves like DeserializeShort.
+func (p *Point_xtw_full) DeserializeAuto(input io.Reader, trustLevel IsInputTrusted) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	point, bytesRead, err := deserializeBanderwagon(input, trustLevel, banderwagonAuto)
+	if err == nil {
+		p.SetFrom(&point)
+	}
+	return
+}
 
 // String is defined to satisfy the fmt.Stringer interface and allows points to be used in most fmt routines.
 // Note that String() is defined on value receivers (as opposed to everything else) for an easier interface when using fmt routines.
@@ -543,6 +856,23 @@ func (p *point_xtw_base) Clone() CurvePointPtrInterfaceBaseRead {
 	return &p_copy
 }
 
+// Snapshot returns a copy of the given point (as a value).
+//
+// Snapshot never modifies p, so it is safe to call Snapshot concurrently from multiple goroutines on the same point, provided nothing writes to p.
+// By contrast, methods such as X_affine or X_projective may change the internal representation (without changing the point), so calling them concurrently is unsafe.
+// The intended usage for read-heavy concurrent workloads (such as a shared table of points) is for each goroutine to take a Snapshot and call read methods on that copy.
+func (p *Point_xtw_subgroup) Snapshot() Point_xtw_subgroup {
+	return *p
+}
+
+// Snapshot returns a copy of the given point (as a value).
+//
+// Snapshot never modifies p, so it is safe to call Snapshot concurrently from multiple goroutines on the same point, provided nothing writes to p.
+// By contrast, methods such as X_affine may change the internal representation (without changing the point), so calling them concurrently is unsafe.
+func (p *Point_xtw_full) Snapshot() Point_xtw_full {
+	return *p
+}
+
 // Clone returns a pointer to an independent copy of the given point.
 // The returned pointer is returned in a CurvePointPtrInterface interface, but the actual value is guaranteed to have the same type as the receiver.
 func (p *Point_xtw_full) Clone() CurvePointPtrInterface {
@@ -707,7 +1037,7 @@ func (p *Point_xtw_full) Neg(input CurvePointPtrInterfaceRead) {
 // a square root of -2.
 func (p *Point_xtw_subgroup) Endo(input CurvePointPtrInterfaceRead) {
 	var result_efgh Point_efgh_subgroup
-	result_efgh.Endo(input)
+	result_efgh.Endo(input) // Note: The result is fully computed before we write to p, so p and input may alias.
 	p.SetFrom(&result_efgh)
 }
 
@@ -717,7 +1047,18 @@ func (p *Point_xtw_subgroup) Endo(input CurvePointPtrInterfaceRead) {
 // a square root of -2.
 func (p *Point_xtw_full) Endo(input CurvePointPtrInterfaceRead) {
 	var result_efgh Point_efgh_full
-	result_efgh.Endo(input)
+	result_efgh.Endo(input) // Note: The result is fully computed before we write to p, so p and input may alias.
+	p.SetFrom(&result_efgh)
+}
+
+// NegEndo computes the negative of the efficient order-2 endomorphism on the given point, i.e. p = -Endo(input).
+// On the prime-order subgroup, this acts as multiplication by -EndomorphismEigenvalue.
+//
+// This is slightly more efficient than calling Endo followed by NegEq, since we negate within the intermediate efgh representation.
+func (p *Point_xtw_subgroup) NegEndo(input CurvePointPtrInterfaceRead) {
+	var result_efgh Point_efgh_subgroup
+	result_efgh.Endo(input) // Note: The result is fully computed before we write to p, so p and input may alias.
+	result_efgh.NegEq()
 	p.SetFrom(&result_efgh)
 }
 
@@ -790,6 +1131,10 @@ func (p *point_xtw_base) IsE2() bool {
 // IsEqual compares two curve points for equality.
 // The two points do not have to be in the same coordinate format.
 func (p *Point_xtw_subgroup) IsEqual(other CurvePointPtrInterfaceRead) bool {
+	// fast path for self-comparison. NaPs take the regular code path, which takes care of NaP-handling.
+	if other == CurvePointPtrInterfaceRead(p) && !p.IsNaP() {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_xtw_subgroup:
 		ret, potentialNaP := p.isEqual_moduloA_tt(&other.point_xtw_base)
@@ -836,6 +1181,10 @@ func (p *Point_xtw_full) IsEqual(other CurvePointPtrInterfaceRead) bool {
 	if p.IsNaP() || other.IsNaP() {
 		return napEncountered("NaP detected during comparison of xtw_full and other point", true, p, other)
 	}
+	// fast path for self-comparison. Note that NaPs were already handled above.
+	if other == CurvePointPtrInterfaceRead(p) {
+		return true
+	}
 	switch other := other.(type) {
 	case *Point_xtw_full:
 		ret, _ := p.isEqual_exact_tt(&other.point_xtw_base)
@@ -993,6 +1342,8 @@ func (p *Point_xtw_subgroup) SetFrom(input CurvePointPtrInterfaceRead) {
 	case *Point_efgh_subgroup:
 		p.point_xtw_base = input.toDecaf_xtw()
 	default:
+		// Note: We use the decaf coordinates rather than XYTZ_projective, since the latter may need to normalize the representative for subgroup-only types.
+		// This performs no multiplications.
 		ensureSubgroupOnly(input)
 		p.x = input.X_decaf_projective()
 		p.y = input.Y_decaf_projective()
@@ -1026,8 +1377,10 @@ func (p *Point_xtw_full) SetFrom(input CurvePointPtrInterfaceRead) {
 	case *Point_efgh_full:
 		p.point_xtw_base = input.toDecaf_xtw()
 	case CurvePointPtrInterfaceCooReadExtended:
+		// This performs no multiplications if the input stores extended projective coordinates.
 		p.x, p.y, p.t, p.z = input.XYTZ_projective()
 	default:
+		// Only types that do not provide a T coordinate end up here. All point types of this package are handled above.
 		p.x, p.y, p.z = input.XYZ_projective()
 		p.t.Mul(&p.x, &p.y)
 		p.x.MulEq(&p.z)
@@ -1052,6 +1405,58 @@ func (p *Point_xtw_subgroup) Validate() bool {
 	return p.point_xtw_base.isPointOnCurve() && legendreCheckA_projectiveXZ(p.x, p.z)
 }
 
+// CurveEquationResidual is the data included in errors returned by CheckOnCurve.
+//
+// Residual is z^2 + dt^2 - y^2 - ax^2, which for z == 1 is the quantity 1 - ax^2 - y^2 + dt^2 computed when deserializing points; it is zero iff the curve equation holds.
+// TResidual is xy - tz, which is zero iff the t coordinate is consistent with x, y and z.
+type CurveEquationResidual struct {
+	Residual  FieldElement
+	TResidual FieldElement
+}
+
+var (
+	ErrCurveEquationViolated = errors.New(ErrorPrefix + "point does not satisfy the curve equation")
+	ErrInconsistentT         = errors.New(ErrorPrefix + "t coordinate of point does not satisfy x*y == t*z")
+	ErrCheckOnCurveNaP       = errors.New(ErrorPrefix + "cannot check curve equation for a NaP")
+)
+
+// CheckOnCurve checks whether the point is a valid curve point. It is a diagnostic variant of Validate intended for debugging:
+// Rather than just a bool, it returns nil for valid points and a descriptive error otherwise.
+//
+// For invalid points, the returned error wraps ErrInconsistentT or ErrCurveEquationViolated and contains the offending values as CurveEquationResidual data.
+// For NaPs, the error wraps ErrCheckOnCurveNaP. Note that this does not call the NaP handler.
+func (p *Point_xtw_full) CheckOnCurve() error {
+	if p.IsNaP() {
+		return ErrCheckOnCurveNaP
+	}
+	var residuals CurveEquationResidual
+
+	// xy - tz
+	var temp FieldElement
+	residuals.TResidual.Mul(&p.x, &p.y)
+	temp.Mul(&p.t, &p.z)
+	residuals.TResidual.SubEq(&temp)
+
+	// z^2 + dt^2 - y^2 - ax^2, computed as in isPointOnCurve
+	residuals.Residual.Square(&p.t)
+	residuals.Residual.MulEq(&CurveParameterD_fe)
+	temp.Square(&p.z)
+	residuals.Residual.AddEq(&temp)
+	temp.Square(&p.y)
+	residuals.Residual.SubEq(&temp)
+	temp.Square(&p.x)
+	temp.Multiply_by_five()
+	residuals.Residual.AddEq(&temp)
+
+	if !residuals.TResidual.IsZero() {
+		return errorsWithData.NewErrorWithParametersFromData(ErrInconsistentT, "%w. x*y - t*z = %v{TResidual}, z^2 + dt^2 - y^2 - ax^2 = %v{Residual}", &residuals)
+	}
+	if !residuals.Residual.IsZero() {
+		return errorsWithData.NewErrorWithParametersFromData(ErrCurveEquationViolated, "%w. z^2 + dt^2 - y^2 - ax^2 = %v{Residual}", &residuals)
+	}
+	return nil
+}
+
 // sampleRandomUnsafe samples a (pseudo-)random curvepoint.
 // It is used in testing only and required by the CurvePointPtrInterfaceTestValue interface.
 //
@@ -1087,6 +1492,29 @@ func (p *Point_xtw_full) SetE2() {
 	p.point_xtw_base = infinitePoint2_xtw_base
 }
 
+// SignNormalize replaces p by the canonical representative of {p, p+A}, where A is the affine point of order two.
+// For affine points, the canonical representative is the one whose affine Y coordinate has positive Sign(); for points at infinity, it is E1.
+//
+// Note that, as opposed to normalizeSubgroup for Point_xtw_subgroup, this actually changes the point (if needed), not just its internal representation.
+// This is meant for comparing full-curve points up to the identification P ~ P+A, which is what Point_xtw_subgroup does internally:
+// P and Q are equal up to A iff they are equal after calling SignNormalize on both.
+func (p *Point_xtw_full) SignNormalize() {
+	if p.IsNaP() {
+		napEncountered("SignNormalize called on NaP", false, p)
+		return
+	}
+	if p.IsAtInfinity() {
+		p.SetE1() // Note that E1 + A == E2 and vice versa.
+		return
+	}
+	// If P has coordinates X:Y:T:Z, then P+A has coordinates -X:-Y:T:Z. Since Y != 0 for affine points, exactly one of them has Sign(Y/Z) > 0.
+	y := p.Y_affine()
+	if y.Sign() < 0 {
+		p.x.NegEq()
+		p.y.NegEq()
+	}
+}
+
 // SetAffineTwoTorsion sets the point to the affine-order two point.
 //
 // This function is required in order to satisfy the curvePointPtrInterfaceTestSampleA interface, which
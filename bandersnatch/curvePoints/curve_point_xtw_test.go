@@ -1,8 +1,17 @@
 package curvePoints
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"math/rand"
+	"sync"
 	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 )
 
 // test specific to Point_xtw go here. Note that most tests are contained in generic tests from curve_point_test_*_test.go files
@@ -81,3 +90,486 @@ func TestCompareAddAgainstNaive(t *testing.T) {
 	}
 	make_samples2_and_run_tests(t, checkfun_addnaive, "Addition inconsistent with naive definition", pointTypeXTWSubgroup, pointTypeXTWSubgroup, 20, 0)
 }
+
+func TestRawProjective(t *testing.T) {
+	drng := rand.New(rand.NewSource(203))
+	for i := 0; i < 10; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		P.normalizeSubgroup()
+		x, y, tt, z := P.RawProjective()
+		if !(x.IsEqual(&P.x) && y.IsEqual(&P.y) && tt.IsEqual(&P.t) && z.IsEqual(&P.z)) {
+			t.Fatalf("RawProjective does not return stored coordinates")
+		}
+		xNormalized, yNormalized, _, _ := P.XYTZ_projective()
+		if !(x.IsEqual(&xNormalized) && y.IsEqual(&yNormalized)) {
+			t.Fatalf("RawProjective differs from XYTZ_projective for normalized point")
+		}
+
+		// switch to the P+A representative.
+		P.flipDecaf()
+		stored := P.point_xtw_base
+		xFlipped, yFlipped, tFlipped, zFlipped := P.RawProjective()
+		if P.point_xtw_base != stored {
+			t.Fatalf("RawProjective modified the point")
+		}
+		var xNeg, yNeg FieldElement
+		xNeg.Neg(&x)
+		yNeg.Neg(&y)
+		if !(xFlipped.IsEqual(&xNeg) && yFlipped.IsEqual(&yNeg) && tFlipped.IsEqual(&tt) && zFlipped.IsEqual(&z)) {
+			t.Fatalf("RawProjective does not return the flipped coordinates for a point in flipped state")
+		}
+		// <foo>_projective normalizes again
+		xNormalized, yNormalized, _, _ = P.XYTZ_projective()
+		if !(x.IsEqual(&xNormalized) && y.IsEqual(&yNormalized)) {
+			t.Fatalf("XYTZ_projective does not undo flipped state")
+		}
+	}
+}
+
+// TestSnapshotConcurrentReads should be run with the race detector enabled to be meaningful.
+func TestSnapshotConcurrentReads(t *testing.T) {
+	drng := rand.New(rand.NewSource(204))
+	shared := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	shared.rerandomizeRepresentation(drng) // ensure that Z != 1 and that the point is likely in flipped state, so reads would need to normalize.
+	sharedFull := MakeRandomPointUnsafe_xtw_full(drng)
+	sharedFull.rerandomizeRepresentation(drng)
+
+	reference := shared.Snapshot()
+	expectedX, expectedY := reference.XY_affine()
+	referenceFull := sharedFull.Snapshot()
+	expectedXFull, expectedYFull := referenceFull.XY_affine()
+
+	const numGoroutines = 8
+	var wg sync.WaitGroup
+	errs := make(chan string, 2*numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				local := shared.Snapshot()
+				x, y := local.XY_affine()
+				if !(x.IsEqual(&expectedX) && y.IsEqual(&expectedY)) {
+					errs <- "Reading affine coordinates from snapshot gave inconsistent results"
+					return
+				}
+				localFull := sharedFull.Snapshot()
+				x, y = localFull.XY_affine()
+				if !(x.IsEqual(&expectedXFull) && y.IsEqual(&expectedYFull)) {
+					errs <- "Reading affine coordinates from snapshot of full point gave inconsistent results"
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestAffineFieldElements(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		P.rerandomizeRepresentation(drng)
+		x, y, err := P.AffineFieldElements()
+		if err != nil {
+			t.Fatalf("AffineFieldElements failed: %v", err)
+		}
+		xExpected, yExpected := P.XY_affine()
+		if !x.IsEqual(&xExpected) || !y.IsEqual(&yExpected) {
+			t.Fatalf("AffineFieldElements does not match XY_affine")
+		}
+		var Q Point_xtw_full
+		if err := Q.SetFromAffineFieldElements(&x, &y, untrustedInput); err != nil {
+			t.Fatalf("SetFromAffineFieldElements failed on valid input: %v", err)
+		}
+		if !Q.IsEqual(&P) {
+			t.Fatalf("SetFromAffineFieldElements did not recover original point")
+		}
+		// invalid input
+		y.AddEq(&fieldElementOne)
+		Q2 := Q
+		if err := Q.SetFromAffineFieldElements(&x, &y, untrustedInput); err == nil {
+			t.Fatalf("SetFromAffineFieldElements accepted point not on the curve")
+		}
+		if Q != Q2 {
+			t.Fatalf("SetFromAffineFieldElements modified the receiver on error")
+		}
+	}
+	for _, infinite := range []Point_xtw_full{InfinitePoint1_xtw, InfinitePoint2_xtw} {
+		_, _, err := infinite.AffineFieldElements()
+		if !errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity) {
+			t.Fatalf("AffineFieldElements did not report error for point at infinity. Got %v", err)
+		}
+	}
+	var NaP Point_xtw_full
+	_, _, err := NaP.AffineFieldElements()
+	if !errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP) {
+		t.Fatalf("AffineFieldElements did not report error for NaP. Got %v", err)
+	}
+}
+
+func TestAffineString(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(668))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		P.rerandomizeRepresentation(drng)
+		s, err := P.AffineString()
+		if err != nil {
+			t.Fatalf("AffineString failed: %v", err)
+		}
+		x, y := P.XY_affine()
+		expected := "(" + x.ToBigInt().String() + ", " + y.ToBigInt().String() + ")"
+		if s != expected {
+			t.Fatalf("AffineString does not match XY_affine. Got %v, expected %v", s, expected)
+		}
+	}
+	for _, infinite := range []Point_xtw_full{InfinitePoint1_xtw, InfinitePoint2_xtw} {
+		_, err := infinite.AffineString()
+		if !errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity) {
+			t.Fatalf("AffineString did not report error for point at infinity. Got %v", err)
+		}
+	}
+	var NaP Point_xtw_full
+	_, err := NaP.AffineString()
+	if !errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP) {
+		t.Fatalf("AffineString did not report error for NaP. Got %v", err)
+	}
+}
+
+func TestXAndSignY(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		P.rerandomizeRepresentation(drng)
+		Q := P
+		x, signY, err := P.XAndSignY()
+		if err != nil {
+			t.Fatalf("XAndSignY failed: %v", err)
+		}
+		xExpected, yExpected := Q.XY_affine()
+		if !x.IsEqual(&xExpected) || signY != yExpected.Sign() {
+			t.Fatalf("XAndSignY does not match XY_affine")
+		}
+		if signY != 1 && signY != -1 {
+			t.Fatalf("XAndSignY returned invalid sign %v", signY)
+		}
+	}
+	for _, infinite := range []Point_xtw_full{InfinitePoint1_xtw, InfinitePoint2_xtw} {
+		_, _, err := infinite.XAndSignY()
+		if !errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity) {
+			t.Fatalf("XAndSignY did not report error for point at infinity. Got %v", err)
+		}
+	}
+	var NaP Point_xtw_full
+	_, _, err := NaP.XAndSignY()
+	if !errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP) {
+		t.Fatalf("XAndSignY did not report error for NaP. Got %v", err)
+	}
+}
+
+func TestCheckOnCurve(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	points := []Point_xtw_full{NeutralElement_xtw_full, AffineOrderTwoPoint_xtw, InfinitePoint1_xtw, InfinitePoint2_xtw}
+	for i := 0; i < 20; i++ {
+		points = append(points, MakeRandomPointUnsafe_xtw_full(drng))
+	}
+	for i := range points {
+		if err := points[i].CheckOnCurve(); err != nil {
+			t.Fatalf("CheckOnCurve reported error for valid point %v: %v", points[i], err)
+		}
+	}
+
+	// Corrupt the y coordinate, adjusting t to keep x*y == t*z
+	corrupted := points[len(points)-1]
+	var one FieldElement
+	one.SetOne()
+	corrupted.y.AddEq(&one)
+	corrupted.t.Mul(&corrupted.x, &corrupted.y)
+	corrupted.t.DivideEq(&corrupted.z)
+	err := corrupted.CheckOnCurve()
+	if !errors.Is(err, ErrCurveEquationViolated) {
+		t.Fatalf("CheckOnCurve did not detect corrupted point. Got %v", err)
+	}
+	residuals := errorsWithData.GetDataFromError[CurveEquationResidual](err)
+	if residuals.Residual.IsZero() || !residuals.TResidual.IsZero() {
+		t.Fatalf("CheckOnCurve did not report expected residuals")
+	}
+	// For z == 1, the residual is 1 - ax^2 - y^2 + dt^2. We compare against the affine formula.
+	var zInv, x, y, tCoo, expected, temp FieldElement
+	zInv.Inv(&corrupted.z)
+	x.Mul(&corrupted.x, &zInv)
+	y.Mul(&corrupted.y, &zInv)
+	tCoo.Mul(&corrupted.t, &zInv)
+	expected.Square(&tCoo)
+	expected.MulEq(&CurveParameterD_fe)
+	expected.AddEq(&one)
+	temp.Square(&y)
+	expected.SubEq(&temp)
+	temp.Square(&x)
+	temp.Multiply_by_five()
+	expected.AddEq(&temp)
+	expected.MulEq(&corrupted.z)
+	expected.MulEq(&corrupted.z)
+	if !expected.IsEqual(&residuals.Residual) {
+		t.Fatalf("CheckOnCurve reported unexpected residual")
+	}
+
+	// Inconsistent t coordinate
+	corrupted = points[len(points)-1]
+	corrupted.t.AddEq(&one)
+	err = corrupted.CheckOnCurve()
+	if !errors.Is(err, ErrInconsistentT) {
+		t.Fatalf("CheckOnCurve did not detect inconsistent t coordinate. Got %v", err)
+	}
+	if !errorsWithData.HasParameter(err, "TResidual") {
+		t.Fatalf("CheckOnCurve error does not contain TResidual")
+	}
+
+	var NaP Point_xtw_full
+	if err = NaP.CheckOnCurve(); !errors.Is(err, ErrCheckOnCurveNaP) {
+		t.Fatalf("CheckOnCurve did not report NaP. Got %v", err)
+	}
+}
+
+// Ensure that IsEqual works for Point_xtw_subgroup in the flipped and unflipped decaf state and does not modify either operand.
+// The latter ensures that concurrent comparisons are fine.
+func TestIsEqualFlippedDecaf(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(668))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Q := P
+		Q.flipDecaf()
+		Q.point_xtw_base.rerandomizeRepresentation(drng) // does not flip
+		if legendreCheckE1_projectiveYZ(P.y, P.z) == legendreCheckE1_projectiveYZ(Q.y, Q.z) {
+			t.Fatalf("flipDecaf did not change the decaf state")
+		}
+		PCopy, QCopy := P, Q
+		if !P.IsEqual(&Q) || !Q.IsEqual(&P) {
+			t.Fatalf("IsEqual does not recognize flipped and unflipped representation of the same point as equal")
+		}
+		if P != PCopy || Q != QCopy {
+			t.Fatalf("IsEqual modified its operands")
+		}
+		var R Point_xtw_subgroup
+		G := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		R.Add(&P, &G)
+		R.flipDecaf()
+		if P.IsEqual(&R) || R.IsEqual(&P) {
+			t.Fatalf("IsEqual gives true for different points")
+		}
+	}
+
+	// Concurrent comparisons. This is meant to be run with -race.
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	Q := P
+	Q.flipDecaf()
+	var wg sync.WaitGroup
+	const numGoroutines = 8
+	results := make([]bool, numGoroutines)
+	wg.Add(numGoroutines)
+	for j := 0; j < numGoroutines; j++ {
+		go func(j int) {
+			defer wg.Done()
+			ret := true
+			for k := 0; k < 20; k++ {
+				ret = ret && P.IsEqual(&Q) && Q.IsEqual(&P)
+			}
+			results[j] = ret
+		}(j)
+	}
+	wg.Wait()
+	for j := 0; j < numGoroutines; j++ {
+		if !results[j] {
+			t.Fatalf("Concurrent IsEqual failed")
+		}
+	}
+}
+
+// Ensure that the encodings of Point_xtw_subgroup do not depend on the decaf state, i.e. the internal representation does not leak into the wire format.
+// Serializers in the pointserializer package are tested in the same way (with a simulated flipped state).
+func TestEncodingIndependentOfDecafState(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(669))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		Q := P
+		Q.flipDecaf()
+		Q.point_xtw_base.rerandomizeRepresentation(drng) // does not flip
+		if DecafEncode(&P) != DecafEncode(&Q) {
+			t.Fatalf("DecafEncode depends on the decaf state")
+		}
+		PFrozen, QFrozen := Freeze(&P), Freeze(&Q)
+		if !PFrozen.IsEqual(&QFrozen) || PFrozen.Encode() != QFrozen.Encode() {
+			t.Fatalf("Freeze depends on the decaf state")
+		}
+	}
+}
+
+func TestSignNormalize(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(670))
+	var A Point_xtw_full
+	A.SetAffineTwoTorsion()
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		switch i {
+		case 0:
+			P.SetNeutral()
+		case 1:
+			P.SetAffineTwoTorsion()
+		case 2:
+			P.SetE1()
+		case 3:
+			P.SetE2()
+		}
+		var PA Point_xtw_full
+		PA.Add(&P, &A)
+
+		PNormalized := P
+		PNormalized.SignNormalize()
+		PANormalized := PA
+		PANormalized.SignNormalize()
+		if !PNormalized.IsEqual(&PANormalized) {
+			t.Fatalf("SignNormalize of P and P+A differ")
+		}
+		if !PNormalized.IsEqual(&P) && !PNormalized.IsEqual(&PA) {
+			t.Fatalf("SignNormalize did not output P or P+A")
+		}
+		if !PNormalized.IsAtInfinity() {
+			y := PNormalized.Y_affine()
+			if y.Sign() <= 0 {
+				t.Fatalf("SignNormalize output has non-positive Y")
+			}
+		} else if !PNormalized.IsE1() {
+			t.Fatalf("SignNormalize of point at infinity is not E1")
+		}
+		// idempotent
+		PNormalizedAgain := PNormalized
+		PNormalizedAgain.SignNormalize()
+		if !PNormalizedAgain.IsEqual(&PNormalized) {
+			t.Fatalf("SignNormalize is not idempotent")
+		}
+	}
+}
+
+func TestNegEndo(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(671))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		var expected, result Point_xtw_subgroup
+		expected.Endo(&P)
+		expected.NegEq()
+		result.NegEndo(&P)
+		if !result.IsEqual(&expected) {
+			t.Fatalf("NegEndo differs from Endo followed by NegEq")
+		}
+		// aliasing
+		PCopy := P
+		PCopy.NegEndo(&PCopy)
+		if !PCopy.IsEqual(&expected) {
+			t.Fatalf("NegEndo fails when argument and receiver alias")
+		}
+		// input of different type
+		var PAffine Point_axtw_subgroup
+		PAffine.SetFrom(&P)
+		result.NegEndo(&PAffine)
+		if !result.IsEqual(&expected) {
+			t.Fatalf("NegEndo fails for input of type Point_axtw_subgroup")
+		}
+	}
+}
+
+func TestXTWDeserializeShortLongAuto(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(669))
+	for i := 0; i < 20; i++ {
+		var P Point_xtw_subgroup
+		P.sampleRandomUnsafe(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		var buf bytes.Buffer
+		_, errSerialize := P.SerializeShort(&buf)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		_, errSerialize = P.SerializeLong(&buf)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		_, errSerialize = P.SerializeShort(&buf)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		data := buf.Bytes()
+		short, long := data[0:32], data[32:96]
+		isLong, ok := DetectBanderwagonFormat((*[32]byte)(short))
+		testutils.FatalUnless(t, ok && !isLong, "DetectBanderwagonFormat did not detect short format")
+		isLong, ok = DetectBanderwagonFormat((*[32]byte)(long[0:32]))
+		testutils.FatalUnless(t, ok && isLong, "DetectBanderwagonFormat did not detect long format")
+
+		var Q Point_xtw_subgroup
+		var QFull Point_xtw_full
+		bytesRead, err := Q.DeserializeShort(bytes.NewReader(short), untrustedInput)
+		testutils.FatalUnless(t, err == nil && bytesRead == 32, "DeserializeShort failed: %v", err)
+		testutils.FatalUnless(t, Q.IsEqual(&P), "DeserializeShort did not round-trip")
+		bytesRead, err = QFull.DeserializeLong(bytes.NewReader(long), untrustedInput)
+		testutils.FatalUnless(t, err == nil && bytesRead == 64, "DeserializeLong failed: %v", err)
+		testutils.FatalUnless(t, QFull.IsEqual(&P), "DeserializeLong did not round-trip")
+
+		// DeserializeAuto only consumes what it needs.
+		reader := bytes.NewReader(data)
+		for _, expectedLen := range []int{32, 64, 32} {
+			Q.SetNeutral()
+			bytesRead, err = Q.DeserializeAuto(reader, trustedInput)
+			testutils.FatalUnless(t, err == nil, "DeserializeAuto failed: %v", err)
+			testutils.FatalUnless(t, bytesRead == expectedLen, "DeserializeAuto read %v bytes, expected %v", bytesRead, expectedLen)
+			testutils.FatalUnless(t, Q.IsEqual(&P), "DeserializeAuto did not round-trip")
+		}
+		testutils.FatalUnless(t, reader.Len() == 0, "")
+
+		// wrong format
+		_, err = Q.DeserializeShort(bytes.NewReader(long), untrustedInput)
+		testutils.FatalUnless(t, err != nil, "DeserializeShort accepted long format")
+		_, err = Q.DeserializeLong(bytes.NewReader(short), untrustedInput)
+		testutils.FatalUnless(t, err != nil, "DeserializeLong accepted short format")
+
+		// partial read
+		bytesRead, err = QFull.DeserializeAuto(bytes.NewReader(long[0:40]), untrustedInput)
+		testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Got %v on truncated input", err)
+		testutils.FatalUnless(t, bytesRead == 40 && err.GetData().BytesRead == 40 && err.GetData().PartialRead, "Wrong error data on truncated input")
+	}
+
+	// malformed header: the top bits 01 match neither format. The receiver must be untouched.
+	malformed := make([]byte, 64)
+	msbPos := 31 // position of the byte containing the header within the first field element
+	if common.DefaultEndian.StartsWithMSB() {
+		msbPos = 0
+	}
+	malformed[msbPos] = 0b0100_0000
+	Q := SubgroupGenerator_xtw_subgroup
+	bytesRead, err := Q.DeserializeAuto(bytes.NewReader(malformed), untrustedInput)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrDidNotReadExpectedString), "Got %v on malformed header", err)
+	testutils.FatalUnless(t, bytesRead == 32 && err.GetData().BytesRead == 32, "")
+	testutils.FatalUnless(t, Q.IsEqual(&SubgroupGenerator_xtw_subgroup), "Receiver was modified on error")
+
+	// empty input
+	bytesRead, err = Q.DeserializeAuto(bytes.NewReader(nil), untrustedInput)
+	testutils.FatalUnless(t, errors.Is(err, io.EOF) && bytesRead == 0, "Got %v on empty input", err)
+
+	// X-coordinates that do not correspond to a subgroup point must be rejected for untrusted input.
+	rejected := 0
+	for i := 0; i < 20; i++ {
+		var encoding [32]byte
+		drng.Read(encoding[:])
+		encoding[msbPos] = (encoding[msbPos] & 0x0F) | 0x80
+		Q = SubgroupGenerator_xtw_subgroup
+		_, err = Q.DeserializeShort(bytes.NewReader(encoding[:]), untrustedInput)
+		if err != nil {
+			rejected++
+			testutils.FatalUnless(t, Q.IsEqual(&SubgroupGenerator_xtw_subgroup), "Receiver was modified on error")
+		}
+	}
+	testutils.FatalUnless(t, rejected > 0, "DeserializeShort accepted random encodings")
+}
@@ -0,0 +1,56 @@
+package curvePoints
+
+// This file contains FrozenPoint, an immutable representation of a point in the prime-order subgroup that is safe for sharing.
+
+// FrozenPoint is an immutable point in the prime-order subgroup.
+//
+// Reading coordinates of a Point_xtw_subgroup may modify its internal representation (e.g. normalizing Z or the choice of P vs. P+A),
+// so a *Point_xtw_subgroup must not be shared across goroutines, even if they only read.
+// A FrozenPoint holds a fully normalized copy (affine, with canonical choice modulo A, fully reduced field elements) together with its encoding,
+// so none of its methods modify it and it is safe to use concurrently from several goroutines. This is meant e.g. for shared tables of generators.
+//
+// The zero value is not a valid FrozenPoint; use Freeze to create one.
+type FrozenPoint struct {
+	x        FieldElement
+	y        FieldElement
+	encoding [hexShortFormByteLength]byte
+}
+
+// Freeze creates a FrozenPoint from p. p itself may have its internal representation changed, but still represents the same point.
+//
+// Freeze panics if p is a NaP.
+func Freeze(p *Point_xtw_subgroup) (ret FrozenPoint) {
+	if p.IsNaP() {
+		panic(ErrorPrefix + "Freeze called on NaP")
+	}
+	ret.x, ret.y = p.XY_affine()
+	ret.x.Normalize()
+	ret.y.Normalize()
+	ret.encoding = DecafEncode(p)
+	return
+}
+
+// XY returns the affine twisted Edwards coordinates of the point. These are the same as p.XY_affine() for the point p that was frozen.
+func (f *FrozenPoint) XY() (x FieldElement, y FieldElement) {
+	return f.x, f.y
+}
+
+// Encode returns the canonical 32-byte encoding of the point, as output by DecafEncode.
+func (f *FrozenPoint) Encode() [hexShortFormByteLength]byte {
+	return f.encoding
+}
+
+// IsEqual compares two FrozenPoints for equality.
+func (f *FrozenPoint) IsEqual(other *FrozenPoint) bool {
+	// The representation is unique, so we can compare coordinates directly.
+	return f.x == other.x && f.y == other.y
+}
+
+// Point returns a (mutable) copy of the point as a Point_xtw_subgroup.
+func (f *FrozenPoint) Point() (ret Point_xtw_subgroup) {
+	ret.x = f.x
+	ret.y = f.y
+	ret.t.Mul(&f.x, &f.y)
+	ret.z.SetOne()
+	return
+}
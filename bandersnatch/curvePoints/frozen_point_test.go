@@ -0,0 +1,61 @@
+package curvePoints
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestFrozenPoint(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Q := P
+		Q.rerandomizeRepresentation(drng)
+		frozenP := Freeze(&P)
+		frozenQ := Freeze(&Q)
+		testutils.FatalUnless(t, frozenP.IsEqual(&frozenQ), "FrozenPoint depends on internal representation")
+		testutils.FatalUnless(t, frozenP.Encode() == DecafEncode(&P), "FrozenPoint.Encode differs from DecafEncode")
+		x, y := frozenP.XY()
+		xP, yP := P.XY_affine()
+		testutils.FatalUnless(t, x.IsEqual(&xP) && y.IsEqual(&yP), "FrozenPoint.XY differs from XY_affine")
+		thawed := frozenP.Point()
+		testutils.FatalUnless(t, thawed.Validate(), "FrozenPoint.Point gives invalid point")
+		testutils.FatalUnless(t, thawed.IsEqual(&P), "FrozenPoint.Point does not round-trip")
+
+		R := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		frozenR := Freeze(&R)
+		testutils.FatalUnless(t, !frozenP.IsEqual(&frozenR), "FrozenPoint.IsEqual gives wrong result for different points")
+	}
+	var NaP Point_xtw_subgroup
+	testutils.FatalUnless(t, testutils.CheckPanic(Freeze, &NaP), "Freeze did not panic on NaP")
+}
+
+// This test is only meaningful when run with the race detector.
+func TestFrozenPointConcurrentReads(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	const num = 8
+	var table [num]FrozenPoint
+	for i := range table {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		P.rerandomizeRepresentation(drng)
+		table[i] = Freeze(&P)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range table {
+				_, _ = table[i].XY()
+				_ = table[i].Encode()
+				_ = table[i].IsEqual(&table[(i+1)%num])
+				P := table[i].Point()
+				P.DoubleEq()
+			}
+		}()
+	}
+	wg.Wait()
+}
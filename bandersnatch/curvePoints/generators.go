@@ -0,0 +1,106 @@
+package curvePoints
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// This file contains routines to deterministically derive "nothing-up-my-sleeve" generators of the prime-order subgroup, e.g. for Pedersen commitments.
+
+// deriveGeneratorsDST is the domain-separation tag used by DeriveGenerators for hashing to the curve.
+const deriveGeneratorsDST = "BANDERSNATCH-V01-CS01-DeriveGenerators-with-bandersnatch_XMD:SHA-256_ELL2_RO_"
+
+// DeriveGenerators deterministically derives n pairwise distinct generators of the prime-order subgroup from the given seed.
+//
+// The generators are obtained by hashing seed || counter (with counter a big-endian uint64) to the curve via HashToSubgroup, starting with counter == 0.
+// In the (astronomically unlikely) case that this gives the neutral element or a point that was already output, we skip the counter value and continue with the next one.
+// This means that, except with negligible probability, the i'th generator is the hash of seed || i, and
+// DeriveGenerators(seed, m) is a prefix of DeriveGenerators(seed, n) for m <= n.
+//
+// Nobody knows any discrete logarithm relations between the outputs (under the usual random-oracle heuristic), so these are suitable for a transparent setup.
+// n must be non-negative; we panic otherwise.
+func DeriveGenerators(seed []byte, n int) []Point_xtw_subgroup {
+	if n < 0 {
+		panic(fmt.Errorf(ErrorPrefix+"DeriveGenerators called with negative number of generators n == %v", n))
+	}
+	ret := make([]Point_xtw_subgroup, 0, n)
+	// We use the short Banderwagon form to check for duplicates, which is unique for subgroup points.
+	seen := make(map[string]struct{}, n)
+	msg := make([]byte, len(seed)+8)
+	copy(msg, seed)
+	for counter := uint64(0); len(ret) < n; counter++ {
+		binary.BigEndian.PutUint64(msg[len(seed):], counter)
+		candidate, err := HashToSubgroup(msg, []byte(deriveGeneratorsDST))
+		if err != nil {
+			panic(fmt.Errorf(ErrorPrefix+"DeriveGenerators could not hash to the curve. This is not supposed to be possible: %w", err))
+		}
+		if !candidate.IsGenerator() {
+			continue
+		}
+		encoding, err := candidate.ToHexString()
+		if err != nil {
+			panic(fmt.Errorf(ErrorPrefix+"DeriveGenerators obtained a point that cannot be encoded. This is not supposed to be possible: %w", err))
+		}
+		if _, ok := seen[encoding]; ok {
+			continue
+		}
+		seen[encoding] = struct{}{}
+		ret = append(ret, candidate)
+	}
+	return ret
+}
+
+// IsGenerator checks whether p generates the prime-order subgroup.
+// Since the subgroup has prime order, this is the case iff p is not the neutral element.
+//
+// For NaPs, this returns false after calling the NaP-handler (as IsNeutralElement does).
+func (p *Point_xtw_subgroup) IsGenerator() bool {
+	if p.IsNaP() {
+		return napEncountered("IsGenerator called on NaP", true, p)
+	}
+	return !p.IsNeutralElement()
+}
+
+// Errors returned (wrapped) by ValidateGenerators.
+var (
+	ErrNeutralGenerator   = errors.New(ErrorPrefix + "generator set contains the neutral element")
+	ErrDuplicateGenerator = errors.New(ErrorPrefix + "generator set contains the same point more than once")
+)
+
+// ValidateGenerators checks that the given points are usable as independent generators, e.g. for a Pedersen commitment setup:
+// none of them may be the neutral element (or a NaP) and, if checkDuplicates is set, they must be pairwise distinct.
+// Otherwise, commitments using these generators are insecure.
+//
+// It returns nil if all checks pass and an error wrapping ErrNeutralGenerator or ErrDuplicateGenerator (which includes the offending indices) otherwise.
+// Note that this cannot detect (and it is computationally infeasible to detect) non-trivial discrete logarithm relations between the generators; use DeriveGenerators for a transparent setup.
+func ValidateGenerators(generators []Point_xtw_subgroup, checkDuplicates bool) error {
+	var seen map[[hexShortFormByteLength]byte]int
+	if checkDuplicates {
+		seen = make(map[[hexShortFormByteLength]byte]int, len(generators))
+	}
+	for i := range generators {
+		if !generators[i].IsGenerator() {
+			return fmt.Errorf("%w: the generator at index %v is the neutral element or a NaP", ErrNeutralGenerator, i)
+		}
+		if !checkDuplicates {
+			continue
+		}
+		// DecafEncode is unique for subgroup points, so we can compare encodings.
+		encoding := DecafEncode(&generators[i])
+		if j, ok := seen[encoding]; ok {
+			return fmt.Errorf("%w: the generators at indices %v and %v are equal", ErrDuplicateGenerator, j, i)
+		}
+		seen[encoding] = i
+	}
+	return nil
+}
+
+// AreIndependent checks that the given points are pairwise distinct and none of them is the neutral element (or a NaP).
+//
+// This is a practical proxy for independence of generators: Since the prime-order subgroup is cyclic, all points are multiples of each other,
+// so true independence is not meaningful; what matters for a transparent setup is that the discrete logarithm relations are unknown, which cannot be checked.
+// The detectable failure modes are repeated points and the identity. This is equivalent to ValidateGenerators(points, true) == nil; use that for a descriptive error.
+func AreIndependent(points []Point_xtw_subgroup) bool {
+	return ValidateGenerators(points, true) == nil
+}
@@ -0,0 +1,109 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeriveGenerators(t *testing.T) {
+	const n = 256
+	seed := []byte("test seed")
+	generators := DeriveGenerators(seed, n)
+	testutils.FatalUnless(t, len(generators) == n, "DeriveGenerators returned %v generators, expected %v", len(generators), n)
+
+	seen := make(map[string]int)
+	for i := range generators {
+		testutils.FatalUnless(t, generators[i].Validate(), "DeriveGenerators returned invalid point")
+		var full Point_xtw_full
+		full.SetFrom(&generators[i])
+		testutils.FatalUnless(t, full.IsInSubgroup(), "DeriveGenerators returned point outside the subgroup")
+		testutils.FatalUnless(t, !generators[i].IsNeutralElement(), "DeriveGenerators returned the neutral element")
+		encoding, err := generators[i].ToHexString()
+		testutils.FatalUnless(t, err == nil, "%v", err)
+		if j, ok := seen[encoding]; ok {
+			t.Fatalf("DeriveGenerators returned identical points at indices %v and %v", i, j)
+		}
+		seen[encoding] = i
+	}
+
+	// determinism and prefix property
+	generators2 := DeriveGenerators(seed, n)
+	generatorsPrefix := DeriveGenerators(seed, 10)
+	for i := range generators {
+		testutils.FatalUnless(t, generators[i].IsEqual(&generators2[i]), "DeriveGenerators is not deterministic")
+		if i < len(generatorsPrefix) {
+			testutils.FatalUnless(t, generators[i].IsEqual(&generatorsPrefix[i]), "DeriveGenerators does not have the prefix property")
+		}
+	}
+
+	// seed matters
+	otherGenerators := DeriveGenerators([]byte("other seed"), 1)
+	testutils.FatalUnless(t, !otherGenerators[0].IsEqual(&generators[0]), "DeriveGenerators does not depend on seed")
+
+	testutils.FatalUnless(t, len(DeriveGenerators(seed, 0)) == 0, "DeriveGenerators(seed, 0) is not empty")
+	testutils.FatalUnless(t, len(DeriveGenerators(nil, 1)) == 1, "DeriveGenerators does not work with nil seed")
+	testutils.FatalUnless(t, testutils.CheckPanic(DeriveGenerators, seed, -1), "DeriveGenerators did not panic for negative n")
+}
+
+func TestIsGenerator(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i%2 == 0 {
+			P.flipDecaf()
+		}
+		testutils.FatalUnless(t, P.IsGenerator(), "IsGenerator returned false for random subgroup point")
+	}
+	testutils.FatalUnless(t, SubgroupGenerator_xtw_subgroup.IsGenerator(), "IsGenerator returned false for SubgroupGenerator")
+	var N Point_xtw_subgroup
+	N.SetNeutral()
+	testutils.FatalUnless(t, !N.IsGenerator(), "IsGenerator returned true for neutral element")
+	N.flipDecaf() // the affine 2-torsion point A represents the neutral element as well
+	testutils.FatalUnless(t, !N.IsGenerator(), "IsGenerator returned true for neutral element (represented as A)")
+
+	var NaP Point_xtw_subgroup
+	var result bool = true
+	testutils.FatalUnless(t, wasInvalidPointEncountered(func() { result = NaP.IsGenerator() }), "IsGenerator did not call NaP handler")
+	testutils.FatalUnless(t, !result, "IsGenerator returned true for NaP")
+}
+
+func TestValidateGenerators(t *testing.T) {
+	generators := DeriveGenerators([]byte("test seed"), 10)
+	testutils.FatalUnless(t, ValidateGenerators(generators, true) == nil, "ValidateGenerators rejected valid generators")
+	testutils.FatalUnless(t, ValidateGenerators(nil, true) == nil, "ValidateGenerators rejected empty generator set")
+
+	withNeutral := append([]Point_xtw_subgroup(nil), generators...)
+	withNeutral[5].SetNeutral()
+	for _, checkDuplicates := range []bool{false, true} {
+		err := ValidateGenerators(withNeutral, checkDuplicates)
+		testutils.FatalUnless(t, errors.Is(err, ErrNeutralGenerator), "ValidateGenerators did not detect neutral element: %v", err)
+	}
+
+	withDuplicate := append([]Point_xtw_subgroup(nil), generators...)
+	withDuplicate[7] = withDuplicate[2]
+	withDuplicate[7].rerandomizeRepresentation(rand.New(rand.NewSource(1))) // duplicates must be detected irrespective of the internal representation
+	err := ValidateGenerators(withDuplicate, true)
+	testutils.FatalUnless(t, errors.Is(err, ErrDuplicateGenerator), "ValidateGenerators did not detect duplicate: %v", err)
+	testutils.FatalUnless(t, ValidateGenerators(withDuplicate, false) == nil, "ValidateGenerators checked for duplicates even though not requested")
+}
+
+func TestAreIndependent(t *testing.T) {
+	points := DeriveGenerators([]byte("test seed"), 10)
+	testutils.FatalUnless(t, AreIndependent(points), "AreIndependent rejected distinct non-identity points")
+	testutils.FatalUnless(t, AreIndependent(nil), "AreIndependent rejected empty set")
+
+	withNeutral := append([]Point_xtw_subgroup(nil), points...)
+	withNeutral[3].SetNeutral()
+	testutils.FatalUnless(t, !AreIndependent(withNeutral), "AreIndependent accepted set containing the identity")
+
+	withRepeat := append([]Point_xtw_subgroup(nil), points...)
+	withRepeat[9].Neg(&withRepeat[4])
+	withRepeat[9].NegEq()
+	testutils.FatalUnless(t, !AreIndependent(withRepeat), "AreIndependent accepted set with repeated point")
+
+	var nap Point_xtw_subgroup
+	testutils.FatalUnless(t, !AreIndependent([]Point_xtw_subgroup{points[0], nap}), "AreIndependent accepted set containing NaP")
+}
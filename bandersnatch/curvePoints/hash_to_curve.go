@@ -0,0 +1,283 @@
+package curvePoints
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// This file contains routines for hashing arbitrary byte strings to curve points.
+//
+// We follow RFC 9380 (Hashing to Elliptic Curves) using the following choices:
+//   - expand_message_xmd with SHA-256 as the expander (Section 5.3.1)
+//   - hash_to_field with k = 128, i.e. L = 48 bytes per field element (Section 5.2)
+//   - Elligator 2 for the Montgomery form of Bandersnatch with Z = 5 (Section 6.7.1), followed by the rational map to twisted Edwards coordinates (Section 6.8.2)
+//   - cofactor clearing by multiplication with the cofactor 4.
+//
+// The resulting suite is what RFC 9380 would call bandersnatch_XMD:SHA-256_ELL2_RO_.
+//
+// NOTE: These routines are not constant-time. Do not use them on secret inputs.
+
+// DefaultHashToCurveDST is the default domain-separation tag for HashToSubgroup.
+//
+// RFC 9380 recommends that each application uses its own tag that includes the application name and version;
+// this default is only provided as a sensible fallback.
+const DefaultHashToCurveDST = "BANDERSNATCH-V01-CS01-with-bandersnatch_XMD:SHA-256_ELL2_RO_"
+
+// MaxHashToCurveDSTLength is the maximal length of domain-separation tags that are used directly in expand_message_xmd.
+// Longer tags are hashed down as described in Section 5.3.3 of RFC 9380.
+const MaxHashToCurveDSTLength = 255
+
+var (
+	ErrHashToCurveDSTEmpty   = errors.New(ErrorPrefix + "the domain separation tag for hashing to the curve must be non-empty")
+	ErrHashToCurveDSTTooLong = fmt.Errorf(ErrorPrefix+"the domain separation tag for hashing to the curve exceeds %v bytes and will be hashed down", MaxHashToCurveDSTLength)
+)
+
+// oversizeDSTPrefix is the prefix used when hashing down overly long domain-separation tags, as specified in RFC 9380.
+const oversizeDSTPrefix = "H2C-OVERSIZE-DST-"
+
+// hashToFieldLength is the number of bytes that expand_message_xmd outputs per field element.
+// This is ceil((ceil(log2(BaseFieldSize)) + k) / 8) with k = 128 the targeted security level.
+const hashToFieldLength = (BaseFieldBitLength + 128 + 7) / 8 // == 48
+
+// ValidateHashToCurveDST checks whether the given domain-separation tag is suitable to be used with HashToSubgroup.
+//
+// It returns an error wrapping ErrHashToCurveDSTEmpty for empty tags and an error wrapping ErrHashToCurveDSTTooLong for tags longer than MaxHashToCurveDSTLength.
+// Note that HashToSubgroup still works with tags that are too long (it hashes them down as specified by RFC 9380); this function
+// is meant as a warning for callers who wish to catch this.
+func ValidateHashToCurveDST(dst []byte) error {
+	if len(dst) == 0 {
+		return ErrHashToCurveDSTEmpty
+	}
+	if len(dst) > MaxHashToCurveDSTLength {
+		return fmt.Errorf("%w. The tag had length %v", ErrHashToCurveDSTTooLong, len(dst))
+	}
+	return nil
+}
+
+// normalizeHashToCurveDST returns the domain-separation tag that is actually used in expand_message_xmd.
+// This hashes down tags that are too long. It panics on empty tags.
+func normalizeHashToCurveDST(dst []byte) []byte {
+	if len(dst) == 0 {
+		panic(ErrHashToCurveDSTEmpty)
+	}
+	if len(dst) <= MaxHashToCurveDSTLength {
+		return dst
+	}
+	hasher := sha256.New()
+	hasher.Write([]byte(oversizeDSTPrefix))
+	hasher.Write(dst)
+	return hasher.Sum(nil)
+}
+
+// expandMessageXMD implements expand_message_xmd from RFC 9380 with SHA-256, outputting lenInBytes uniformly random bytes.
+// dst must already be normalized (i.e. non-empty and of length at most 255). lenInBytes must be at most 255*32.
+func expandMessageXMD(msg []byte, dst []byte, lenInBytes int) []byte {
+	const bInBytes = sha256.Size
+	const sInBytes = sha256.BlockSize
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 0xFFFF || len(dst) > MaxHashToCurveDSTLength {
+		panic(ErrorPrefix + "invalid parameters for expandMessageXMD")
+	}
+	dstPrime := make([]byte, 0, len(dst)+1)
+	dstPrime = append(dstPrime, dst...)
+	dstPrime = append(dstPrime, byte(len(dst)))
+
+	hasher := sha256.New()
+	hasher.Write(make([]byte, sInBytes)) // Z_pad
+	hasher.Write(msg)
+	hasher.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	hasher.Write(dstPrime)
+	b0 := hasher.Sum(nil)
+
+	hasher.Reset()
+	hasher.Write(b0)
+	hasher.Write([]byte{1})
+	hasher.Write(dstPrime)
+	bi := hasher.Sum(nil)
+
+	uniformBytes := make([]byte, 0, ell*bInBytes)
+	uniformBytes = append(uniformBytes, bi...)
+	for i := 2; i <= ell; i++ {
+		var xored [bInBytes]byte
+		for j := 0; j < bInBytes; j++ {
+			xored[j] = b0[j] ^ bi[j]
+		}
+		hasher.Reset()
+		hasher.Write(xored[:])
+		hasher.Write([]byte{byte(i)})
+		hasher.Write(dstPrime)
+		bi = hasher.Sum(nil)
+		uniformBytes = append(uniformBytes, bi...)
+	}
+	return uniformBytes[0:lenInBytes]
+}
+
+// hashToField implements hash_to_field from RFC 9380, outputting count many field elements.
+func hashToField(msg []byte, dst []byte, count int) (ret []FieldElement) {
+	uniformBytes := expandMessageXMD(msg, normalizeHashToCurveDST(dst), count*hashToFieldLength)
+	ret = make([]FieldElement, count)
+	var temp big.Int
+	for i := 0; i < count; i++ {
+		temp.SetBytes(uniformBytes[i*hashToFieldLength : (i+1)*hashToFieldLength])
+		temp.Mod(&temp, BaseFieldSize_Int)
+		ret[i].SetBigInt(&temp)
+	}
+	return
+}
+
+// Parameters for the Montgomery form K*t^2 = s^3 + J*s^2 + s of the Bandersnatch curve.
+// These are J = 2(a+d)/(a-d) and K = 4/(a-d) for the twisted Edwards parameters a,d.
+//
+// These are also used in weierstrass.go. They are set via initializers rather than in init, so they are available in every init function of the package.
+var (
+	montgomeryJ_fe FieldElement = computeMontgomeryJ()
+	montgomeryK_fe FieldElement = computeMontgomeryK()
+)
+
+// computeMontgomeryJ computes the parameter J = 2(a+d)/(a-d) of the Montgomery form. It is used to initialize montgomeryJ_fe.
+func computeMontgomeryJ() (J FieldElement) {
+	var aMinusD FieldElement
+	aMinusD.Sub(&CurveParameterA_fe, &CurveParameterD_fe)
+	J.Add(&CurveParameterA_fe, &CurveParameterD_fe)
+	J.DoubleEq()
+	J.DivideEq(&aMinusD)
+	return
+}
+
+// computeMontgomeryK computes the parameter K = 4/(a-d) of the Montgomery form. It is used to initialize montgomeryK_fe.
+func computeMontgomeryK() (K FieldElement) {
+	var aMinusD FieldElement
+	aMinusD.Sub(&CurveParameterA_fe, &CurveParameterD_fe)
+	K.SetUInt64(4)
+	K.DivideEq(&aMinusD)
+	return
+}
+
+// Derived constants for the Elligator 2 map.
+var (
+	elligatorC1_fe FieldElement // J/K
+	elligatorC2_fe FieldElement // 1/K^2
+	elligatorZ_fe  FieldElement // non-square used in the Elligator 2 map
+)
+
+// elligatorZ is the non-square constant Z used in the Elligator 2 map. This is the choice made by the find_z_ell2 algorithm of RFC 9380.
+const elligatorZ = 5
+
+func init() {
+	elligatorC1_fe.Divide(&montgomeryJ_fe, &montgomeryK_fe)
+	elligatorC2_fe.Square(&montgomeryK_fe)
+	elligatorC2_fe.InvEq()
+	elligatorZ_fe.SetUInt64(elligatorZ)
+	// RFC 9380 requires Z to be a non-square. This is a refactoring guard.
+	if elligatorZ_fe.Jacobi() != -1 {
+		panic(ErrorPrefix + "Elligator constant Z is a square")
+	}
+}
+
+// sgn0 is the sign function from RFC 9380, i.e. the parity of the minimal non-negative representative.
+// Note that this differs from FieldElement's Sign() method.
+func sgn0(x *FieldElement) int {
+	return int(x.ToBigInt().Bit(0))
+}
+
+// mapToCurveElligator2 maps a field element to a point on the Bandersnatch curve (not necessarily in the subgroup)
+// using the Elligator 2 map to the Montgomery form, followed by the rational map to twisted Edwards coordinates.
+//
+// The exceptional cases of the rational map (which only happen for the images of 2-torsion points of the Montgomery curve) are mapped to the neutral element, as specified in RFC 9380.
+func mapToCurveElligator2(u *FieldElement) (ret Point_xtw_full) {
+	// Notation follows Section 6.7.1 of RFC 9380 (with the Montgomery coordinates named s,t)
+	var tv1, x1, gx1, x2, gx2, y FieldElement
+	tv1.Square(u)
+	tv1.MulEq(&elligatorZ_fe) // Z*u^2
+	// Note: Z*u^2 == -1 is impossible, because Z is a non-square and -1 is a square.
+	x1.Add(&tv1, &fieldElementOne)
+	x1.InvEq()
+	x1.MulEq(&elligatorC1_fe)
+	x1.NegEq() // x1 = -(J/K) / (1 + Z * u^2)
+
+	gx1.Add(&x1, &elligatorC1_fe)
+	gx1.MulEq(&x1)
+	gx1.AddEq(&elligatorC2_fe)
+	gx1.MulEq(&x1) // gx1 = x1^3 + (J / K) * x1^2 + x1 / K^2
+
+	x2.Add(&x1, &elligatorC1_fe)
+	x2.NegEq() // x2 = -x1 - J/K
+	gx2.Mul(&tv1, &gx1)
+
+	var s FieldElement
+	var e2 bool = gx1.Jacobi() >= 0
+	if e2 {
+		s = x1
+		if !y.SquareRoot(&gx1) {
+			panic(ErrorPrefix + "Elligator 2: could not take square root of a square")
+		}
+	} else {
+		s = x2
+		if !y.SquareRoot(&gx2) {
+			panic(ErrorPrefix + "Elligator 2: could not take square root of a square")
+		}
+	}
+	// ensure sgn0(y) == 1 iff e2 == true
+	if (sgn0(&y) == 1) != e2 {
+		y.NegEq()
+	}
+	s.MulEq(&montgomeryK_fe)
+	var t FieldElement
+	t.Mul(&y, &montgomeryK_fe)
+
+	// rational map (s,t) -> (x,y) = (s/t, (s-1)/(s+1)) to twisted Edwards form in projective coordinates,
+	// i.e. X = s(s+1), Y = t(s-1), Z = t(s+1), T = s(s-1)
+	var sPlusOne, sMinusOne FieldElement
+	sPlusOne.Add(&s, &fieldElementOne)
+	sMinusOne.Sub(&s, &fieldElementOne)
+	if t.IsZero() || sPlusOne.IsZero() {
+		ret.SetNeutral()
+		return
+	}
+	ret.x.Mul(&s, &sPlusOne)
+	ret.y.Mul(&t, &sMinusOne)
+	ret.z.Mul(&t, &sPlusOne)
+	ret.t.Mul(&s, &sMinusOne)
+	return
+}
+
+// HashToSubgroup hashes the given message msg to a point in the prime-order subgroup, using the domain-separation tag dst.
+// We follow RFC 9380 (hash_to_curve, i.e. the random-oracle variant) with the suite described at the top of this file.
+//
+// If dst is nil, DefaultHashToCurveDST is used. Tags longer than MaxHashToCurveDSTLength are hashed down as specified in RFC 9380.
+// Use ValidateHashToCurveDST to check the tag beforehand if you want to catch overly long tags.
+//
+// The only possible error is ErrHashToCurveDSTEmpty, which is returned for a non-nil empty dst. In this case, ret is a NaP.
+//
+// NOTE: This is not constant-time.
+func HashToSubgroup(msg []byte, dst []byte) (ret Point_xtw_subgroup, err error) {
+	if dst == nil {
+		dst = []byte(DefaultHashToCurveDST)
+	}
+	if len(dst) == 0 {
+		err = ErrHashToCurveDSTEmpty
+		return
+	}
+	u := hashToField(msg, dst, 2)
+	Q0 := mapToCurveElligator2(&u[0])
+	Q1 := mapToCurveElligator2(&u[1])
+	var R Point_xtw_full
+	R.Add(&Q0, &Q1)
+	ret.ClearCofactor(&R)
+	return
+}
+
+// HashToCurve hashes data to a point in the prime-order subgroup, using domainSeparator as domain-separation tag.
+// This is the same as HashToSubgroup and is named after the hash_to_curve function of RFC 9380.
+// A nil domainSeparator means DefaultHashToCurveDST. As opposed to HashToSubgroup, we panic on a non-nil empty domainSeparator.
+//
+// The output is deterministic and never a NaP or a point at infinity. It is the neutral element only with negligible probability.
+func HashToCurve(data []byte, domainSeparator []byte) Point_xtw_subgroup {
+	ret, err := HashToSubgroup(data, domainSeparator)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
@@ -0,0 +1,168 @@
+package curvePoints
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// Test vectors for expand_message_xmd with SHA-256 from Appendix K.1 of RFC 9380.
+func TestExpandMessageXMD(t *testing.T) {
+	const dst = "QUUX-V01-CS02-with-expander-SHA256-128"
+	var testVectors = []struct {
+		msg      string
+		expected string
+	}{
+		{"", "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+	}
+	for _, testVector := range testVectors {
+		got := expandMessageXMD([]byte(testVector.msg), []byte(dst), 0x20)
+		expected, _ := hex.DecodeString(testVector.expected)
+		if !bytes.Equal(got, expected) {
+			t.Fatalf("expandMessageXMD does not match test vector for msg = %q: got %x, expected %x", testVector.msg, got, expected)
+		}
+	}
+	// output length not a multiple of the hash length
+	if L := len(expandMessageXMD([]byte("abc"), []byte(dst), 2*hashToFieldLength)); L != 2*hashToFieldLength {
+		t.Fatalf("expandMessageXMD outputs wrong length: got %v, expected %v", L, 2*hashToFieldLength)
+	}
+}
+
+func TestElligator2MapsToCurve(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	var u FieldElement
+	for i := 0; i < 100; i++ {
+		if i == 0 {
+			u.SetZero()
+		} else {
+			u.SetRandomUnsafe(drng)
+		}
+		P := mapToCurveElligator2(&u)
+		if P.IsNaP() {
+			t.Fatalf("Elligator 2 map resulted in NaP")
+		}
+		if !P.Validate() {
+			t.Fatalf("Elligator 2 map resulted in invalid point")
+		}
+		var uNeg FieldElement
+		uNeg.Neg(&u)
+		Q := mapToCurveElligator2(&uNeg)
+		if !P.IsEqual(&Q) {
+			t.Fatalf("Elligator 2 map does not satisfy map(u) == map(-u)")
+		}
+	}
+}
+
+func TestHashToSubgroup(t *testing.T) {
+	dst := []byte("BANDERSNATCH-TEST-DST")
+	P1, _ := HashToSubgroup([]byte("abc"), dst)
+	P2, _ := HashToSubgroup([]byte("abc"), dst)
+	if P1.IsNaP() {
+		t.Fatalf("HashToSubgroup returned NaP")
+	}
+	if !P1.Validate() {
+		t.Fatalf("HashToSubgroup returned point that does not validate")
+	}
+	var P1Full Point_xtw_full
+	P1Full.SetFrom(&P1)
+	if !P1Full.IsInSubgroup() {
+		t.Fatalf("HashToSubgroup returned point outside the subgroup")
+	}
+	if P1.IsNeutralElement() {
+		t.Fatalf("HashToSubgroup returned neutral element")
+	}
+	if !P1.IsEqual(&P2) {
+		t.Fatalf("HashToSubgroup is not deterministic")
+	}
+	P3, _ := HashToSubgroup([]byte("abd"), dst)
+	if P1.IsEqual(&P3) {
+		t.Fatalf("HashToSubgroup gives the same result for different messages")
+	}
+	PDefault, _ := HashToSubgroup([]byte("abc"), nil)
+	PDefault2, _ := HashToSubgroup([]byte("abc"), []byte(DefaultHashToCurveDST))
+	if !PDefault.IsEqual(&PDefault2) {
+		t.Fatalf("HashToSubgroup with nil dst does not use DefaultHashToCurveDST")
+	}
+}
+
+func TestHashToCurveDST(t *testing.T) {
+	msg := []byte("message")
+
+	// different tags give different points
+	P1, _ := HashToSubgroup(msg, []byte("DST-1"))
+	P2, _ := HashToSubgroup(msg, []byte("DST-2"))
+	if P1.IsEqual(&P2) {
+		t.Fatalf("HashToSubgroup gives identical results for different domain separation tags")
+	}
+
+	// validation
+	if err := ValidateHashToCurveDST([]byte(DefaultHashToCurveDST)); err != nil {
+		t.Fatalf("DefaultHashToCurveDST does not validate: %v", err)
+	}
+	if err := ValidateHashToCurveDST(nil); !errors.Is(err, ErrHashToCurveDSTEmpty) {
+		t.Fatalf("ValidateHashToCurveDST did not reject empty tag. Got error %v", err)
+	}
+	maxLengthDST := bytes.Repeat([]byte{'a'}, MaxHashToCurveDSTLength)
+	if err := ValidateHashToCurveDST(maxLengthDST); err != nil {
+		t.Fatalf("ValidateHashToCurveDST rejected tag of maximal length: %v", err)
+	}
+	longDST := bytes.Repeat([]byte{'a'}, MaxHashToCurveDSTLength+1)
+	if err := ValidateHashToCurveDST(longDST); !errors.Is(err, ErrHashToCurveDSTTooLong) {
+		t.Fatalf("ValidateHashToCurveDST did not warn about overly long tag. Got error %v", err)
+	}
+
+	// overly long tags get hashed down, as specified in RFC 9380.
+	hashedDownDST := sha256.Sum256(append([]byte("H2C-OVERSIZE-DST-"), longDST...))
+	PLong, _ := HashToSubgroup(msg, longDST)
+	PHashedDown, _ := HashToSubgroup(msg, hashedDownDST[:])
+	if !PLong.IsEqual(&PHashedDown) {
+		t.Fatalf("HashToSubgroup does not hash down long domain separation tags as expected")
+	}
+	PMax, _ := HashToSubgroup(msg, maxLengthDST)
+	if PLong.IsEqual(&PMax) {
+		t.Fatalf("HashToSubgroup gives identical results for different long domain separation tags")
+	}
+	if !PLong.Validate() {
+		t.Fatalf("HashToSubgroup with long tag gives invalid point")
+	}
+
+	// empty tag gives an error for HashToSubgroup and a panic for HashToCurve
+	PEmpty, err := HashToSubgroup(msg, []byte{})
+	if !errors.Is(err, ErrHashToCurveDSTEmpty) || !PEmpty.IsNaP() {
+		t.Fatalf("HashToSubgroup did not reject empty domain separation tag. Got error %v", err)
+	}
+	if !testutils.CheckPanic(HashToCurve, msg, []byte{}) {
+		t.Fatalf("HashToCurve did not panic for empty domain separation tag")
+	}
+}
+
+// Regression test vectors for HashToCurve, given as the hex encoding of the short Banderwagon form of the output.
+// These were generated by this implementation; they ensure that results stay reproducible across versions.
+func TestHashToCurveTestVectors(t *testing.T) {
+	const dst = "QUUX-V01-CS02-with-bandersnatch_XMD:SHA-256_ELL2_RO_"
+	var testVectors = []struct {
+		msg      string
+		dst      string
+		expected string
+	}{
+		{"", dst, "615b154faef1012aa2d0d5c249171db2c7aae429b8b4f3abb091b7af3ce9c0bd"},
+		{"abc", dst, "baffda13068f6153432fbcc6eb1fc291933081d1d46496ffee046eed30042e92"},
+		{"abcdef0123456789", dst, "8b49fe6d8bffc4505223af243a81c83eab825e8889310c1369e45ed2226e448d"},
+		{"abc", DefaultHashToCurveDST, "594e2d98ae9dadda23fe6343d160edbf26cd5b11d7e8524698593feab703dba6"},
+	}
+	for _, testVector := range testVectors {
+		P := HashToCurve([]byte(testVector.msg), []byte(testVector.dst))
+		testutils.FatalUnless(t, !P.IsNaP() && !P.IsAtInfinity(), "HashToCurve returned NaP or point at infinity")
+		got, err := P.ToHexString()
+		testutils.FatalUnless(t, err == nil, "ToHexString failed: %v", err)
+		testutils.FatalUnless(t, got == testVector.expected, "HashToCurve does not match test vector for msg = %q, dst = %q: got %v, expected %v", testVector.msg, testVector.dst, got, testVector.expected)
+		Q, _ := HashToSubgroup([]byte(testVector.msg), []byte(testVector.dst))
+		testutils.FatalUnless(t, P.IsEqual(&Q), "HashToCurve and HashToSubgroup differ")
+	}
+}
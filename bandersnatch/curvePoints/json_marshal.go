@@ -0,0 +1,99 @@
+package curvePoints
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// This file makes the subgroup point types satisfy the json.Marshaler and json.Unmarshaler interfaces.
+//
+// A point is represented in JSON as a string holding "0x" followed by the (lower-case) hex encoding of its short Banderwagon form, as output by ToHexString.
+// Note that the byte order is common.DefaultEndian, so the hex string matches the output of MarshalBinary.
+//
+// Since the methods are defined on pointer receivers, encoding/json only uses them for addressable values; in particular,
+// marshalling a struct containing points needs to be done via a pointer to the struct.
+
+// jsonHexPrefix is the prefix of the hex strings used for JSON (de)serialization.
+const jsonHexPrefix = "0x"
+
+// marshalJSONSubgroup is the common implementation of MarshalJSON for the subgroup point types.
+func marshalJSONSubgroup(p CurvePointPtrInterfaceRead) ([]byte, error) {
+	var pSubgroup Point_xtw_subgroup
+	pSubgroup.SetFrom(p)
+	hexString, err := pSubgroup.ToHexString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonHexPrefix + hexString)
+}
+
+// unmarshalJSONSubgroup is the common implementation of UnmarshalJSON for the subgroup point types.
+// The input is always treated as untrusted. On error, receiver is untouched.
+func unmarshalJSONSubgroup(receiver CurvePointPtrInterfaceWrite, data []byte) error {
+	// By convention, unmarshalling JSON null is a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: JSON representation of a curve point must be a string: %v", ErrInvalidHexString, err)
+	}
+	if !strings.HasPrefix(s, jsonHexPrefix) {
+		return fmt.Errorf("%w: JSON representation of a curve point must start with %v", ErrInvalidHexString, jsonHexPrefix)
+	}
+	point, err := CurvePointFromHexString_subgroup(s[len(jsonHexPrefix):], untrustedInput)
+	if err != nil {
+		return err
+	}
+	receiver.SetFrom(&point)
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of p, which is a string holding the 0x-prefixed hex encoding of the short Banderwagon form of p.
+// This satisfies the json.Marshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_xtw_subgroup) MarshalJSON() ([]byte, error) {
+	return marshalJSONSubgroup(p)
+}
+
+// MarshalJSON returns the JSON representation of p, which is a string holding the 0x-prefixed hex encoding of the short Banderwagon form of p.
+// This satisfies the json.Marshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_axtw_subgroup) MarshalJSON() ([]byte, error) {
+	return marshalJSONSubgroup(p)
+}
+
+// MarshalJSON returns the JSON representation of p, which is a string holding the 0x-prefixed hex encoding of the short Banderwagon form of p.
+// This satisfies the json.Marshaler interface.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package.
+func (p *Point_efgh_subgroup) MarshalJSON() ([]byte, error) {
+	return marshalJSONSubgroup(p)
+}
+
+// UnmarshalJSON sets p to the point given by its JSON representation (as output by MarshalJSON). This satisfies the json.Unmarshaler interface.
+// The input is treated as untrusted. As usual for encoding/json, JSON null leaves p unchanged.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrInvalidHexString (for non-strings, missing 0x-prefix or invalid hex) and any error that CurvePointFromHexString_subgroup may output.
+func (p *Point_xtw_subgroup) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONSubgroup(p, data)
+}
+
+// UnmarshalJSON sets p to the point given by its JSON representation (as output by MarshalJSON). This satisfies the json.Unmarshaler interface.
+// The input is treated as untrusted. As usual for encoding/json, JSON null leaves p unchanged.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrInvalidHexString (for non-strings, missing 0x-prefix or invalid hex) and any error that CurvePointFromHexString_subgroup may output.
+func (p *Point_axtw_subgroup) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONSubgroup(p, data)
+}
+
+// UnmarshalJSON sets p to the point given by its JSON representation (as output by MarshalJSON). This satisfies the json.Unmarshaler interface.
+// The input is treated as untrusted. As usual for encoding/json, JSON null leaves p unchanged.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrInvalidHexString (for non-strings, missing 0x-prefix or invalid hex) and any error that CurvePointFromHexString_subgroup may output.
+func (p *Point_efgh_subgroup) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONSubgroup(p, data)
+}
@@ -0,0 +1,97 @@
+package curvePoints
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ json.Marshaler = &Point_xtw_subgroup{}
+var _ json.Marshaler = &Point_axtw_subgroup{}
+var _ json.Marshaler = &Point_efgh_subgroup{}
+
+var _ json.Unmarshaler = &Point_xtw_subgroup{}
+var _ json.Unmarshaler = &Point_axtw_subgroup{}
+var _ json.Unmarshaler = &Point_efgh_subgroup{}
+
+type jsonMarshalablePoint interface {
+	CurvePointPtrInterface
+	json.Marshaler
+	json.Unmarshaler
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		hexString, _ := P.ToHexString()
+		expected := "\"0x" + hexString + "\""
+		for _, pointType := range []PointType{pointTypeXTWSubgroup, pointTypeAXTWSubgroup, pointTypeEFGHSubgroup} {
+			point := makeCurvePointPtrInterface(pointType).(jsonMarshalablePoint)
+			point.SetFrom(&P)
+			data, err := json.Marshal(point)
+			testutils.FatalUnless(t, err == nil, "json.Marshal failed for %v: %v", pointTypeToString(pointType), err)
+			testutils.FatalUnless(t, string(data) == expected, "Unexpected JSON output for %v: %s", pointTypeToString(pointType), data)
+
+			result := makeCurvePointPtrInterface(pointType).(jsonMarshalablePoint)
+			err = json.Unmarshal(data, result)
+			testutils.FatalUnless(t, err == nil, "json.Unmarshal failed for %v: %v", pointTypeToString(pointType), err)
+			testutils.FatalUnless(t, result.IsEqual(&P), "Roundtrip failed for %v", pointTypeToString(pointType))
+		}
+	}
+
+	// points inside structs
+	type container struct {
+		Points []Point_xtw_subgroup `json:"points"`
+	}
+	input := container{Points: []Point_xtw_subgroup{SubgroupGenerator_xtw_subgroup, MakeRandomPointUnsafe_xtw_subgroup(drng)}}
+	data, err := json.Marshal(&input)
+	testutils.FatalUnless(t, err == nil, "json.Marshal failed: %v", err)
+	var output container
+	err = json.Unmarshal(data, &output)
+	testutils.FatalUnless(t, err == nil, "json.Unmarshal failed: %v", err)
+	testutils.FatalUnless(t, len(output.Points) == 2 && output.Points[0].IsEqual(&input.Points[0]) && output.Points[1].IsEqual(&input.Points[1]), "Roundtrip failed for struct")
+}
+
+func TestJSONErrors(t *testing.T) {
+	var nap Point_xtw_subgroup
+	_, err := nap.MarshalJSON()
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error for NaP: %v", err)
+
+	hexString, _ := SubgroupGenerator_xtw_subgroup.Clone().(*Point_xtw_subgroup).ToHexString()
+	for _, input := range []string{"1", "\"" + hexString + "\"", "\"0xzz\"", "\"0x" + hexString[0:62] + "\"", "\"0x" + hexString + "00\""} {
+		P := NeutralElement_xtw_subgroup
+		err = json.Unmarshal([]byte(input), &P)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidHexString) || errors.Is(err, ErrWrongHexStringLength), "Unexpected error for input %v: %v", input, err)
+		testutils.FatalUnless(t, P.IsNeutralElement(), "UnmarshalJSON modified receiver on error")
+	}
+
+	// valid hex, but invalid encodings. Note that random data fails (among other reasons) the subgroup check with probability 1/2.
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	var rejected int
+	for i := 0; i < 20; i++ {
+		var randomData [32]byte
+		drng.Read(randomData[:])
+		randomData[31] |= 0x80 // set the bit header for the short Banderwagon format
+		var Q Point_xtw_subgroup
+		if Q.DecodeFrom(&randomData, untrustedInput) == nil {
+			continue
+		}
+		rejected++
+		P := NeutralElement_xtw_subgroup
+		err = P.UnmarshalJSON([]byte("\"0x" + hex.EncodeToString(randomData[:]) + "\""))
+		testutils.FatalUnless(t, err != nil, "UnmarshalJSON accepted invalid encoding")
+		testutils.FatalUnless(t, P.IsNeutralElement(), "UnmarshalJSON modified receiver on error")
+	}
+	testutils.FatalUnless(t, rejected > 0, "No invalid inputs were generated")
+
+	// null is a no-op
+	P := SubgroupGenerator_xtw_subgroup
+	err = json.Unmarshal([]byte("null"), &P)
+	testutils.FatalUnless(t, err == nil && P.IsEqual(&SubgroupGenerator_xtw_subgroup), "Unmarshalling null failed")
+}
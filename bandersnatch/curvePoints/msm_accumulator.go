@@ -0,0 +1,133 @@
+package curvePoints
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// This file contains MSMAccumulator, which computes a multi-scalar multiplication sum_i scalars[i] * points[i] incrementally for streamed input.
+
+// DefaultMSMFlushThreshold is the number of buffered (scalar, point) pairs after which an MSMAccumulator created with a non-positive threshold flushes its buffer.
+const DefaultMSMFlushThreshold = 256
+
+// MSMAccumulator accumulates sum_i scalars[i] * points[i] for (scalar, point) pairs that are added one at a time.
+//
+// Pairs are buffered; whenever flushThreshold many pairs are buffered, we compute their contribution with Pippenger's bucket method and add it to a running partial result.
+// This means memory usage is bounded irrespective of the number of pairs added.
+//
+// MSMAccumulators must be created with NewMSMAccumulator. They are not safe for concurrent use.
+//
+// NOTE: This is not constant-time.
+type MSMAccumulator struct {
+	flushThreshold int
+	scalars        []big.Int // buffered scalars, reduced modulo GroupOrder
+	points         []Point_xtw_subgroup
+	partialResult  Point_xtw_subgroup // contribution of all flushed pairs
+}
+
+// NewMSMAccumulator creates a new MSMAccumulator whose result is initially the neutral element.
+// flushThreshold is the number of pairs that are buffered before computing their contribution; if it is non-positive, we use DefaultMSMFlushThreshold.
+func NewMSMAccumulator(flushThreshold int) *MSMAccumulator {
+	if flushThreshold <= 0 {
+		flushThreshold = DefaultMSMFlushThreshold
+	}
+	ret := MSMAccumulator{
+		flushThreshold: flushThreshold,
+		scalars:        make([]big.Int, 0, flushThreshold),
+		points:         make([]Point_xtw_subgroup, 0, flushThreshold),
+	}
+	ret.partialResult.SetNeutral()
+	return &ret
+}
+
+// Add adds scalar * point to the accumulated sum. The scalar may be negative and is reduced modulo GroupOrder_Int.
+// Neither scalar nor point are modified or retained; the caller may reuse them.
+//
+// point must not be a NaP; we panic otherwise.
+func (acc *MSMAccumulator) Add(scalar *big.Int, point *Point_xtw_subgroup) {
+	if point.IsNaP() {
+		panic(ErrorPrefix + "MSMAccumulator.Add called with NaP")
+	}
+	acc.scalars = append(acc.scalars, big.Int{})
+	ReduceScalar(&acc.scalars[len(acc.scalars)-1], scalar)
+	acc.points = append(acc.points, *point)
+	if len(acc.points) >= acc.flushThreshold {
+		acc.flush()
+	}
+}
+
+// Result returns the sum of scalar * point over all pairs added so far.
+// The accumulator remains usable afterwards, i.e. subsequent calls to Add continue the sum.
+func (acc *MSMAccumulator) Result() Point_xtw_subgroup {
+	acc.flush()
+	return acc.partialResult
+}
+
+// flush adds the contribution of the buffered pairs to the partial result and clears the buffer.
+func (acc *MSMAccumulator) flush() {
+	if len(acc.points) == 0 {
+		return
+	}
+	contribution := multiScalarMultPippenger(acc.scalars, acc.points)
+	acc.partialResult.AddEq(&contribution)
+	acc.scalars = acc.scalars[:0]
+	acc.points = acc.points[:0]
+}
+
+// pippengerWindowSize returns the window size (in bits) used by multiScalarMultPippenger for n pairs.
+// The cost is roughly (numBits / c) * (n + 2^c) additions, which is minimized for c about log2(n) - log2(log2(n)).
+func pippengerWindowSize(n int) int {
+	if n < 8 {
+		return 2
+	}
+	c := bits.Len(uint(n)) - bits.Len(uint(bits.Len(uint(n)))) + 1
+	if c < 2 {
+		c = 2
+	}
+	if c > 16 {
+		c = 16
+	}
+	return c
+}
+
+// multiScalarMultPippenger computes sum_i scalars[i] * points[i] using Pippenger's bucket method.
+// The scalars must be non-negative; scalars and points must have the same length.
+func multiScalarMultPippenger(scalars []big.Int, points []Point_xtw_subgroup) (result Point_xtw_subgroup) {
+	result.SetNeutral()
+	maxBitLen := 0
+	for i := range scalars {
+		if l := scalars[i].BitLen(); l > maxBitLen {
+			maxBitLen = l
+		}
+	}
+	c := pippengerWindowSize(len(points))
+	numWindows := (maxBitLen + c - 1) / c
+	buckets := make([]Point_xtw_subgroup, 1<<c) // buckets[0] is unused
+	for window := numWindows - 1; window >= 0; window-- {
+		for j := 0; j < c; j++ {
+			result.DoubleEq()
+		}
+		for d := range buckets {
+			buckets[d].SetNeutral()
+		}
+		for i := range scalars {
+			var digit uint
+			for j := c - 1; j >= 0; j-- {
+				digit = (digit << 1) | scalars[i].Bit(window*c+j)
+			}
+			if digit != 0 {
+				buckets[digit].AddEq(&points[i])
+			}
+		}
+		// sum_d d * buckets[d] = sum_{d} (sum_{d' >= d} buckets[d'])
+		var runningSum, windowSum Point_xtw_subgroup
+		runningSum.SetNeutral()
+		windowSum.SetNeutral()
+		for d := len(buckets) - 1; d >= 1; d-- {
+			runningSum.AddEq(&buckets[d])
+			windowSum.AddEq(&runningSum)
+		}
+		result.AddEq(&windowSum)
+	}
+	return
+}
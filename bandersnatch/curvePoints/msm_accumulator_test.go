@@ -0,0 +1,67 @@
+package curvePoints
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestMSMAccumulator(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const num = 100
+	scalars := make([]*big.Int, num)
+	points := make([]Point_xtw_subgroup, num)
+	for i := 0; i < num; i++ {
+		points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		scalars[i] = new(big.Int).Rand(drng, GroupOrder_Int)
+		switch i {
+		case 0:
+			scalars[i].SetInt64(0)
+		case 1:
+			scalars[i].Neg(scalars[i])
+		case 2:
+			points[i].SetNeutral()
+		}
+	}
+
+	for _, threshold := range []int{0, 1, 7, 32, num, 2 * num} {
+		acc := NewMSMAccumulator(threshold)
+		result := acc.Result()
+		testutils.FatalUnless(t, result.IsNeutralElement(), "empty MSMAccumulator does not give neutral element")
+		for i := 0; i < num; i++ {
+			acc.Add(scalars[i], &points[i])
+			if i == num/2 {
+				// intermediate results must not disturb the accumulation
+				expected := naiveMultiScalarMult(points[0:i+1], scalars[0:i+1])
+				result = acc.Result()
+				testutils.FatalUnless(t, result.IsEqual(&expected), "intermediate result of MSMAccumulator with threshold %v is wrong", threshold)
+			}
+		}
+		expected := naiveMultiScalarMult(points, scalars)
+		result = acc.Result()
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MSMAccumulator with threshold %v differs from batch computation", threshold)
+	}
+
+	var nap Point_xtw_subgroup
+	acc := NewMSMAccumulator(0)
+	testutils.FatalUnless(t, testutils.CheckPanic(acc.Add, big.NewInt(1), &nap), "MSMAccumulator.Add did not panic on NaP")
+}
+
+func TestMultiScalarMultPippenger(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 8, 20, 300} {
+		scalars := make([]big.Int, n)
+		scalarPtrs := make([]*big.Int, n)
+		points := make([]Point_xtw_subgroup, n)
+		for i := 0; i < n; i++ {
+			scalars[i].Rand(drng, GroupOrder_Int)
+			scalarPtrs[i] = &scalars[i]
+			points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		}
+		expected := naiveMultiScalarMult(points, scalarPtrs)
+		result := multiScalarMultPippenger(scalars, points)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "Pippenger gives wrong result for n == %v", n)
+	}
+}
@@ -0,0 +1,44 @@
+package curvePoints
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// This file contains MultiScalarMult, which computes sum_i scalars[i] * points[i] for given slices in one go.
+// For input that arrives one pair at a time, see MSMAccumulator.
+
+// ErrMSMLengthMismatch is returned (wrapped) by MultiScalarMult if the number of points and scalars differ.
+var ErrMSMLengthMismatch = errors.New(ErrorPrefix + "MultiScalarMult called with different numbers of points and scalars")
+
+// MultiScalarMult computes sum_i scalars[i] * points[i] with Pippenger's bucket method and stores the result in result.
+// The window size is chosen based on the number of pairs. The scalars may be negative and are reduced modulo GroupOrder_Int; points and scalars are not modified.
+//
+// Pairs whose scalar is 0 modulo GroupOrder_Int are skipped. For empty input, the result is the neutral element.
+// If len(points) != len(scalars), we return an error wrapping ErrMSMLengthMismatch and do not modify result.
+// Scalars must not be nil and points must not be NaPs; we panic otherwise.
+//
+// NOTE: This is not constant-time.
+func MultiScalarMult(result CurvePointPtrInterfaceWrite, points []Point_xtw_subgroup, scalars []*big.Int) error {
+	if len(points) != len(scalars) {
+		return fmt.Errorf("%w: len(points) == %v, len(scalars) == %v", ErrMSMLengthMismatch, len(points), len(scalars))
+	}
+	reducedScalars := make([]big.Int, 0, len(scalars))
+	nonZeroPoints := make([]Point_xtw_subgroup, 0, len(points))
+	for i := range points {
+		if points[i].IsNaP() {
+			panic(ErrorPrefix + "MultiScalarMult called with NaP")
+		}
+		var reduced big.Int
+		ReduceScalar(&reduced, scalars[i])
+		if reduced.Sign() == 0 {
+			continue
+		}
+		reducedScalars = append(reducedScalars, reduced)
+		nonZeroPoints = append(nonZeroPoints, points[i])
+	}
+	sum := multiScalarMultPippenger(reducedScalars, nonZeroPoints)
+	result.SetFrom(&sum)
+	return nil
+}
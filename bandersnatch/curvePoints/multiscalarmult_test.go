@@ -0,0 +1,96 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// naiveMultiScalarMult computes sum_i scalars[i] * points[i] one scalar multiplication at a time. This is the reference for testing the batched algorithms.
+func naiveMultiScalarMult(points []Point_xtw_subgroup, scalars []*big.Int) (sum Point_xtw_subgroup) {
+	sum.SetNeutral()
+	for i := range points {
+		var summand Point_xtw_subgroup
+		summand.ScalarMult(&points[i], scalars[i])
+		sum.AddEq(&summand)
+	}
+	return
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(671))
+	for _, n := range []int{1, 2, 7, 33, 200} {
+		scalars := make([]*big.Int, n)
+		points := make([]Point_xtw_subgroup, n)
+		for i := 0; i < n; i++ {
+			scalars[i] = new(big.Int).Rand(drng, GroupOrder_Int)
+			switch i % 5 {
+			case 1:
+				scalars[i].Neg(scalars[i])
+			case 2:
+				scalars[i].SetInt64(0)
+			case 3:
+				scalars[i].Set(GroupOrder_Int) // zero modulo GroupOrder
+			}
+			points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		}
+		expected := naiveMultiScalarMult(points, scalars)
+
+		var result Point_xtw_subgroup
+		err := MultiScalarMult(&result, points, scalars)
+		testutils.FatalUnless(t, err == nil, "MultiScalarMult failed: %v", err)
+		testutils.FatalUnless(t, result.IsEqual(&expected), "MultiScalarMult gives wrong result for n == %v", n)
+
+		// other result types
+		var resultFull Point_efgh_full
+		err = MultiScalarMult(&resultFull, points, scalars)
+		testutils.FatalUnless(t, err == nil, "MultiScalarMult failed: %v", err)
+		testutils.FatalUnless(t, resultFull.IsEqual(&expected), "MultiScalarMult gives wrong result for Point_efgh_full for n == %v", n)
+	}
+
+	// empty input
+	result := SubgroupGenerator_xtw_subgroup
+	err := MultiScalarMult(&result, nil, nil)
+	testutils.FatalUnless(t, err == nil && result.IsNeutralElement(), "MultiScalarMult on empty input should give the neutral element")
+
+	// all-zero scalars
+	result = SubgroupGenerator_xtw_subgroup
+	err = MultiScalarMult(&result, []Point_xtw_subgroup{SubgroupGenerator_xtw_subgroup}, []*big.Int{big.NewInt(0)})
+	testutils.FatalUnless(t, err == nil && result.IsNeutralElement(), "MultiScalarMult with zero scalar should give the neutral element")
+
+	// length mismatch
+	result = SubgroupGenerator_xtw_subgroup
+	err = MultiScalarMult(&result, []Point_xtw_subgroup{SubgroupGenerator_xtw_subgroup}, nil)
+	testutils.FatalUnless(t, errors.Is(err, ErrMSMLengthMismatch), "MultiScalarMult did not report length mismatch, got %v", err)
+	testutils.FatalUnless(t, result.IsEqual(&SubgroupGenerator_xtw_subgroup), "MultiScalarMult modified result on error")
+}
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	const n = 1024
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	scalars := make([]*big.Int, n)
+	points := make([]Point_xtw_subgroup, n)
+	for i := 0; i < n; i++ {
+		scalars[i] = new(big.Int).Rand(drng, GroupOrder_Int)
+		points[i] = MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	var result Point_xtw_subgroup
+	b.Run("MultiScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = MultiScalarMult(&result, points, scalars)
+		}
+	})
+	b.Run("ScalarMult loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var summand Point_xtw_subgroup
+			result.SetNeutral()
+			for j := 0; j < n; j++ {
+				summand.ScalarMult(&points[j], scalars[j])
+				result.AddEq(&summand)
+			}
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package curvePoints
+
+import "math/big"
+
+// This file contains Pedersen commitments to a single scalar.
+
+// PedersenCommit returns the Pedersen commitment value*G + blinding*H to value with blinding factor blinding.
+//
+// G and H must be generators of the prime-order subgroup whose discrete logarithm relation is unknown (e.g. obtained via hashing to the curve); otherwise, the commitment is not binding.
+// The commitment is perfectly hiding if blinding is chosen uniformly at random modulo GroupOrder.
+// value and blinding may be arbitrary (including negative) integers; they are reduced modulo GroupOrder and not modified.
+//
+// The result is computed with a single 2-point multi-scalar multiplication, so the commitment is additively homomorphic:
+// PedersenCommit(v1, b1, G, H) + PedersenCommit(v2, b2, G, H) == PedersenCommit(v1+v2, b1+b2, G, H).
+//
+// NOTE: This is not constant-time in value and blinding.
+func PedersenCommit(value, blinding *big.Int, G, H *Point_xtw_subgroup) Point_xtw_subgroup {
+	scalars := make([]big.Int, 2)
+	ReduceScalar(&scalars[0], value)
+	ReduceScalar(&scalars[1], blinding)
+	points := []Point_xtw_subgroup{*G, *H}
+	return multiScalarMultPippenger(scalars, points)
+}
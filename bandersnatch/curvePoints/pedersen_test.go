@@ -0,0 +1,52 @@
+package curvePoints
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestPedersenCommit(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1002))
+	G := SubgroupGenerator_xtw_subgroup
+	var H Point_xtw_subgroup
+	H.sampleRandomUnsafe(drng)
+
+	for i := 0; i < 20; i++ {
+		v1 := new(big.Int).Rand(drng, GroupOrder_Int)
+		b1 := new(big.Int).Rand(drng, GroupOrder_Int)
+		v2 := new(big.Int).Rand(drng, GroupOrder_Int)
+		b2 := new(big.Int).Rand(drng, GroupOrder_Int)
+		if i == 0 {
+			v2.Neg(v2) // negative inputs are allowed
+		}
+
+		// compare against naive computation
+		C1 := PedersenCommit(v1, b1, &G, &H)
+		var vG, bH, expected Point_xtw_subgroup
+		vG.ScalarMult(&G, v1)
+		bH.ScalarMult(&H, b1)
+		expected.Add(&vG, &bH)
+		testutils.FatalUnless(t, C1.IsEqual(&expected), "PedersenCommit differs from naive computation")
+
+		// homomorphic property
+		C2 := PedersenCommit(v2, b2, &G, &H)
+		var sum Point_xtw_subgroup
+		sum.Add(&C1, &C2)
+		vSum := new(big.Int).Add(v1, v2)
+		bSum := new(big.Int).Add(b1, b2)
+		CSum := PedersenCommit(vSum, bSum, &G, &H)
+		testutils.FatalUnless(t, sum.IsEqual(&CSum), "PedersenCommit is not homomorphic")
+
+		// inputs are not modified
+		testutils.FatalUnless(t, vSum.Cmp(new(big.Int).Add(v1, v2)) == 0, "PedersenCommit modified its input")
+	}
+
+	zero := big.NewInt(0)
+	C := PedersenCommit(zero, zero, &G, &H)
+	testutils.FatalUnless(t, C.IsNeutralElement(), "Commitment to 0 with blinding 0 is not neutral")
+	C = PedersenCommit(GroupOrder_Int, big.NewInt(1), &G, &H)
+	testutils.FatalUnless(t, C.IsEqual(&H), "PedersenCommit does not reduce modulo the group order")
+}
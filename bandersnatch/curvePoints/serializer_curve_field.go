@@ -5,6 +5,7 @@ import (
 
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 )
 
 // This file contains routines that map curve points to field elements and allow reconstructing them from field elements.
@@ -47,6 +48,24 @@ func MapToFieldElement(input CurvePointPtrInterfaceRead) (ret FieldElement) {
 // Note: We do not guarantee consistent return values because the modular square root algorithms might be randomized.
 // An optimized implementation for hardwired field size probably is not, but a generic one for field size mod 8 = 1 is reasonably likely randomized.
 // We do not wish to depend on particularities of the base field implementation.
+// Users who need consistent return values can install a deterministic square root algorithm via SetSquareRootImpl.
+
+// IsValidXCoordinate checks whether x is the affine x coordinate of some point on the Bandersnatch curve.
+// The point need not be in the prime-order subgroup.
+//
+// This is cheaper than recoverYFromXAffine, as it only computes a Legendre symbol rather than a square root.
+func IsValidXCoordinate(x *FieldElement) bool {
+	// We have y^2 = (1-ax^2) / (1-dx^2), so x is valid iff (1-ax^2) * (1-dx^2) is a square.
+	// Note that both factors are non-zero, since a and d are non-squares.
+	var num, denom FieldElement
+	num.Square(x)
+	denom.Mul(&num, &CurveParameterD_fe)
+	num.Multiply_by_five()
+	num.AddEq(&fieldElementOne)
+	denom.Sub(&fieldElementOne, &denom)
+	num.MulEq(&denom)
+	return num.Jacobi() >= 0
+}
 
 // recoverYFromXAffine computes y from x such that (x,y) is a point on the Bandersnatch curve in affine twisted Edwards coordinates.
 // Note that the result only depends on x up to sign.
@@ -94,7 +113,7 @@ func recoverYFromXAffine(x *FieldElement, legendreCheckX bool) (y FieldElement,
 
 			// The type of error depends on whether denom is a square or not.
 			num.DivideEq(&denom)
-			if y.SquareRoot(&num) {
+			if squareRoot(&y, &num) {
 				err = errorsWithData.NewErrorWithGuaranteedParameters[struct{ X FieldElement }](bandersnatchErrors.ErrXNotInSubgroup, "%w. The received X coordinates was %v{X}", "X", *x)
 
 			} else {
@@ -106,7 +125,7 @@ func recoverYFromXAffine(x *FieldElement, legendreCheckX bool) (y FieldElement,
 		}
 	}
 	num.DivideEq(&denom) // (1-ax^2)/(1-dx^2). Note that 1-dx^2 cannot be 0, as d is a non-square.
-	if !y.SquareRoot(&num) {
+	if !squareRoot(&y, &num) {
 		err = errorsWithData.NewErrorWithGuaranteedParameters[struct{ X FieldElement }](bandersnatchErrors.ErrXNotOnCurve, "%w. The received X coordinate was %v{X}", "X", *x)
 		return
 	}
@@ -134,7 +153,7 @@ func recoverXFromYAffine(y *FieldElement) (x FieldElement, err errorsWithData.Er
 		return
 	}
 	num.DivideEq(&denom) // (y^2 - 1) / (dy^2 - a)
-	ok := x.SquareRoot(&num)
+	ok := squareRoot(&x, &num)
 	if !ok {
 		x.SetZero() // We prefer to have some consistent return value for x on error. It must not be used anyway.
 		err = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrYNotOnCurve, "%w. The received affine Y/Z coordinate was %v{Y}", &struct{ Y FieldElement }{Y: *y})
@@ -220,6 +239,8 @@ func CurvePointFromXYAffine_subgroup(x *FieldElement, y *FieldElement, trustLeve
 // NOTE: For the current implementation of FullCurvePointFromXAndSigny, trustLevel actually does not influence whether we perform checks.
 // We always check if the x coordinate corresponds to a curve point.
 // However, for trustedInput, we panic on failure rather than return an error.
+// For CurvePointFromXAndSignY_full and CurvePointFromXAndSignY_subgroup, these panics are internal consistency checks
+// that are skipped if the noassertions build tag is set (see testutils.AssertionsActive); we then return the error.
 
 // TODO: Document possible errors?
 
@@ -258,7 +279,7 @@ func CurvePointFromXAndSignY_full(x *FieldElement, signY int, trustLevel IsInput
 		)
 
 		// If the caller claimed the input was trusted and we detected it's invalid, we panic.
-		if trustLevel.Bool() {
+		if trustLevel.Bool() && testutils.AssertionsActive {
 			panic(err)
 		}
 		return
@@ -270,9 +291,9 @@ func CurvePointFromXAndSignY_full(x *FieldElement, signY int, trustLevel IsInput
 	point.y, errWithX = recoverYFromXAffine(x, false)
 
 	if errWithX != nil {
-		err = errorsWithData.IncludeGuaranteedParametersInError[retData](errWithX, "%w", "SignY", signY)
+		err = errorsWithData.IncludeGuaranteedParametersInError[retData](errWithX, "SignY", signY)
 		// On trusted input, we panic on error.
-		if trustLevel.Bool() {
+		if trustLevel.Bool() && testutils.AssertionsActive {
 			panic(fmt.Errorf(ErrorPrefix_CurveFieldElementSerializers+"CurvePointFromXAndSignY_full encountered error on trusted input. Error was %w", err))
 		}
 		point = Point_axtw_full{}
@@ -319,7 +340,7 @@ func CurvePointFromXAndSignY_subgroup(x *FieldElement, signY int, trustLevel IsI
 		)
 
 		// If the caller claimed the input was trusted and we detected it's invalid, we panic.
-		if trustLevel.Bool() {
+		if trustLevel.Bool() && testutils.AssertionsActive {
 			panic(err)
 		}
 		return
@@ -335,7 +356,7 @@ func CurvePointFromXAndSignY_subgroup(x *FieldElement, signY int, trustLevel IsI
 	if err != nil {
 
 		// Note: trustLevel == TrustedInput ought to be impossible, because CurvePointFromXAndSignY_full should have panicked already. Still included for robustness.
-		if trustLevel.Bool() {
+		if trustLevel.Bool() && testutils.AssertionsActive {
 
 			panic(fmt.Errorf(ErrorPrefix_CurveFieldElementSerializers+"error encountered upon trusted construction of curve point with SubgroupCurvePointFromXAndSignY. Error was %w", err))
 		}
@@ -354,7 +375,7 @@ func CurvePointFromXAndSignY_subgroup(x *FieldElement, signY int, trustLevel IsI
 		// So we always have ok == true for trusted input (even it's wrong).
 		// So if the input is not in the subgroup and trustLevel is TrustedInput, we actually DO output garbage.
 		// It is entirely the fault of the caller if that happens.
-		if trustLevel.Bool() {
+		if trustLevel.Bool() && testutils.AssertionsActive {
 			panic(err)
 		}
 
@@ -362,6 +383,36 @@ func CurvePointFromXAndSignY_subgroup(x *FieldElement, signY int, trustLevel IsI
 	return
 }
 
+// signFromBit converts a sign bit (true meaning negative) into a +/-1 - valued sign.
+func signFromBit(negative bool) int {
+	if negative {
+		return -1
+	}
+	return +1
+}
+
+// CurvePointFromXAndSignYBit_full is a variant of CurvePointFromXAndSignY_full that takes the sign of the y coordinate as a sign bit. negative == true means Sign(y) == -1.
+//
+// Since the sign is given as a bool, it cannot be invalid; the possible errors are the same as for CurvePointFromXAndSignY_full except for ErrInvalidSign.
+// Note that the SignY field of the returned error's data is -1 or +1.
+func CurvePointFromXAndSignYBit_full(x *FieldElement, negative bool, trustLevel IsInputTrusted) (point Point_axtw_full, err errorsWithData.ErrorWithGuaranteedParameters[struct {
+	X     FieldElement
+	SignY int
+}]) {
+	return CurvePointFromXAndSignY_full(x, signFromBit(negative), trustLevel)
+}
+
+// CurvePointFromXAndSignYBit_subgroup is a variant of CurvePointFromXAndSignY_subgroup that takes the sign of the y coordinate as a sign bit. negative == true means Sign(y) == -1.
+//
+// Since the sign is given as a bool, it cannot be invalid; the possible errors are the same as for CurvePointFromXAndSignY_subgroup except for ErrInvalidSign.
+// Note that the SignY field of the returned error's data is -1 or +1.
+func CurvePointFromXAndSignYBit_subgroup(x *FieldElement, negative bool, trustLevel IsInputTrusted) (point Point_axtw_subgroup, err errorsWithData.ErrorWithGuaranteedParameters[struct {
+	X     FieldElement
+	SignY int
+}]) {
+	return CurvePointFromXAndSignY_subgroup(x, signFromBit(negative), trustLevel)
+}
+
 // TODO: Special-case Point at infinity? After all, these have a meaningful Y/Z coo.
 // (As in: Either give specific error message or allow constructing points of infinity -- the latter means changing the return type, which is annoying)
 
@@ -627,3 +678,68 @@ func CurvePointFromXYTimesSignY_subgroup(xSignY *FieldElement, ySignY *FieldElem
 func CurvePointFromYXTimesSignY_subgroup(ySignY *FieldElement, xSignY *FieldElement, trustLevel IsInputTrusted) (point Point_axtw_subgroup, err errorsWithData.ErrorWithGuaranteedParameters[struct{ XSignY, YSignY FieldElement }]) {
 	return CurvePointFromXYTimesSignY_subgroup(xSignY, ySignY, trustLevel)
 }
+
+// CurvePointFromMapFieldElement_subgroup constructs an elliptic curve point on the prime-order subgroup from its image xOverY = X/Y under MapToFieldElement.
+// trustLevel should be one of TrustedInput or UntrustedInput.
+// Since MapToFieldElement is injective on the prime-order subgroup (and maps the neutral element to 0), this uniquely determines the point.
+//
+// It returns an error if the provided input is invalid. In this case, the returned point must not be used.
+// If trustLevel is TrustedInput, you *MUST* call this only with valid input; we are free to skip some tests.
+// The library makes no guarantees whatsoever about what happens if you violate this.
+//
+// Possible errors are (errors possibly wrapping)
+//
+// ErrNotOnCurve, ErrNotInSubgroup
+func CurvePointFromMapFieldElement_subgroup(xOverY *FieldElement, trustLevel IsInputTrusted) (point Point_axtw_subgroup, err errorsWithData.ErrorWithGuaranteedParameters[struct{ XOverY FieldElement }]) {
+	type errData = struct{ XOverY FieldElement }
+
+	if xOverY.IsZero() {
+		point = NeutralElement_axtw_subgroup
+		return
+	}
+
+	// Plugging x = xOverY * y into the curve equation ax^2 + y^2 = 1 + dx^2y^2 gives a quadratic equation in y^2, namely
+	// d*m^2 * (y^2)^2 - (1+am^2) * y^2 + 1 == 0 for m = xOverY.
+	// The product of the two roots is 1/(dm^2), which is a non-square, since d is. So at most one root is a square, which means there is at most one rational y^2.
+	var mSquared, b, discriminant, twoDMSquared, root, ySquared, temp FieldElement
+	mSquared.Square(xOverY)
+	b.Mul(&mSquared, &CurveParameterA_fe)
+	b.AddEq(&fieldElementOne) // 1 + am^2
+	twoDMSquared.Mul(&mSquared, &CurveParameterD_fe)
+	twoDMSquared.DoubleEq() // 2dm^2
+	discriminant.Square(&b)
+	temp.Double(&twoDMSquared)
+	discriminant.SubEq(&temp) // (1+am^2)^2 - 4dm^2
+	if !squareRoot(&root, &discriminant) {
+		err = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrNotOnCurve, "%w. The received X/Y was %v{XOverY}", &errData{XOverY: *xOverY})
+		if trustLevel.Bool() {
+			panic(err)
+		}
+		return
+	}
+	ySquared.Add(&b, &root)
+	ySquared.DivideEq(&twoDMSquared)
+	if !squareRoot(&point.y, &ySquared) {
+		ySquared.Sub(&b, &root)
+		ySquared.DivideEq(&twoDMSquared)
+		if !squareRoot(&point.y, &ySquared) {
+			err = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrNotOnCurve, "%w. The received X/Y was %v{XOverY}", &errData{XOverY: *xOverY})
+			point = Point_axtw_subgroup{}
+			if trustLevel.Bool() {
+				panic(err)
+			}
+			return
+		}
+	}
+	// The choice of sign of y corresponds to the choice between P and P+A, which we do not care about.
+	point.x.Mul(xOverY, &point.y)
+	point.t.Mul(&point.x, &point.y)
+
+	if !trustLevel.Bool() {
+		if !legendreCheckA_affineX(point.x) {
+			err = errorsWithData.NewErrorWithParametersFromData(bandersnatchErrors.ErrNotInSubgroup, "%w. The received X/Y was %v{XOverY}", &errData{XOverY: *xOverY})
+			point = Point_axtw_subgroup{}
+		}
+	}
+	return
+}
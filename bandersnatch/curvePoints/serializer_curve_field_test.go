@@ -549,3 +549,127 @@ func make_checkfun_recoverPoint(recoveryFun interface{}, name string, subgroupOn
 	}
 	return
 }
+
+func TestCurvePointFromXAndSignYBit(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		var x FieldElement
+		if i%5 == 0 {
+			P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+			x = P.X_affine()
+		} else {
+			x.SetRandomUnsafe(drng)
+		}
+		for _, negative := range []bool{false, true} {
+			signY := +1
+			if negative {
+				signY = -1
+			}
+			P1, err1 := CurvePointFromXAndSignY_full(&x, signY, untrustedInput)
+			P2, err2 := CurvePointFromXAndSignYBit_full(&x, negative, untrustedInput)
+			testutils.FatalUnless(t, (err1 == nil) == (err2 == nil), "CurvePointFromXAndSignYBit_full differs from CurvePointFromXAndSignY_full in error behaviour")
+			if err1 == nil {
+				testutils.FatalUnless(t, P1.IsEqual(&P2), "CurvePointFromXAndSignYBit_full differs from CurvePointFromXAndSignY_full")
+				y := P2.Y_affine()
+				testutils.FatalUnless(t, y.Sign() == signY, "CurvePointFromXAndSignYBit_full gives wrong sign")
+			} else {
+				testutils.FatalUnless(t, err1.Error() == err2.Error(), "CurvePointFromXAndSignYBit_full gives different error")
+			}
+			Q1, errQ1 := CurvePointFromXAndSignY_subgroup(&x, signY, untrustedInput)
+			Q2, errQ2 := CurvePointFromXAndSignYBit_subgroup(&x, negative, untrustedInput)
+			testutils.FatalUnless(t, (errQ1 == nil) == (errQ2 == nil), "CurvePointFromXAndSignYBit_subgroup differs from CurvePointFromXAndSignY_subgroup in error behaviour")
+			if errQ1 == nil {
+				testutils.FatalUnless(t, Q1.IsEqual(&Q2), "CurvePointFromXAndSignYBit_subgroup differs from CurvePointFromXAndSignY_subgroup")
+			} else {
+				testutils.FatalUnless(t, errQ1.Error() == errQ2.Error(), "CurvePointFromXAndSignYBit_subgroup gives different error")
+			}
+		}
+	}
+}
+
+// Regression test: CurvePointFromXAndSignY_full used to pass a stray format argument when attaching SignY to the error, which broke the error path for x coordinates not on the curve.
+func TestCurvePointFromXAndSignYErrorData(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	var tested int
+	for i := 0; i < 20; i++ {
+		var x FieldElement
+		x.SetRandomUnsafe(drng)
+		if _, errRecover := recoverYFromXAffine(&x, false); errRecover == nil {
+			continue
+		}
+		tested++
+		for _, signY := range []int{+1, -1} {
+			_, err := CurvePointFromXAndSignY_full(&x, signY, untrustedInput)
+			testutils.FatalUnless(t, errors.Is(err, ErrXNotOnCurve), "Unexpected error: %v", err)
+			data := err.GetData()
+			testutils.FatalUnless(t, data.SignY == signY, "Error data has SignY == %v, expected %v", data.SignY, signY)
+			testutils.FatalUnless(t, data.X.IsEqual(&x), "Error data has wrong X")
+		}
+	}
+	testutils.FatalUnless(t, tested > 0, "No x coordinates that are not on the curve were generated")
+}
+
+// TestCurvePointFromXAndSignYTrustedChecks checks that invalid trusted input causes a panic iff assertions are active.
+// Run with -tags noassertions to test the other case.
+func TestCurvePointFromXAndSignYTrustedChecks(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	var x FieldElement
+	for {
+		x.SetRandomUnsafe(drng)
+		if _, errRecover := recoverYFromXAffine(&x, false); errRecover != nil {
+			break
+		}
+	}
+	for _, signY := range []int{+1, 0} {
+		didPanic := testutils.CheckPanic(CurvePointFromXAndSignY_full, &x, signY, trustedInput)
+		testutils.FatalUnless(t, didPanic == testutils.AssertionsActive, "CurvePointFromXAndSignY_full on invalid trusted input: panic was %v, AssertionsActive is %v", didPanic, testutils.AssertionsActive)
+		didPanic = testutils.CheckPanic(CurvePointFromXAndSignY_subgroup, &x, signY, trustedInput)
+		testutils.FatalUnless(t, didPanic == testutils.AssertionsActive, "CurvePointFromXAndSignY_subgroup on invalid trusted input: panic was %v, AssertionsActive is %v", didPanic, testutils.AssertionsActive)
+		if !testutils.AssertionsActive {
+			_, err := CurvePointFromXAndSignY_subgroup(&x, signY, trustedInput)
+			testutils.FatalUnless(t, err != nil, "CurvePointFromXAndSignY_subgroup did not report invalid trusted input")
+		}
+	}
+}
+
+func TestCurvePointFromMapFieldElement(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		m := MapToFieldElement(&P)
+		for _, trustLevel := range []IsInputTrusted{trustedInput, untrustedInput} {
+			Q, err := CurvePointFromMapFieldElement_subgroup(&m, trustLevel)
+			testutils.FatalUnless(t, err == nil, "CurvePointFromMapFieldElement_subgroup failed: %v", err)
+			testutils.FatalUnless(t, Q.IsEqual(&P), "CurvePointFromMapFieldElement_subgroup did not recover point")
+		}
+
+		// P + E1 is on the curve, but not in the subgroup (modulo A)
+		var R Point_xtw_full
+		R.Add(&P, &InfinitePoint1_xtw)
+		if !R.IsAtInfinity() {
+			mR := MapToFieldElement(&R)
+			_, err := CurvePointFromMapFieldElement_subgroup(&mR, untrustedInput)
+			testutils.FatalUnless(t, errors.Is(err, ErrNotInSubgroup), "CurvePointFromMapFieldElement_subgroup did not detect point outside subgroup: %v", err)
+		}
+	}
+
+	// random field elements either give an error or a point with the correct image.
+	var seenNotOnCurve bool
+	for i := 0; i < 50; i++ {
+		var m FieldElement
+		m.SetRandomUnsafe(drng)
+		Q, err := CurvePointFromMapFieldElement_subgroup(&m, untrustedInput)
+		if errors.Is(err, ErrNotOnCurve) {
+			seenNotOnCurve = true
+			continue
+		}
+		if err == nil {
+			mQ := MapToFieldElement(&Q)
+			testutils.FatalUnless(t, mQ.IsEqual(&m), "CurvePointFromMapFieldElement_subgroup gives point with wrong image")
+		}
+	}
+	testutils.FatalUnless(t, seenNotOnCurve, "")
+}
@@ -0,0 +1,140 @@
+package curvePoints
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+)
+
+// This file contains convenience functions to convert curve points from/to hex strings and the underlying canonical 32-byte encoding.
+// These are meant for tests, scripts and logging. For anything else, use the serializers from the pointserializer package.
+//
+// The format used is the short Banderwagon format, i.e. X*Sign(Y) written as a single field element in common.DefaultEndian byte order,
+// where the most significant bit is set to 1. This is the same format as used by the pointserializer package's default short Banderwagon serializer.
+
+// hexShortFormBitHeader is the bit header used for the X*Sign(Y) field element in the short Banderwagon format.
+var hexShortFormBitHeader common.BitHeader = common.MakeBitHeader(common.PrefixBits(0b1), 1)
+
+// hexShortFormByteLength is the number of bytes encoded by a hex string in short Banderwagon form.
+const hexShortFormByteLength = BaseFieldByteLength
+
+var ErrInvalidHexString = errors.New(ErrorPrefix + "input is not a valid hex string")
+var ErrWrongHexStringLength = fmt.Errorf(ErrorPrefix+"hex string does not encode exactly %v bytes", hexShortFormByteLength)
+
+// DecafEncode returns the canonical 32-byte encoding of p, identifying P and P+A as the subgroup types do.
+// The format is the short Banderwagon format, i.e. X*Sign(Y) (which is invariant under P -> P+A) as a single field element in common.DefaultEndian byte order
+// with the most significant bit set to 1.
+// The output agrees with the pointserializer package's default short Banderwagon serializer, but this does not involve any of the serializer machinery.
+//
+// DecafEncode panics if p is a NaP.
+func DecafEncode(p *Point_xtw_subgroup) (ret [hexShortFormByteLength]byte) {
+	if p.IsNaP() {
+		panic(ErrorPrefix + "DecafEncode called on NaP")
+	}
+	if err := p.EncodeTo(&ret); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// DecafDecode constructs a point on the prime-order subgroup from its encoding as output by DecafEncode.
+// trustLevel should be one of TrustedInput or UntrustedInput.
+//
+// It returns an error if data is invalid. In this case, the returned point must not be used.
+// Possible errors are any error that the deserialization of field elements or CurvePointFromXTimesSignY_subgroup may output.
+// In particular, non-canonical encodings (with a non-normalized field element) are rejected.
+func DecafDecode(data [hexShortFormByteLength]byte, trustLevel IsInputTrusted) (point Point_xtw_subgroup, err error) {
+	var xSignY FieldElement
+	_, errDeserialize := xSignY.DeserializeWithPrefix(bytes.NewReader(data[:]), hexShortFormBitHeader, common.DefaultEndian)
+	if errDeserialize != nil {
+		err = errDeserialize
+		return
+	}
+	pointAffine, errConversion := CurvePointFromXTimesSignY_subgroup(&xSignY, trustLevel)
+	if errConversion != nil {
+		err = errConversion
+		return
+	}
+	point.SetFrom(&pointAffine)
+	return
+}
+
+// EncodeTo writes the canonical 32-byte encoding of p (as output by DecafEncode) to dst.
+// As opposed to DecafEncode or the serializers from the pointserializer package, this performs no heap allocations and is meant for hot loops.
+//
+// The only possible error is ErrCannotSerializeNaP from the bandersnatchErrors package, in which case dst is untouched.
+func (p *Point_xtw_subgroup) EncodeTo(dst *[hexShortFormByteLength]byte) error {
+	if p.IsNaP() {
+		return bandersnatchErrors.ErrCannotSerializeNaP
+	}
+	// X*Sign(Y) is invariant under (X,Y) -> (-X,-Y), so we may use the decaf coordinates.
+	X := p.X_decaf_affine()
+	Y := p.Y_decaf_affine()
+	if Y.Sign() < 0 {
+		X.NegEq()
+	}
+	if err := X.PutBytesWithPrefix(dst, hexShortFormBitHeader, common.DefaultEndian); err != nil {
+		panic(fmt.Errorf(ErrorPrefix+"X*Sign(Y) did not fit into the short Banderwagon format. This is not supposed to be possible: %w", err))
+	}
+	return nil
+}
+
+// DecodeFrom sets p to the point encoded in src, which must be in the format output by EncodeTo (or DecafEncode).
+// trustLevel should be one of TrustedInput or UntrustedInput.
+// As opposed to DecafDecode or the deserializers from the pointserializer package, this does not use an io.Reader or intermediate buffers and is meant for hot loops.
+// Note that the square root computation needed to recover Y currently still allocates.
+//
+// On error, p is untouched. Possible errors are (errors wrapping) ErrPrefixMismatch and ErrNonNormalizedDeserialization from the fieldElements package and
+// any error that CurvePointFromXTimesSignY_subgroup may output.
+func (p *Point_xtw_subgroup) DecodeFrom(src *[hexShortFormByteLength]byte, trustLevel IsInputTrusted) error {
+	var xSignY FieldElement
+	if err := xSignY.SetBytesWithPrefix(src, hexShortFormBitHeader, common.DefaultEndian); err != nil {
+		return err
+	}
+	pointAffine, err := CurvePointFromXTimesSignY_subgroup(&xSignY, trustLevel)
+	if err != nil {
+		return err
+	}
+	p.SetFrom(&pointAffine)
+	return nil
+}
+
+// ToHexString returns the (lower-case) hex encoding of the short Banderwagon form of p.
+// The output can be read back with CurvePointFromHexString_subgroup.
+//
+// Possible errors are ErrCannotSerializeNaP and ErrCannotSerializePointAtInfinity from the bandersnatchErrors package.
+func (p *Point_xtw_subgroup) ToHexString() (string, error) {
+	if p.IsNaP() {
+		return "", bandersnatchErrors.ErrCannotSerializeNaP
+	}
+	if p.IsAtInfinity() {
+		return "", bandersnatchErrors.ErrCannotSerializePointAtInfinity // cannot happen for subgroup points, but we keep the check for robustness
+	}
+	encoding := DecafEncode(p)
+	return hex.EncodeToString(encoding[:]), nil
+}
+
+// CurvePointFromHexString_subgroup constructs a point on the prime-order subgroup from a hex string of its short Banderwagon form (as output by ToHexString).
+// trustLevel should be one of TrustedInput or UntrustedInput.
+//
+// It returns an error if the provided input is invalid. In this case, the returned point must not be used.
+// Possible errors are (errors wrapping) ErrInvalidHexString (for odd-length or non-hex input), ErrWrongHexStringLength and
+// any error that the deserialization of field elements or CurvePointFromXTimesSignY_subgroup may output.
+func CurvePointFromHexString_subgroup(hexShortForm string, trustLevel IsInputTrusted) (point Point_xtw_subgroup, err error) {
+	data, errHex := hex.DecodeString(hexShortForm)
+	if errHex != nil {
+		err = fmt.Errorf("%w: %v", ErrInvalidHexString, errHex)
+		return
+	}
+	if len(data) != hexShortFormByteLength {
+		err = fmt.Errorf("%w. The given string encoded %v bytes", ErrWrongHexStringLength, len(data))
+		return
+	}
+	var encoding [hexShortFormByteLength]byte
+	copy(encoding[:], data)
+	return DecafDecode(encoding, trustLevel)
+}
@@ -0,0 +1,206 @@
+package curvePoints
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// encodeHexShortForm_reference computes the hex string of the short Banderwagon form of point directly, independently of ToHexString.
+func encodeHexShortForm_reference(point *Point_xtw_subgroup) string {
+	X, Y := point.XY_affine()
+	if Y.Sign() < 0 {
+		X.NegEq()
+	}
+	var buf bytes.Buffer
+	_, err := X.SerializeWithPrefix(&buf, hexShortFormBitHeader, common.DefaultEndian)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func TestCurvePointFromHexString(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		var P Point_xtw_subgroup
+		if i == 0 {
+			P.SetNeutral()
+		} else {
+			P = MakeRandomPointUnsafe_xtw_subgroup(drng)
+		}
+		s := encodeHexShortForm_reference(&P)
+		for _, trustLevel := range []IsInputTrusted{trustedInput, untrustedInput} {
+			Q, err := CurvePointFromHexString_subgroup(s, trustLevel)
+			if err != nil {
+				t.Fatalf("CurvePointFromHexString_subgroup failed on valid input: %v", err)
+			}
+			if !P.IsEqual(&Q) {
+				t.Fatalf("CurvePointFromHexString_subgroup did not recover the original point")
+			}
+		}
+		Q, err := CurvePointFromHexString_subgroup(strings.ToUpper(s), untrustedInput)
+		if err != nil || !P.IsEqual(&Q) {
+			t.Fatalf("CurvePointFromHexString_subgroup does not accept upper-case hex")
+		}
+	}
+
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	s := encodeHexShortForm_reference(&P)
+
+	for _, invalid := range []string{s[1:], s[:len(s)-1] + "g", "0x" + s[2:]} {
+		_, err := CurvePointFromHexString_subgroup(invalid, untrustedInput)
+		if !errors.Is(err, ErrInvalidHexString) {
+			t.Fatalf("CurvePointFromHexString_subgroup did not report ErrInvalidHexString for invalid hex input %v. Got error %v", invalid, err)
+		}
+	}
+	for _, wrongLength := range []string{"", s[2:], s + "00"} {
+		_, err := CurvePointFromHexString_subgroup(wrongLength, untrustedInput)
+		if !errors.Is(err, ErrWrongHexStringLength) {
+			t.Fatalf("CurvePointFromHexString_subgroup did not report ErrWrongHexStringLength for input of wrong length. Got error %v", err)
+		}
+	}
+	// wrong prefix bit
+	data, _ := hex.DecodeString(s)
+	data[len(data)-1] ^= 0x80
+	_, err := CurvePointFromHexString_subgroup(hex.EncodeToString(data), untrustedInput)
+	if err == nil {
+		t.Fatalf("CurvePointFromHexString_subgroup accepted input with wrong prefix")
+	}
+	// not on the curve / subgroup
+	var failures int
+	for i := 0; i < 20; i++ {
+		var x FieldElement
+		x.SetRandomUnsafe(drng)
+		var buf bytes.Buffer
+		x.SerializeWithPrefix(&buf, hexShortFormBitHeader, common.DefaultEndian)
+		_, err := CurvePointFromHexString_subgroup(hex.EncodeToString(buf.Bytes()), untrustedInput)
+		if err != nil {
+			failures++
+		}
+	}
+	if failures == 0 {
+		t.Fatalf("CurvePointFromHexString_subgroup accepted 20 random field elements")
+	}
+}
+
+func TestToHexString(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 50; i++ {
+		var P Point_xtw_subgroup
+		if i == 0 {
+			P.SetNeutral()
+		} else {
+			P = MakeRandomPointUnsafe_xtw_subgroup(drng)
+			P.rerandomizeRepresentation(drng)
+			if i%2 == 0 {
+				P.flipDecaf()
+			}
+		}
+		s, err := P.ToHexString()
+		if err != nil {
+			t.Fatalf("ToHexString failed: %v", err)
+		}
+		if len(s) != 2*hexShortFormByteLength {
+			t.Fatalf("ToHexString output has wrong length %v", len(s))
+		}
+		if s != encodeHexShortForm_reference(&P) {
+			t.Fatalf("ToHexString does not output the short Banderwagon form")
+		}
+		Q, err := CurvePointFromHexString_subgroup(s, untrustedInput)
+		if err != nil {
+			t.Fatalf("ToHexString output could not be read back: %v", err)
+		}
+		if !P.IsEqual(&Q) {
+			t.Fatalf("ToHexString followed by CurvePointFromHexString_subgroup does not recover the original point")
+		}
+		s2, _ := Q.ToHexString()
+		if s != s2 {
+			t.Fatalf("ToHexString is not unique")
+		}
+	}
+	var NaP Point_xtw_subgroup
+	_, err := NaP.ToHexString()
+	if !errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP) {
+		t.Fatalf("ToHexString did not report ErrCannotSerializeNaP for NaP. Got error %v", err)
+	}
+}
+
+func TestDecafEncode(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Q := P
+		Q.rerandomizeRepresentation(drng) // may flip the decaf representation
+		encoding := DecafEncode(&P)
+		if hex.EncodeToString(encoding[:]) != encodeHexShortForm_reference(&P) {
+			t.Fatalf("DecafEncode does not match the short Banderwagon form")
+		}
+		if DecafEncode(&Q) != encoding {
+			t.Fatalf("DecafEncode depends on the internal representation")
+		}
+		for _, trustLevel := range []IsInputTrusted{trustedInput, untrustedInput} {
+			R, err := DecafDecode(encoding, trustLevel)
+			if err != nil {
+				t.Fatalf("DecafDecode failed: %v", err)
+			}
+			if !R.IsEqual(&P) {
+				t.Fatalf("DecafDecode did not round-trip")
+			}
+		}
+	}
+	// Missing header bit
+	var invalid [32]byte
+	if _, err := DecafDecode(invalid, untrustedInput); err == nil {
+		t.Fatalf("DecafDecode accepted encoding without header bit")
+	}
+	var NaP Point_xtw_subgroup
+	if !testutils.CheckPanic(DecafEncode, &NaP) {
+		t.Fatalf("DecafEncode did not panic on NaP")
+	}
+}
+
+func TestEncodeToDecodeFrom(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		var arr [32]byte
+		err := P.EncodeTo(&arr)
+		testutils.FatalUnless(t, err == nil, "EncodeTo failed: %v", err)
+		testutils.FatalUnless(t, arr == DecafEncode(&P), "EncodeTo differs from DecafEncode")
+		var Q Point_xtw_subgroup
+		err = Q.DecodeFrom(&arr, common.UntrustedInput)
+		testutils.FatalUnless(t, err == nil, "DecodeFrom failed: %v", err)
+		testutils.FatalUnless(t, Q.IsEqual(&P), "DecodeFrom did not round-trip")
+
+		// wrong header: p must be untouched
+		QCopy := Q
+		arr[hexShortFormByteLength-1] &= 0x7F
+		err = Q.DecodeFrom(&arr, common.UntrustedInput)
+		testutils.FatalUnless(t, err != nil, "DecodeFrom did not detect wrong header")
+		testutils.FatalUnless(t, Q == QCopy, "DecodeFrom modified point on error")
+	}
+
+	var nap Point_xtw_subgroup
+	var arr [32]byte
+	err := nap.EncodeTo(&arr)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "EncodeTo did not reject NaP")
+	testutils.FatalUnless(t, arr == [32]byte{}, "EncodeTo wrote on error")
+
+	// EncodeTo performs no heap allocations
+	P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = P.EncodeTo(&arr)
+	})
+	testutils.FatalUnless(t, allocs == 0, "EncodeTo allocated %v times", allocs)
+}
@@ -0,0 +1,63 @@
+package curvePoints
+
+import "sync/atomic"
+
+// This file contains the hook that allows users to replace the modular square root algorithm used when recovering curve points from a single coordinate.
+//
+// By default, we use FieldElement.SquareRoot, which makes no guarantees about which of the two roots is returned (the generic algorithms may be randomized).
+// Users may install a deterministic (e.g. canonical root) or faster implementation via SetSquareRootImpl.
+
+// SquareRootImpl is the function type for square root implementations that can be installed with SetSquareRootImpl.
+//
+// A call ok := impl(dst, src) must behave like ok := dst.SquareRoot(src):
+// If src is a square, it sets dst to some field element with dst*dst == src and returns true.
+// If src is not a square, it returns false; dst must not be used in this case.
+// Implementations must allow dst == src and must be safe for concurrent use.
+type SquareRootImpl = func(dst, src *FieldElement) bool
+
+// defaultSquareRoot is the default square root implementation, using FieldElement.SquareRoot.
+func defaultSquareRoot(dst, src *FieldElement) bool {
+	return dst.SquareRoot(src)
+}
+
+// squareRootImplHolder wraps a SquareRootImpl, since atomic.Value requires all stored values to have the same concrete type.
+type squareRootImplHolder struct {
+	impl SquareRootImpl
+}
+
+// currently installed square root implementation, stored as a squareRootImplHolder. If nothing was stored yet, defaultSquareRoot is used.
+// Since squareRoot is called on hot paths such as deserialization, we use an atomic.Value rather than a mutex, so reading is lock-free.
+// Not storing the default in an init function ensures squareRoot also works during package-level variable initialization.
+var current_square_root_impl atomic.Value
+
+// SetSquareRootImpl atomically exchanges the square root implementation used for recovering points from (functions of) a single coordinate, e.g. during deserialization, and returns the previously installed one.
+// Passing nil restores the default implementation.
+//
+// The installed implementation must satisfy the contract documented at SquareRootImpl; we do not check this.
+// Note that an implementation that always returns the same root for a given input makes point recovery deterministic (at the level of coordinates, not just of points).
+func SetSquareRootImpl(impl SquareRootImpl) (old_impl SquareRootImpl) {
+	if impl == nil {
+		impl = defaultSquareRoot
+	}
+	old, _ := current_square_root_impl.Swap(squareRootImplHolder{impl: impl}).(squareRootImplHolder)
+	old_impl = old.impl
+	if old_impl == nil {
+		old_impl = defaultSquareRoot
+	}
+	return
+}
+
+// GetSquareRootImpl gets the currently installed square root implementation.
+func GetSquareRootImpl() SquareRootImpl {
+	holder, ok := current_square_root_impl.Load().(squareRootImplHolder)
+	if !ok {
+		return defaultSquareRoot
+	}
+	return holder.impl
+}
+
+// squareRoot computes dst as a square root of src, using the currently installed square root implementation. The return value tells whether src was a square.
+func squareRoot(dst, src *FieldElement) bool {
+	f := GetSquareRootImpl()
+	return f(dst, src)
+}
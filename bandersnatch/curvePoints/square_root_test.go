@@ -0,0 +1,75 @@
+package curvePoints
+
+import (
+	"math/rand"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+)
+
+func TestSetSquareRootImpl(t *testing.T) {
+	var calls int64
+	// canonicalRoot always returns the root with non-negative sign.
+	var canonicalRoot SquareRootImpl = func(dst, src *FieldElement) bool {
+		atomic.AddInt64(&calls, 1)
+		var root FieldElement
+		if !root.SquareRoot(src) {
+			return false
+		}
+		if root.Sign() < 0 {
+			root.NegEq()
+		}
+		*dst = root
+		return true
+	}
+	old := SetSquareRootImpl(canonicalRoot)
+	defer SetSquareRootImpl(old)
+	if reflect.ValueOf(GetSquareRootImpl()).Pointer() != reflect.ValueOf(canonicalRoot).Pointer() {
+		t.Fatalf("GetSquareRootImpl does not return installed implementation")
+	}
+
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		P := MakeRandomPointUnsafe_xtw_subgroup(drng)
+		x := P.X_decaf_affine()
+		y1, err1 := recoverYFromXAffine(&x, true)
+		y2, err2 := recoverYFromXAffine(&x, true)
+		if err1 != nil || err2 != nil {
+			t.Fatalf("recoverYFromXAffine failed unexpectedly: %v %v", err1, err2)
+		}
+		if !y1.IsEqual(&y2) || y1.Sign() < 0 {
+			t.Fatalf("recoverYFromXAffine did not use canonical square root")
+		}
+		y := P.Y_decaf_affine()
+		x1, err := recoverXFromYAffine(&y)
+		if err != nil {
+			t.Fatalf("recoverXFromYAffine failed unexpectedly: %v", err)
+		}
+		if x1.Sign() < 0 {
+			t.Fatalf("recoverXFromYAffine did not use canonical square root")
+		}
+
+		// Deserialization uses the installed implementation and gives identical coordinates every time.
+		encoding := DecafEncode(&P)
+		callsBefore := atomic.LoadInt64(&calls)
+		Q1, errQ1 := DecafDecode(encoding, common.UntrustedInput)
+		Q2, errQ2 := DecafDecode(encoding, common.UntrustedInput)
+		if errQ1 != nil || errQ2 != nil {
+			t.Fatalf("DecafDecode failed unexpectedly: %v %v", errQ1, errQ2)
+		}
+		if atomic.LoadInt64(&calls) == callsBefore {
+			t.Fatalf("Deserialization did not use installed square root implementation")
+		}
+		if !Q1.IsEqual(&P) || Q1 != Q2 {
+			t.Fatalf("Deserialization with canonical square root is not deterministic")
+		}
+	}
+
+	// nil restores the default
+	SetSquareRootImpl(nil)
+	if reflect.ValueOf(GetSquareRootImpl()).Pointer() != reflect.ValueOf(defaultSquareRoot).Pointer() {
+		t.Fatalf("SetSquareRootImpl(nil) did not restore default implementation")
+	}
+}
@@ -0,0 +1,21 @@
+package curvePoints
+
+// CurvePointFromFieldElementTryIncrement maps a field element to a point on the Bandersnatch curve (not necessarily in the subgroup) using the classic try-and-increment approach:
+// Starting from x, we repeatedly add 1 until we find a valid x coordinate (as determined by IsValidXCoordinate).
+// The y coordinate of the result is chosen to have positive sign (as defined by FieldElement's Sign method), so the output is deterministic for a given x.
+//
+// Since about half of all field elements are valid x coordinates, the expected number of iterations is 2.
+//
+// NOTE: This is not constant-time. Do not use it on secret inputs. For hashing, HashToSubgroup is usually preferable;
+// this function is intended for non-secret use cases where simplicity is preferred over a standardized map.
+func CurvePointFromFieldElementTryIncrement(x *FieldElement) Point_axtw_full {
+	var candidate FieldElement = *x
+	for !IsValidXCoordinate(&candidate) {
+		candidate.AddEq(&fieldElementOne)
+	}
+	point, err := CurvePointFromXAndSignY_full(&candidate, +1, trustedInput)
+	if err != nil {
+		panic(err) // cannot happen; CurvePointFromXAndSignY_full panics itself on errors for trusted input.
+	}
+	return point
+}
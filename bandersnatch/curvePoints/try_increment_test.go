@@ -0,0 +1,43 @@
+package curvePoints
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestIsValidXCoordinate(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		if P.IsAtInfinity() {
+			continue
+		}
+		x := P.X_decaf_affine()
+		testutils.FatalUnless(t, IsValidXCoordinate(&x), "x coordinate of curve point not recognized as valid")
+
+		var fe FieldElement
+		fe.SetRandomUnsafe(drng)
+		_, err := recoverYFromXAffine(&fe, false)
+		testutils.FatalUnless(t, IsValidXCoordinate(&fe) == (err == nil), "IsValidXCoordinate differs from recoverYFromXAffine")
+	}
+}
+
+func TestCurvePointFromFieldElementTryIncrement(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		var x FieldElement
+		x.SetRandomUnsafe(drng)
+		xCopy := x
+		P := CurvePointFromFieldElementTryIncrement(&x)
+		testutils.FatalUnless(t, x.IsEqual(&xCopy), "CurvePointFromFieldElementTryIncrement modified its input")
+		testutils.FatalUnless(t, P.Validate(), "CurvePointFromFieldElementTryIncrement returned invalid point")
+		testutils.FatalUnless(t, P.y.Sign() > 0, "CurvePointFromFieldElementTryIncrement returned point with negative y")
+		Q := CurvePointFromFieldElementTryIncrement(&x)
+		testutils.FatalUnless(t, P.IsEqual(&Q), "CurvePointFromFieldElementTryIncrement is not deterministic")
+		if IsValidXCoordinate(&x) {
+			testutils.FatalUnless(t, P.x.IsEqual(&x), "CurvePointFromFieldElementTryIncrement did not use valid x directly")
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package curvePoints
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This file contains the conversion between the twisted Edwards form of the Bandersnatch curve and an isomorphic short Weierstrass form.
+// This is meant for interoperability with libraries that only support short Weierstrass curves.
+//
+// We go via the Montgomery form K*t^2 = s^3 + J*s^2 + s (see hash_to_curve.go), using the maps
+//   (x,y) -> (s,t) = ((1+y)/(1-y), s/x)                    twisted Edwards -> Montgomery
+//   (s,t) -> (u,v) = (s/K + J/(3K), t/K)                   Montgomery -> short Weierstrass
+// The short Weierstrass curve is v^2 = u^3 + A_W * u + B_W with
+//   A_W = (3 - J^2) / (3K^2)
+//   B_W = (2J^3 - 9J) / (27K^3)
+// Note that this differs from the short Weierstrass form v^2 = u^3 - 3763200000*u - 78675968000000 given in the Bandersnatch paper by a scaling (u,v) -> (lambda*u, lambda^(3/2)*v).
+// Libraries using that form need to apply this scaling, which requires a choice of square root of lambda.
+//
+// The exceptional points of these maps are the 2-torsion points:
+// The neutral element maps to the point at infinity of the Weierstrass curve, the affine point of order two maps to (J/(3K), 0)
+// and the points at infinity E1, E2 of the twisted Edwards curve map to the remaining two Weierstrass points with v == 0.
+// With these conventions, the map is a group isomorphism.
+
+// Parameters A_W, B_W of the short Weierstrass form v^2 = u^3 + A_W * u + B_W of the Bandersnatch curve that ToWeierstrass maps to.
+var (
+	WeierstrassParameterA_fe FieldElement // A_W
+	WeierstrassParameterB_fe FieldElement // B_W
+	weierstrassShift_fe      FieldElement // J/(3K), i.e. the u-coordinate of the image of s == 0
+)
+
+// ErrNotOnWeierstrassCurve is returned (wrapped) by CurvePointFromWeierstrass_full if the given coordinates do not satisfy the short Weierstrass equation.
+var ErrNotOnWeierstrassCurve = errors.New(ErrorPrefix + "given coordinates do not define a point on the short Weierstrass form of the Bandersnatch curve")
+
+func init() {
+	var three, JSquared, temp FieldElement
+	three.SetUInt64(3)
+
+	weierstrassShift_fe.Mul(&three, &montgomeryK_fe)
+	weierstrassShift_fe.Divide(&montgomeryJ_fe, &weierstrassShift_fe)
+
+	// A_W = (3 - J^2) / (3K^2)
+	JSquared.Square(&montgomeryJ_fe)
+	WeierstrassParameterA_fe.Sub(&three, &JSquared)
+	temp.Square(&montgomeryK_fe)
+	temp.MulEq(&three)
+	WeierstrassParameterA_fe.DivideEq(&temp)
+
+	// B_W = (2J^3 - 9J) / (27K^3) = J(2J^2 - 9) / (27K^3)
+	var nine FieldElement
+	nine.SetUInt64(9)
+	WeierstrassParameterB_fe.Double(&JSquared)
+	WeierstrassParameterB_fe.SubEq(&nine)
+	WeierstrassParameterB_fe.MulEq(&montgomeryJ_fe)
+	temp.Square(&montgomeryK_fe)
+	temp.MulEq(&montgomeryK_fe)
+	temp.MulEq(&nine)
+	temp.MulEq(&three)
+	WeierstrassParameterB_fe.DivideEq(&temp)
+}
+
+// ToWeierstrass returns the coordinates of the image of p on the short Weierstrass form v^2 = u^3 + A_W*u + B_W of the Bandersnatch curve,
+// with A_W, B_W given by WeierstrassParameterA_fe, WeierstrassParameterB_fe.
+//
+// If infinity is true, the image is the point at infinity (this happens iff p is the neutral element) and x, y are zero.
+// ToWeierstrass panics if p is a NaP.
+func (p *Point_xtw_full) ToWeierstrass() (x, y FieldElement, infinity bool) {
+	if p.IsNaP() {
+		napEncountered("called ToWeierstrass on a NaP", false, p)
+		panic(ErrorPrefix + "called ToWeierstrass on a NaP")
+	}
+	if p.x.IsZero() {
+		// p is either the neutral element or the affine point of order two. Note that X != 0 for points at infinity.
+		if p.y.IsEqual(&p.z) {
+			infinity = true
+			return
+		}
+		x = weierstrassShift_fe
+		return
+	}
+
+	// We have s = (1+y)/(1-y) = (x + xy) / (x - xy) = (X+T)/(X-T) and t = s/x = (X+T)Z / ((X-T)X) in terms of projective twisted Edwards coordinates.
+	// The latter form is also valid for the points at infinity. Note that X-T != 0, since X==T would imply x==0 or y==1, which in turn implies x==0.
+	var XPlusT, denom FieldElement
+	XPlusT.Add(&p.x, &p.t)
+	denom.Sub(&p.x, &p.t)
+	denom.MulEq(&p.x)
+	denom.MulEq(&montgomeryK_fe)
+	denom.InvEq() // 1/((X-T) X K)
+
+	x.Mul(&XPlusT, &p.x)
+	x.MulEq(&denom) // s/K
+	x.AddEq(&weierstrassShift_fe)
+	y.Mul(&XPlusT, &p.z)
+	y.MulEq(&denom) // t/K
+	return
+}
+
+// CurvePointFromWeierstrass_full constructs a curve point from the coordinates of its image on the short Weierstrass form, as output by ToWeierstrass.
+// If infinity is true, x and y are ignored and the result is the neutral element.
+// trustLevel should be one of TrustedInput or UntrustedInput.
+//
+// It returns an error wrapping ErrNotOnWeierstrassCurve if x,y do not satisfy the short Weierstrass equation. In this case, the returned point must not be used.
+// If trustLevel is TrustedInput, you *MUST* call this only with valid input; we are free to skip this check.
+func CurvePointFromWeierstrass_full(x, y *FieldElement, infinity bool, trustLevel IsInputTrusted) (point Point_xtw_full, err error) {
+	if infinity {
+		point.SetNeutral()
+		return
+	}
+	if !trustLevel.Bool() {
+		var lhs, rhs FieldElement
+		lhs.Square(y)
+		rhs.Square(x)
+		rhs.AddEq(&WeierstrassParameterA_fe)
+		rhs.MulEq(x)
+		rhs.AddEq(&WeierstrassParameterB_fe)
+		if !lhs.IsEqual(&rhs) {
+			err = fmt.Errorf("%w. The given coordinates were x = %v, y = %v", ErrNotOnWeierstrassCurve, *x, *y)
+			return
+		}
+	}
+
+	// Montgomery coordinates s = K(x - J/(3K)), t = K*y
+	var s, t FieldElement
+	s.Sub(x, &weierstrassShift_fe)
+	s.MulEq(&montgomeryK_fe)
+	t.Mul(y, &montgomeryK_fe)
+	if s.IsZero() {
+		// Note that s == 0 implies t == 0 on the curve.
+		point = AffineOrderTwoPoint_xtw
+		return
+	}
+
+	// rational map (s,t) -> (x,y) = (s/t, (s-1)/(s+1)) in projective coordinates, i.e. X = s(s+1), Y = t(s-1), Z = t(s+1), T = s(s-1)
+	// For t == 0, this gives the points at infinity. Note that s == -1 is impossible on the curve.
+	var sPlusOne, sMinusOne FieldElement
+	sPlusOne.Add(&s, &fieldElementOne)
+	sMinusOne.Sub(&s, &fieldElementOne)
+	point.x.Mul(&s, &sPlusOne)
+	point.y.Mul(&t, &sMinusOne)
+	point.z.Mul(&t, &sPlusOne)
+	point.t.Mul(&s, &sMinusOne)
+	return
+}
@@ -0,0 +1,94 @@
+package curvePoints
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// The paper's short Weierstrass form v^2 = u^3 - 3763200000*u - 78675968000000 must be related to ours by a scaling u -> lambda*u, v -> lambda^(3/2) v,
+// i.e. A_W = lambda^2 * A_paper and B_W = lambda^3 * B_paper for some square lambda.
+func TestWeierstrassParameters(t *testing.T) {
+	paperA := fieldElements.InitFieldElementFromString("-3763200000")
+	paperB := fieldElements.InitFieldElementFromString("-78675968000000")
+	var ratioA, ratioB, lambda, check FieldElement
+	ratioA.Divide(&WeierstrassParameterA_fe, &paperA) // lambda^2
+	ratioB.Divide(&WeierstrassParameterB_fe, &paperB) // lambda^3
+	lambda.Divide(&ratioB, &ratioA)
+	check.Square(&lambda)
+	testutils.FatalUnless(t, check.IsEqual(&ratioA), "Weierstrass parameters do not define the same curve as the paper's parameters")
+	testutils.FatalUnless(t, lambda.Jacobi() == 1, "Weierstrass form is a quadratic twist of the paper's form")
+}
+
+// isOnWeierstrassCurve checks whether (x,y) satisfies the short Weierstrass equation.
+func isOnWeierstrassCurve(x, y *FieldElement) bool {
+	var lhs, rhs FieldElement
+	lhs.Square(y)
+	rhs.Square(x)
+	rhs.AddEq(&WeierstrassParameterA_fe)
+	rhs.MulEq(x)
+	rhs.AddEq(&WeierstrassParameterB_fe)
+	return lhs.IsEqual(&rhs)
+}
+
+func TestWeierstrassRoundTrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	points := []Point_xtw_full{NeutralElement_xtw_full, AffineOrderTwoPoint_xtw, InfinitePoint1_xtw, InfinitePoint2_xtw}
+	for i := 0; i < 50; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		P.rerandomizeRepresentation(drng)
+		points = append(points, P)
+	}
+	images := make(map[[2]FieldElement]bool)
+	for i := range points {
+		P := points[i]
+		x, y, infinity := P.ToWeierstrass()
+		testutils.FatalUnless(t, infinity == P.IsNeutralElement(), "ToWeierstrass reports point at infinity for wrong point")
+		if !infinity {
+			testutils.FatalUnless(t, isOnWeierstrassCurve(&x, &y), "ToWeierstrass gives point not on the curve")
+			x.Normalize()
+			y.Normalize()
+			testutils.FatalUnless(t, !images[[2]FieldElement{x, y}], "ToWeierstrass is not injective")
+			images[[2]FieldElement{x, y}] = true
+		}
+		for _, trustLevel := range []IsInputTrusted{trustedInput, untrustedInput} {
+			Q, err := CurvePointFromWeierstrass_full(&x, &y, infinity, trustLevel)
+			testutils.FatalUnless(t, err == nil, "CurvePointFromWeierstrass_full failed: %v", err)
+			testutils.FatalUnless(t, Q.Validate(), "CurvePointFromWeierstrass_full gives invalid point")
+			testutils.FatalUnless(t, Q.IsEqual(&P), "Weierstrass conversion does not round-trip for point %v", i)
+		}
+	}
+
+	// The map is a group homomorphism: check that the images of P, Q, P+Q lie on a line.
+	for i := 0; i < 10; i++ {
+		P := MakeRandomPointUnsafe_xtw_full(drng)
+		Q := MakeRandomPointUnsafe_xtw_full(drng)
+		var R Point_xtw_full
+		R.Add(&P, &Q)
+		R.NegEq()
+		xP, yP, _ := P.ToWeierstrass()
+		xQ, yQ, _ := Q.ToWeierstrass()
+		xR, yR, _ := R.ToWeierstrass()
+		// (yQ - yP)(xR - xP) == (yR - yP)(xQ - xP)
+		var l1, l2, temp FieldElement
+		l1.Sub(&yQ, &yP)
+		temp.Sub(&xR, &xP)
+		l1.MulEq(&temp)
+		l2.Sub(&yR, &yP)
+		temp.Sub(&xQ, &xP)
+		l2.MulEq(&temp)
+		testutils.FatalUnless(t, l1.IsEqual(&l2), "ToWeierstrass is not a group homomorphism")
+	}
+
+	var x, y FieldElement
+	x.SetOne()
+	y.SetOne()
+	_, err := CurvePointFromWeierstrass_full(&x, &y, false, untrustedInput)
+	testutils.FatalUnless(t, errors.Is(err, ErrNotOnWeierstrassCurve), "CurvePointFromWeierstrass_full did not detect invalid point")
+
+	var NaP Point_xtw_full
+	testutils.FatalUnless(t, testutils.CheckPanic(NaP.ToWeierstrass), "ToWeierstrass did not panic on NaP")
+}
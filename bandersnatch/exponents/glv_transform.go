@@ -105,6 +105,18 @@ var (
 	glvDecompositionMax_Int = common.InitIntFromString(glvDecompositionMax_string)
 )
 
+// GLVBasis returns the reduced basis of the lattice L = {(a,b) | a + b * EndomorphismEigenvalue == 0 mod GroupOrder} that is used by GLV_representation.
+// v1 and v2 are the two basis vectors; both have norm roughly sqrt(GroupOrder) and their determinant is GroupOrder.
+// beta holds the rounding constants: GLV_representation computes the (scaled) coefficients of (t,0) wrt. (v1,v2) as t*beta[0] resp. t*beta[1] mod GroupOrder.
+//
+// The returned big.Ints are fresh copies and may be freely modified by the caller. This function is mainly intended for auditing and tests.
+func GLVBasis() (v1, v2 [2]*big.Int, beta [2]*big.Int) {
+	v1 = [2]*big.Int{new(big.Int).Set(lBasis_11_Int), new(big.Int).Set(lBasis_12_Int)}
+	v2 = [2]*big.Int{new(big.Int).Set(lBasis_21_Int), new(big.Int).Set(lBasis_22_Int)}
+	beta = [2]*big.Int{new(big.Int).Set(lBasis_22_Int), new(big.Int).Neg(lBasis_12_Int)}
+	return
+}
+
 // infty_norm computes the max of the absolute values of x and y.
 func infty_norm(x, y *big.Int) (result *big.Int) {
 	result = big.NewInt(0)
@@ -236,6 +248,16 @@ func GLV_representation(exponent *Exponent) (ret glvExponents) {
 	return
 }
 
+// GLVDecompose is a variant of GLV_representation that works with big.Ints rather than Exponents.
+// It outputs u,v such that t == u + v * EndomorphismEigenvalue mod GroupOrder, so t*P = u*P + v*Psi(P) for any P in the subgroup.
+// t may be any (possibly negative) integer and is not modified. We guarantee that |u|, |v| both have at most 126 bit.
+func GLVDecompose(t *big.Int) (u, v *big.Int) {
+	var exponent Exponent
+	exponent.SetBigInt(t) // reduces modulo CurveExponent, which is a multiple of GroupOrder.
+	glv := GLV_representation(&exponent)
+	return glv.U.ToBigInt(), glv.V.ToBigInt()
+}
+
 type decompositionCoefficient struct {
 	position uint
 	coeff    uint
@@ -204,3 +204,66 @@ func TestDecomposition(t *testing.T) {
 	}
 
 }
+
+// TestGLVBasis checks that the basis returned by GLVBasis consists of short lattice vectors and that the rounding constants match the cofactor matrix.
+func TestGLVBasis(t *testing.T) {
+	v1, v2, beta := GLVBasis()
+	for i, vec := range [][2]*big.Int{v1, v2} {
+		var check *big.Int = new(big.Int).Mul(vec[1], EndomorphismEigenvalue_Int)
+		check.Add(check, vec[0])
+		check.Mod(check, GroupOrder_Int)
+		if check.Sign() != 0 {
+			t.Fatalf("Basis vector %v returned by GLVBasis is not in the lattice", i+1)
+		}
+		// GroupOrder has 253 bits, so short vectors should have about 127 bits.
+		if vec[0].BitLen() > 128 || vec[1].BitLen() > 128 {
+			t.Fatalf("Basis vector %v returned by GLVBasis is not short", i+1)
+		}
+	}
+	var det *big.Int = new(big.Int).Mul(v1[0], v2[1])
+	det.Sub(det, new(big.Int).Mul(v1[1], v2[0]))
+	if det.CmpAbs(GroupOrder_Int) != 0 {
+		t.Fatal("Determinant of basis returned by GLVBasis is not +/-GroupOrder")
+	}
+	// (beta[0], beta[1]) is the first column of the cofactor matrix, so beta[0]*v1 + beta[1]*v2 == (det, 0)
+	var first *big.Int = new(big.Int).Mul(beta[0], v1[0])
+	first.Add(first, new(big.Int).Mul(beta[1], v2[0]))
+	var second *big.Int = new(big.Int).Mul(beta[0], v1[1])
+	second.Add(second, new(big.Int).Mul(beta[1], v2[1]))
+	if first.Cmp(det) != 0 || second.Sign() != 0 {
+		t.Fatal("Rounding constants returned by GLVBasis do not match the basis")
+	}
+
+	// modifying the returned values must not affect subsequent calls
+	v1[0].SetInt64(0)
+	beta[1].SetInt64(0)
+	v1Again, _, betaAgain := GLVBasis()
+	if v1Again[0].Cmp(lBasis_11_Int) != 0 || betaAgain[1].Sign() == 0 {
+		t.Fatal("GLVBasis returns references to internal values")
+	}
+}
+
+func TestGLVDecompose(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(141153))
+	var bigrange *big.Int = new(big.Int).Lsh(CurveOrder_Int, 1)
+	var check *big.Int = big.NewInt(0)
+	for i := 0; i < 1000; i++ {
+		var scalar *big.Int = new(big.Int).Rand(drng, bigrange)
+		scalar.Sub(scalar, CurveOrder_Int) // range -CurveOrder .. CurveOrder
+		var scalarCopy *big.Int = new(big.Int).Set(scalar)
+		u, v := GLVDecompose(scalar)
+		if scalar.Cmp(scalarCopy) != 0 {
+			t.Fatal("GLVDecompose modified its input")
+		}
+		if u.BitLen() > 126 || v.BitLen() > 126 {
+			t.Fatal("GLVDecompose output is too large")
+		}
+		check.Mul(v, EndomorphismEigenvalue_Int)
+		check.Add(check, u)
+		check.Sub(check, scalar)
+		check.Mod(check, GroupOrder_Int)
+		if check.Sign() != 0 {
+			t.Fatal("GLVDecompose does not output u,v with u + v*EndomorphismEigenvalue == t")
+		}
+	}
+}
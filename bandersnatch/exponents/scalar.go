@@ -0,0 +1,210 @@
+package exponents
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/utils"
+)
+
+// This file contains the Scalar type, which holds elements of Z/p253, where p253 == GroupOrder is the order of the prime-order subgroup.
+// As opposed to Exponent (which works modulo 2*p253 to accommodate points outside the subgroup), this is meant to be used by users of the library
+// that work in the prime-order subgroup, e.g. for signatures or commitments, without having to reduce *big.Int's modulo GroupOrder_Int themselves.
+//
+// Scalars are always stored in canonical (i.e. fully reduced) form, so two Scalars are equal iff they compare equal with ==.
+// As for Exponent, we use a plain (non-Montgomery) representation; multiplication and inversion go through big.Int and are not particularly fast.
+//
+// The byte representation of Scalars (used by Bytes, SetBytes and SetBytesCanonical) is little endian, matching common.DefaultEndian for field elements.
+
+// ScalarByteLength is the length in bytes of the output of Bytes, which is also the input length required by SetBytesCanonical.
+const ScalarByteLength = 32
+
+var (
+	ErrScalarWrongLength    = fmt.Errorf("bandersnatch / exponents: encoding of scalar does not have exactly %v bytes", ScalarByteLength)
+	ErrScalarNonCanonical   = errors.New("bandersnatch / exponents: encoding of scalar is not fully reduced modulo GroupOrder")
+	ErrScalarDivisionByZero = errors.New("bandersnatch / exponents: trying to invert the zero scalar")
+)
+
+// Scalar stores an element of Z/GroupOrder, i.e. an exponent for points in the prime-order subgroup.
+//
+// The zero value of Scalar is a valid scalar with value 0.
+type Scalar struct {
+	value [4]uint64 // low-endian, between 0 and GroupOrder-1
+}
+
+// isReduced checks whether the given value is in the range 0 <= . < GroupOrder.
+func (z *Scalar) isReduced() bool {
+	_, borrow := bits.Sub64(z.value[0], groupOrder_0, 0)
+	_, borrow = bits.Sub64(z.value[1], groupOrder_1, borrow)
+	_, borrow = bits.Sub64(z.value[2], groupOrder_2, borrow)
+	_, borrow = bits.Sub64(z.value[3], groupOrder_3, borrow)
+	return borrow != 0
+}
+
+// reduce_once subtracts GroupOrder from z, provided the value stored is >= GroupOrder.
+func (z *Scalar) reduce_once() {
+	if !z.isReduced() {
+		var borrow uint64
+		z.value[0], borrow = bits.Sub64(z.value[0], groupOrder_0, 0)
+		z.value[1], borrow = bits.Sub64(z.value[1], groupOrder_1, borrow)
+		z.value[2], borrow = bits.Sub64(z.value[2], groupOrder_2, borrow)
+		z.value[3], _ = bits.Sub64(z.value[3], groupOrder_3, borrow)
+	}
+}
+
+// SetZero sets the scalar to 0.
+func (z *Scalar) SetZero() {
+	z.value = [4]uint64{}
+}
+
+// SetOne sets the scalar to 1.
+func (z *Scalar) SetOne() {
+	z.value = [4]uint64{1, 0, 0, 0}
+}
+
+// IsZero checks whether the scalar is 0.
+func (z *Scalar) IsZero() bool {
+	return z.value == [4]uint64{}
+}
+
+// IsEqual checks whether the two scalars are equal. This is equivalent to *z == *x.
+func (z *Scalar) IsEqual(x *Scalar) bool {
+	return z.value == x.value
+}
+
+// SetBigInt sets z to the value given by x, reduced modulo GroupOrder. x may be negative or larger than GroupOrder.
+func (z *Scalar) SetBigInt(x *big.Int) {
+	var xReduced *big.Int = big.NewInt(0)
+	xReduced.Mod(x, GroupOrder_Int) // is in 0 <= . < GroupOrder_Int, even if input is negative
+	z.value = utils.BigIntToUIntArray(xReduced)
+}
+
+// ToBigInt converts the scalar into a *big.Int in the range 0 <= . < GroupOrder.
+func (z *Scalar) ToBigInt() *big.Int {
+	return utils.UIntarrayToInt(&z.value)
+}
+
+// SetBytes sets z to the value given by the little-endian byte slice b, reduced modulo GroupOrder. b may have any length.
+//
+// Use SetBytesCanonical for strict decoding of the output of Bytes.
+func (z *Scalar) SetBytes(b []byte) {
+	bigEndian := make([]byte, len(b))
+	for i := range b {
+		bigEndian[len(b)-1-i] = b[i]
+	}
+	z.SetBigInt(new(big.Int).SetBytes(bigEndian))
+}
+
+// SetBytesCanonical sets z to the value given by the little-endian byte slice b, which must be the output of Bytes.
+// As opposed to SetBytes, we require b to have length exactly ScalarByteLength and to encode a value in 0 <= . < GroupOrder.
+//
+// On error, z is untouched. Possible errors are ErrScalarWrongLength and ErrScalarNonCanonical.
+func (z *Scalar) SetBytesCanonical(b []byte) error {
+	if len(b) != ScalarByteLength {
+		return fmt.Errorf("%w. The given input has length %v", ErrScalarWrongLength, len(b))
+	}
+	var candidate Scalar
+	for i := range candidate.value {
+		candidate.value[i] = binary.LittleEndian.Uint64(b[8*i : 8*i+8])
+	}
+	if !candidate.isReduced() {
+		return ErrScalarNonCanonical
+	}
+	*z = candidate
+	return nil
+}
+
+// Bytes returns the canonical little-endian encoding of z. This can be read back with SetBytesCanonical.
+func (z *Scalar) Bytes() (ret [ScalarByteLength]byte) {
+	for i := range z.value {
+		binary.LittleEndian.PutUint64(ret[8*i:8*i+8], z.value[i])
+	}
+	return
+}
+
+// SetRandom sets z to a uniformly random scalar, using rnd as source of randomness.
+// For anything security-relevant, rnd should be a cryptographically secure source such as crypto/rand.Reader.
+//
+// The only possible errors are errors from reading from rnd. In this case, z is untouched.
+func (z *Scalar) SetRandom(rnd io.Reader) error {
+	r, err := rand.Int(rnd, GroupOrder_Int)
+	if err != nil {
+		return err
+	}
+	z.value = utils.BigIntToUIntArray(r)
+	return nil
+}
+
+// Add performs addition of scalars.
+//
+// Use z.Add(&x, &y) to compute z = x + y (modulo GroupOrder)
+func (z *Scalar) Add(x, y *Scalar) {
+	// Since GroupOrder < 2^253, the sum cannot overflow 256 bits and subtracting GroupOrder once suffices.
+	var carry uint64
+	z.value[0], carry = bits.Add64(x.value[0], y.value[0], 0)
+	z.value[1], carry = bits.Add64(x.value[1], y.value[1], carry)
+	z.value[2], carry = bits.Add64(x.value[2], y.value[2], carry)
+	z.value[3], _ = bits.Add64(x.value[3], y.value[3], carry)
+	z.reduce_once()
+}
+
+// Sub performs subtraction of scalars.
+//
+// Use z.Sub(&x, &y) to compute z = x - y (modulo GroupOrder)
+func (z *Scalar) Sub(x, y *Scalar) {
+	var borrow uint64
+	z.value[0], borrow = bits.Sub64(x.value[0], y.value[0], 0)
+	z.value[1], borrow = bits.Sub64(x.value[1], y.value[1], borrow)
+	z.value[2], borrow = bits.Sub64(x.value[2], y.value[2], borrow)
+	z.value[3], borrow = bits.Sub64(x.value[3], y.value[3], borrow)
+	if borrow != 0 {
+		z.value[0], borrow = bits.Add64(z.value[0], groupOrder_0, 0)
+		z.value[1], borrow = bits.Add64(z.value[1], groupOrder_1, borrow)
+		z.value[2], borrow = bits.Add64(z.value[2], groupOrder_2, borrow)
+		z.value[3], _ = bits.Add64(z.value[3], groupOrder_3, borrow)
+	}
+}
+
+// Neg performs negation of scalars.
+//
+// Use z.Neg(&x) to compute z = -x (modulo GroupOrder)
+func (z *Scalar) Neg(x *Scalar) {
+	var zero Scalar
+	z.Sub(&zero, x)
+}
+
+// Mul performs multiplication of scalars.
+//
+// Use z.Mul(&x, &y) to compute z = x * y (modulo GroupOrder)
+func (z *Scalar) Mul(x, y *Scalar) {
+	xInt := x.ToBigInt()
+	yInt := y.ToBigInt()
+	xInt.Mul(xInt, yInt)
+	z.SetBigInt(xInt)
+}
+
+// Inv computes the multiplicative inverse of a scalar.
+//
+// Use z.Inv(&x) to compute z = 1/x (modulo GroupOrder). We panic with ErrScalarDivisionByZero if x is zero.
+func (z *Scalar) Inv(x *Scalar) {
+	xInt := x.ToBigInt()
+	if xInt.ModInverse(xInt, GroupOrder_Int) == nil {
+		panic(ErrScalarDivisionByZero)
+	}
+	z.value = utils.BigIntToUIntArray(xInt)
+}
+
+// String is provided to satisfy the fmt.Stringer interface. Note that this is defined on value receivers for convenience.
+func (z Scalar) String() string {
+	return z.ToBigInt().String()
+}
+
+// Format is provided to satisfy the fmt.Formatter interface. Note that this is defined on value receivers for convenience.
+func (z Scalar) Format(s fmt.State, ch rune) {
+	z.ToBigInt().Format(s, ch)
+}
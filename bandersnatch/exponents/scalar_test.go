@@ -0,0 +1,148 @@
+package exponents
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestScalarArithmetic(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1000))
+	for i := 0; i < 1000; i++ {
+		var x, y, z Scalar
+		if err := x.SetRandom(drng); err != nil {
+			t.Fatalf("SetRandom failed: %v", err)
+		}
+		y.SetBigInt(new(big.Int).Rand(drng, CurveExponent_Int)) // intentionally larger than GroupOrder
+		xInt, yInt := x.ToBigInt(), y.ToBigInt()
+		if xInt.Cmp(GroupOrder_Int) >= 0 || yInt.Cmp(GroupOrder_Int) >= 0 || xInt.Sign() < 0 || yInt.Sign() < 0 {
+			t.Fatalf("Scalars are not reduced")
+		}
+		expected := new(big.Int)
+
+		z.Add(&x, &y)
+		expected.Add(xInt, yInt).Mod(expected, GroupOrder_Int)
+		if z.ToBigInt().Cmp(expected) != 0 {
+			t.Fatalf("Add gives wrong result")
+		}
+		z.Sub(&x, &y)
+		expected.Sub(xInt, yInt).Mod(expected, GroupOrder_Int)
+		if z.ToBigInt().Cmp(expected) != 0 {
+			t.Fatalf("Sub gives wrong result")
+		}
+		z.Neg(&x)
+		expected.Neg(xInt).Mod(expected, GroupOrder_Int)
+		if z.ToBigInt().Cmp(expected) != 0 {
+			t.Fatalf("Neg gives wrong result")
+		}
+		z.Mul(&x, &y)
+		expected.Mul(xInt, yInt).Mod(expected, GroupOrder_Int)
+		if z.ToBigInt().Cmp(expected) != 0 {
+			t.Fatalf("Mul gives wrong result")
+		}
+		if !x.IsZero() {
+			z.Inv(&x)
+			z.Mul(&z, &x)
+			var one Scalar
+			one.SetOne()
+			if !z.IsEqual(&one) {
+				t.Fatalf("Inv gives wrong result")
+			}
+		}
+	}
+
+	// edge cases
+	var x, y Scalar
+	x.SetBigInt(big.NewInt(-1))
+	y.SetOne()
+	x.Add(&x, &y)
+	if !x.IsZero() {
+		t.Fatalf("-1 + 1 != 0")
+	}
+	x.SetBigInt(GroupOrder_Int)
+	if !x.IsZero() {
+		t.Fatalf("SetBigInt does not reduce modulo GroupOrder")
+	}
+	didPanic := func() (ret bool) {
+		defer func() { ret = recover() != nil }()
+		var zero, z Scalar
+		z.Inv(&zero)
+		return
+	}()
+	if !didPanic {
+		t.Fatalf("Inverting zero did not panic")
+	}
+}
+
+func TestScalarBytes(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1000))
+	for i := 0; i < 100; i++ {
+		var x, y Scalar
+		_ = x.SetRandom(drng)
+		encoding := x.Bytes()
+		if err := y.SetBytesCanonical(encoding[:]); err != nil {
+			t.Fatalf("SetBytesCanonical failed on output of Bytes: %v", err)
+		}
+		if y != x {
+			t.Fatalf("Bytes / SetBytesCanonical roundtrip failed")
+		}
+		y.SetZero()
+		y.SetBytes(encoding[:])
+		if y != x {
+			t.Fatalf("Bytes / SetBytes roundtrip failed")
+		}
+		// little endian
+		bigEndian := x.ToBigInt().FillBytes(make([]byte, ScalarByteLength))
+		for j := range bigEndian {
+			if bigEndian[j] != encoding[ScalarByteLength-1-j] {
+				t.Fatalf("Bytes is not little endian")
+			}
+		}
+	}
+
+	// SetBytes reduces, SetBytesCanonical rejects non-canonical values.
+	var x, y Scalar
+	groupOrderLE := GroupOrder_Int.FillBytes(make([]byte, ScalarByteLength))
+	for i, j := 0, ScalarByteLength-1; i < j; i, j = i+1, j-1 {
+		groupOrderLE[i], groupOrderLE[j] = groupOrderLE[j], groupOrderLE[i]
+	}
+	x.SetOne()
+	if err := x.SetBytesCanonical(groupOrderLE); !errors.Is(err, ErrScalarNonCanonical) {
+		t.Fatalf("SetBytesCanonical did not reject GroupOrder: %v", err)
+	}
+	var one Scalar
+	one.SetOne()
+	if x != one {
+		t.Fatalf("SetBytesCanonical modified receiver on error")
+	}
+	x.SetBytes(groupOrderLE)
+	if !x.IsZero() {
+		t.Fatalf("SetBytes did not reduce modulo GroupOrder")
+	}
+	allOnes := bytes.Repeat([]byte{0xFF}, 64)
+	y.SetBytes(allOnes)
+	if y.ToBigInt().Cmp(new(big.Int).Mod(new(big.Int).SetBytes(allOnes), GroupOrder_Int)) != 0 {
+		t.Fatalf("SetBytes gives wrong result for long input")
+	}
+	for _, length := range []int{0, 31, 33} {
+		if err := x.SetBytesCanonical(make([]byte, length)); !errors.Is(err, ErrScalarWrongLength) {
+			t.Fatalf("SetBytesCanonical did not reject input of length %v: %v", length, err)
+		}
+	}
+}
+
+func TestScalarSetRandomError(t *testing.T) {
+	var x Scalar
+	x.SetOne()
+	err := x.SetRandom(bytes.NewReader([]byte{1, 2, 3}))
+	if err == nil {
+		t.Fatalf("SetRandom did not report read error")
+	}
+	var one Scalar
+	one.SetOne()
+	if x != one {
+		t.Fatalf("SetRandom modified receiver on error")
+	}
+}
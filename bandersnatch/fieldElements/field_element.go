@@ -1,5 +1,7 @@
 package fieldElements
 
+import "math/big"
+
 // Code for FieldElement (meaning the field of definition of the bandersnatch curve)
 // is in field_element_64.go and field_element_8.go
 // Only field_element_64.go is used; field_element_8.go serves as a comparison
@@ -48,3 +50,48 @@ type BSFieldElement_Interface interface {
 
 }
 */
+
+// FieldElementBackend lists the field operations that the curve layer (package curvePoints) depends on.
+// FEPtr is the pointer type of the implementation, e.g. *bsFieldElement_64. All arguments are of this pointer type.
+//
+// Due to the efficiency issues described above, this is not used as a run-time interface. Rather, it serves as
+// documentation and as a type constraint for generic test code (see FieldElementConformance in field_element_conformance.go), which
+// any alternative implementation must pass before the FieldElement type alias is pointed to it (see field_element_backend_64.go).
+//
+// Apart from these, the curve layer also uses Double, DoubleEq, Multiply_by_five, CondSet, CondSelect and CmpAbs of bsFieldElement_64.
+// These are only optimizations or conveniences that can be expressed via the operations below; an alternative backend needs to provide them as well.
+type FieldElementBackend[FEPtr any] interface {
+	SetZero()
+	SetOne()
+	IsZero() bool
+	IsOne() bool
+	IsEqual(x FEPtr) bool
+	Normalize()
+
+	Add(x, y FEPtr)
+	Sub(x, y FEPtr)
+	Neg(x FEPtr)
+	Mul(x, y FEPtr)
+	Square(x FEPtr)
+	Inv(x FEPtr)
+	Divide(num, denom FEPtr)
+	AddEq(x FEPtr)
+	SubEq(x FEPtr)
+	MulEq(x FEPtr)
+	NegEq()
+	SquareEq()
+	InvEq()
+	DivideEq(denom FEPtr)
+
+	SquareRoot(x FEPtr) (ok bool)
+	Jacobi() int
+	Sign() int
+
+	SetBigInt(v *big.Int)
+	ToBigInt() *big.Int
+	SetUInt64(value uint64)
+}
+
+var _ FieldElementBackend[*bsFieldElement_64] = &bsFieldElement_64{}
+var _ FieldElementBackend[*FieldElement] = &FieldElement{}
+var _ FieldElementBackend[*bsFieldElement_8] = &bsFieldElement_8{}
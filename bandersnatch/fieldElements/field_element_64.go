@@ -151,6 +151,7 @@ var _ = callcounters.CreateHierarchicalCallCounter("AddFe", "Additions", "AddSub
 var _ = callcounters.CreateHierarchicalCallCounter("SubFe", "Subtractions", "AddSubFe")
 var _ = callcounters.CreateHierarchicalCallCounter("Jacobi", "Jacobi symbols", "OtherFe")
 var _ = callcounters.CreateHierarchicalCallCounter("NegFe", "Negations", "OtherFe")
+var _ = callcounters.CreateHierarchicalCallCounter("CondSelectFe", "Conditional selections", "OtherFe")
 var _ = callcounters.CreateHierarchicalCallCounter("MulFe", "generic Multiplications", "Multiplications")
 var _ = callcounters.CreateHierarchicalCallCounter("MulByFive", "Multiplications by 5", "Multiplications")
 var _ = callcounters.CreateHierarchicalCallCounter("Squarings", "", "Multiplications")
@@ -587,6 +588,31 @@ func (z *bsFieldElement_64) SetUInt64(value uint64) {
 	z.restoreMontgomery()
 }
 
+// Limbs returns the internal representation of z as 4 64-bit limbs in low-endian order (i.e. limbs[0] holds the least significant bits).
+//
+// The returned value is in Montgomery form: If z represents the field element x, interpreting limbs as a 256-bit number L gives L == x * 2^256 mod BaseFieldSize.
+// Since the internal representation of a field element is not unique, we Normalize z first, so L satisfies 0 <= L < BaseFieldSize and is uniquely determined by x.
+//
+// This is intended for interoperability with hand-written assembly or other libraries that use the same representation.
+// Users who just want the number x should use ToBigInt or serialization instead.
+func (z *bsFieldElement_64) Limbs() [4]uint64 {
+	z.Normalize()
+	return z.words
+}
+
+// SetLimbs sets the internal representation of z to the given limbs. This is the inverse of Limbs, i.e. limbs are in low-endian order and in Montgomery form:
+// Interpreting limbs as a 256-bit number L, z is set to the field element L / 2^256 mod BaseFieldSize.
+//
+// We require 0 <= L < BaseFieldSize. Otherwise, z is unchanged and we return ErrLimbsOutOfRange; we do not silently reduce.
+func (z *bsFieldElement_64) SetLimbs(limbs [4]uint64) error {
+	var temp bsFieldElement_64 = bsFieldElement_64{words: limbs}
+	if !temp.isNormalized() {
+		return ErrLimbsOutOfRange
+	}
+	*z = temp
+	return nil
+}
+
 // temporarily exported. Needs some restructing to unexport.
 
 // SetRandomUnsafe generates a uniformly random field element.
@@ -724,6 +750,37 @@ func (z *bsFieldElement_64) IsEqual(x *bsFieldElement_64) bool {
 	}
 }
 
+// EqualCT compares two field elements for equality, i.e. it checks whether z == x (mod BaseFieldSize).
+//
+// As opposed to IsEqual, this runs in constant time: we always process all limbs and there are no data-dependent branches or early returns.
+// Use this for comparing secret-derived field elements. Also unlike IsEqual, this never modifies the internal representation of z or x.
+func (z *bsFieldElement_64) EqualCT(x *bsFieldElement_64) bool {
+	// Since there are at most 2 representations per field element and they differ by BaseFieldSize,
+	// z == x (mod BaseFieldSize) iff their difference d (modulo 2^256) is one of 0, BaseFieldSize or 2^256 - BaseFieldSize.
+	var d [4]uint64
+	var borrow uint64
+	d[0], borrow = bits.Sub64(z.words[0], x.words[0], 0)
+	d[1], borrow = bits.Sub64(z.words[1], x.words[1], borrow)
+	d[2], borrow = bits.Sub64(z.words[2], x.words[2], borrow)
+	d[3], _ = bits.Sub64(z.words[3], x.words[3], borrow)
+
+	// minusP holds 2^256 - BaseFieldSize
+	var minusP [4]uint64
+	minusP[0], borrow = bits.Sub64(0, baseFieldSize_0, 0)
+	minusP[1], borrow = bits.Sub64(0, baseFieldSize_1, borrow)
+	minusP[2], borrow = bits.Sub64(0, baseFieldSize_2, borrow)
+	minusP[3], _ = bits.Sub64(0, baseFieldSize_3, borrow)
+
+	// diffZero, diffP, diffMinusP are zero iff d equals 0, BaseFieldSize, 2^256 - BaseFieldSize, respectively.
+	diffZero := d[0] | d[1] | d[2] | d[3]
+	diffP := (d[0] ^ baseFieldSize_0) | (d[1] ^ baseFieldSize_1) | (d[2] ^ baseFieldSize_2) | (d[3] ^ baseFieldSize_3)
+	diffMinusP := (d[0] ^ minusP[0]) | (d[1] ^ minusP[1]) | (d[2] ^ minusP[2]) | (d[3] ^ minusP[3])
+
+	// For a uint64 v, (v | -v) has its top bit set iff v != 0. So isZero(v) below is 1 iff v == 0 and 0 otherwise.
+	isZero := func(v uint64) uint64 { return ((v | -v) >> 63) ^ 1 }
+	return (isZero(diffZero) | isZero(diffP) | isZero(diffMinusP)) == 1
+}
+
 // TODO: error or bool? Specify what happens with z on error?
 
 // SquareRoot computes a SquareRoot in the field.
@@ -911,3 +968,28 @@ func (z *bsFieldElement_64) CmpAbs(x *bsFieldElement_64) (absEqual bool, exactly
 	}
 	return false, false
 }
+
+// CondSet sets z = x if choice == 1 and leaves z unchanged if choice == 0.
+// This is done without data-dependent branches or memory access patterns, so it can be used with secret choice.
+// The behaviour is unspecified if choice is neither 0 nor 1.
+//
+// Note that z.CondSet(&x, 1) copies the internal representation of x, so z.IsEqual(&x) holds afterwards.
+func (z *bsFieldElement_64) CondSet(x *bsFieldElement_64, choice int) {
+	var mask uint64 = -uint64(choice) // all-ones iff choice == 1
+	z.words[0] ^= mask & (z.words[0] ^ x.words[0])
+	z.words[1] ^= mask & (z.words[1] ^ x.words[1])
+	z.words[2] ^= mask & (z.words[2] ^ x.words[2])
+	z.words[3] ^= mask & (z.words[3] ^ x.words[3])
+}
+
+// CondSelect sets z = a if choice == 0 and z = b if choice == 1.
+// As CondSet, this is done without data-dependent branches or memory access patterns, so it can be used with secret choice.
+// The behaviour is unspecified if choice is neither 0 nor 1. Any of z, a, b may alias.
+func (z *bsFieldElement_64) CondSelect(a, b *bsFieldElement_64, choice int) {
+	IncrementCallCounter("CondSelectFe")
+	var mask uint64 = -uint64(choice) // all-ones iff choice == 1
+	z.words[0] = a.words[0] ^ (mask & (a.words[0] ^ b.words[0]))
+	z.words[1] = a.words[1] ^ (mask & (a.words[1] ^ b.words[1]))
+	z.words[2] = a.words[2] ^ (mask & (a.words[2] ^ b.words[2]))
+	z.words[3] = a.words[3] ^ (mask & (a.words[3] ^ b.words[3]))
+}
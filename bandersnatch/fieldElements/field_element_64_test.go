@@ -1,8 +1,10 @@
 package fieldElements
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"math/bits"
 	"math/rand"
 	"testing"
 
@@ -380,6 +382,61 @@ func TestSetUIunt(t *testing.T) {
 	}
 }
 
+func TestLimbs(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(445))
+	for i := 0; i < 1000; i++ {
+		var x, y bsFieldElement_64
+		x.SetRandomUnsafe(drng)
+		// use the alternative representation half of the time, if it exists (i.e. if x.words + 2*BaseFieldSize does not overflow).
+		if drng.Intn(2) == 0 {
+			alt, _ := addWords(x.words, bsFieldElement_64_zero_alt.words)
+			if _, overflow := addWords(alt, bsFieldElement_64_zero_alt.words); overflow == 0 {
+				x.words = alt
+			}
+		}
+		limbs := x.Limbs()
+		if err := y.SetLimbs(limbs); err != nil {
+			t.Fatalf("SetLimbs failed on output of Limbs: %v", err)
+		}
+		if !x.IsEqual(&y) || y.words != limbs {
+			t.Fatalf("SetLimbs and Limbs are not inverse to each other")
+		}
+		// Limbs are in Montgomery form.
+		limbsInt := utils.UIntarrayToInt(&limbs)
+		expected := new(big.Int).Lsh(x.ToBigInt(), 256)
+		expected.Mod(expected, BaseFieldSize_Int)
+		if limbsInt.Cmp(expected) != 0 {
+			t.Fatalf("Limbs is not the Montgomery representation")
+		}
+	}
+
+	// out-of-range limbs are rejected.
+	var one bsFieldElement_64
+	one.SetOne()
+	for _, invalid := range [][4]uint64{
+		bsFieldElement_64_zero_alt.words, // BaseFieldSize itself
+		{0xFFFFFFFF_FFFFFFFF, 0xFFFFFFFF_FFFFFFFF, 0xFFFFFFFF_FFFFFFFF, 0xFFFFFFFF_FFFFFFFF},
+		{0, 0, 0, baseFieldSize_3 + 1},
+	} {
+		z := one
+		err := z.SetLimbs(invalid)
+		if !errors.Is(err, ErrLimbsOutOfRange) {
+			t.Fatalf("SetLimbs did not reject out-of-range limbs %v. Got error %v", invalid, err)
+		}
+		if z != one {
+			t.Fatalf("SetLimbs modified the receiver on error")
+		}
+	}
+}
+
+// addWords adds two 256-bit numbers given as low-endian words. carry is the overflow.
+func addWords(x, y [4]uint64) (ret [4]uint64, carry uint64) {
+	for i := 0; i < 4; i++ {
+		ret[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	return
+}
+
 func TestMultiplyByFive(t *testing.T) {
 	var drng *rand.Rand = rand.New(rand.NewSource(444))
 	const iterations = 10000
@@ -416,3 +473,103 @@ func TestConstants(t *testing.T) {
 		t.Fatal("Representation of one or minus one are inconsistent: They do not add to zero")
 	}
 }
+
+func TestCondSet(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 100; i++ {
+		var x, y bsFieldElement_64
+		x.SetRandomUnsafe(drng)
+		y.SetRandomUnsafe(drng)
+		xCopy := x
+		x.CondSet(&y, 0)
+		if x != xCopy {
+			t.Fatalf("CondSet with choice 0 modified the receiver")
+		}
+		x.CondSet(&y, 1)
+		if x != y {
+			t.Fatalf("CondSet with choice 1 did not copy the argument")
+		}
+		// aliasing
+		x.CondSet(&x, 1)
+		if x != y {
+			t.Fatalf("CondSet with aliasing arguments modified the receiver")
+		}
+	}
+}
+
+func TestCondSelect(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 100; i++ {
+		var x, y, z bsFieldElement_64
+		x.SetRandomUnsafe(drng)
+		y.SetRandomUnsafe(drng)
+		xCopy, yCopy := x, y
+		z.CondSelect(&x, &y, 0)
+		if z != x {
+			t.Fatalf("CondSelect with choice 0 did not select the first argument")
+		}
+		z.CondSelect(&x, &y, 1)
+		if z != y {
+			t.Fatalf("CondSelect with choice 1 did not select the second argument")
+		}
+		if x != xCopy || y != yCopy {
+			t.Fatalf("CondSelect modified its arguments")
+		}
+		// aliasing
+		x.CondSelect(&x, &y, 1)
+		if x != y {
+			t.Fatalf("CondSelect with aliasing receiver did not select the second argument")
+		}
+		x = xCopy
+		y.CondSelect(&x, &y, 0)
+		if y != x {
+			t.Fatalf("CondSelect with aliasing receiver did not select the first argument")
+		}
+	}
+}
+
+func TestEqualCT(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	for i := 0; i < 200; i++ {
+		var x, y bsFieldElement_64
+		x.SetRandomUnsafe(drng)
+		if i%2 == 0 {
+			y = x
+		} else {
+			y.SetRandomUnsafe(drng)
+		}
+		xCopy, yCopy := x, y
+		if x.EqualCT(&y) != x.IsEqual(&y) || y.EqualCT(&x) != x.IsEqual(&y) {
+			t.Fatalf("EqualCT differs from IsEqual")
+		}
+
+		// non-normalized representation of the same field element: add BaseFieldSize to the internal representation
+		x.Normalize()
+		var z bsFieldElement_64
+		var carry uint64
+		z.words[0], carry = bits.Add64(x.words[0], baseFieldSize_0, 0)
+		z.words[1], carry = bits.Add64(x.words[1], baseFieldSize_1, carry)
+		z.words[2], carry = bits.Add64(x.words[2], baseFieldSize_2, carry)
+		z.words[3], _ = bits.Add64(x.words[3], baseFieldSize_3, carry)
+		zCopy := z
+		if !x.EqualCT(&z) || !z.EqualCT(&x) {
+			t.Fatalf("EqualCT does not recognize different representations of the same field element as equal")
+		}
+		if z != zCopy {
+			t.Fatalf("EqualCT modified its argument")
+		}
+		x, y = xCopy, yCopy
+		xCopy.EqualCT(&yCopy)
+		if x != xCopy || y != yCopy {
+			t.Fatalf("EqualCT modified its arguments")
+		}
+		if !x.EqualCT(&x) {
+			t.Fatalf("EqualCT with aliasing arguments failed")
+		}
+	}
+	var zero, one bsFieldElement_64
+	one.SetOne()
+	if zero.EqualCT(&one) || !zero.EqualCT(&zero) {
+		t.Fatalf("EqualCT fails for 0 and 1")
+	}
+}
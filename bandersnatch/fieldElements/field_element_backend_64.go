@@ -0,0 +1,24 @@
+//go:build !altfieldbackend
+
+package fieldElements
+
+// This file selects bsFieldElement_64 as the implementation of FieldElement.
+// It is compiled unless tags=altfieldbackend is set.
+// An alternative backend needs to provide a file with the opposite build constraint that defines FieldElement and the constants below,
+// satisfies FieldElementBackend and passes FieldElementConformance (run go test -tags altfieldbackend).
+
+// FieldElement is an element of the field of definition of the Bandersnatch curve.
+//
+// The size of this field matches (by design) the size of the prime-order subgroup of the BLS12-381 curve.
+type FieldElement = bsFieldElement_64
+
+// NOTE: We intentionally expose copies of unexported variables here to prevent users
+// from modifying bsFieldElement_64_one etc. and to give the compiler at least a chance to observe that these are never modified.
+// Internal code should not use the exported variables.
+
+var (
+	// Important constants of type FieldElement
+	FieldElementOne      FieldElement = bsFieldElement_64_one
+	FieldElementZero     FieldElement = bsFieldElement_64_zero
+	FieldElementMinusOne FieldElement = bsFieldElement_64_minusone
+)
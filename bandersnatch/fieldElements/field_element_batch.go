@@ -1,5 +1,10 @@
 package fieldElements
 
+import (
+	"encoding/binary"
+	"math/big"
+)
+
 /*
 	This file contains field element operations that can operate on multiple field elements.
 */
@@ -288,3 +293,54 @@ func MultiInvertEqSkipZeros(args ...*bsFieldElement_64) (zeroIndices []int) {
 	}
 	return
 }
+
+// BatchJacobi computes the Legendre symbols of all given field elements, i.e. the i'th output is elems[i].Jacobi().
+// Each entry of the output is +1 if elems[i] is a non-zero square, -1 if it is a non-square and 0 if it is zero.
+//
+// NOTE: The Legendre symbol is multiplicative, but (unlike for inversion) there is no way to recover individual symbols from a symbol of a product,
+// so the symbols themselves need to be computed one by one. The work shared is the conversion to big.Int, which reuses a single temporary here,
+// avoiding the per-element allocations of calling Jacobi in a loop.
+func BatchJacobi(elems []bsFieldElement_64) []int {
+	ret := make([]int, len(elems))
+	var buf [32]byte
+	var tempInt big.Int
+	for i := range elems {
+		IncrementCallCounter("Jacobi")
+		if elems[i].IsZero() {
+			ret[i] = 0
+			continue
+		}
+		words := elems[i].undoMontgomery()
+		for j := 0; j < 4; j++ {
+			binary.BigEndian.PutUint64(buf[32-8*(j+1):32-8*j], words[j])
+		}
+		tempInt.SetBytes(buf[:])
+		ret[i] = big.Jacobi(&tempInt, BaseFieldSize_Int)
+	}
+	return ret
+}
+
+// MulVec computes dst[i] = a[i] * b[i] for all i.
+//
+// dst may alias a or b (fully, i.e. with the same offset). MulVec panics if the slices do not all have the same length.
+func MulVec(dst, a, b []bsFieldElement_64) {
+	if len(dst) != len(a) || len(dst) != len(b) {
+		panic(ErrorPrefix + "MulVec called with slices of different lengths")
+	}
+	for i := range dst {
+		dst[i].Mul(&a[i], &b[i])
+	}
+}
+
+// ScaleVec computes dst[i] = a[i] * c for all i.
+//
+// dst may alias a (fully, i.e. with the same offset) and c may point to an element of a or dst. ScaleVec panics if dst and a have different lengths.
+func ScaleVec(dst, a []bsFieldElement_64, c *bsFieldElement_64) {
+	if len(dst) != len(a) {
+		panic(ErrorPrefix + "ScaleVec called with slices of different lengths")
+	}
+	var factor bsFieldElement_64 = *c // copy, since c might alias some dst[i] that we overwrite
+	for i := range dst {
+		dst[i].Mul(&a[i], &factor)
+	}
+}
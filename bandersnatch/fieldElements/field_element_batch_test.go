@@ -383,3 +383,94 @@ func TestMultiplySlice(t *testing.T) {
 		t.Fatal("MultiplyMany does not work when results and all inputs alias")
 	}
 }
+
+func TestBatchJacobi(t *testing.T) {
+	const size = 100
+	var drng *rand.Rand = rand.New(rand.NewSource(100))
+	elems := make([]bsFieldElement_64, size)
+	for i := range elems {
+		switch i % 10 {
+		case 0:
+			elems[i].SetZero()
+		case 1:
+			elems[i].SetOne()
+		case 2:
+			elems[i].SetRandomUnsafe(drng)
+			elems[i].SquareEq()
+		default:
+			elems[i].SetRandomUnsafe(drng)
+		}
+	}
+	result := BatchJacobi(elems)
+	testutils.FatalUnless(t, len(result) == size, "BatchJacobi returns wrong length")
+	var seenNonSquare bool
+	for i := range elems {
+		testutils.FatalUnless(t, result[i] == elems[i].Jacobi(), "BatchJacobi differs from Jacobi at index %v", i)
+		if result[i] == -1 {
+			seenNonSquare = true
+		}
+	}
+	testutils.FatalUnless(t, seenNonSquare, "")
+	testutils.FatalUnless(t, len(BatchJacobi(nil)) == 0, "")
+}
+
+func TestMulVec(t *testing.T) {
+	const size = 50
+	var drng *rand.Rand = rand.New(rand.NewSource(101))
+	a := make([]bsFieldElement_64, size)
+	b := make([]bsFieldElement_64, size)
+	expected := make([]bsFieldElement_64, size)
+	for i := 0; i < size; i++ {
+		a[i].SetRandomUnsafe(drng)
+		b[i].SetRandomUnsafe(drng)
+		expected[i].Mul(&a[i], &b[i])
+	}
+	dst := make([]bsFieldElement_64, size)
+	MulVec(dst, a, b)
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, dst[i].IsEqual(&expected[i]), "MulVec differs from Mul at index %v", i)
+	}
+
+	// aliasing
+	aCopy := append([]bsFieldElement_64(nil), a...)
+	MulVec(aCopy, aCopy, b)
+	bCopy := append([]bsFieldElement_64(nil), b...)
+	MulVec(bCopy, a, bCopy)
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, aCopy[i].IsEqual(&expected[i]), "MulVec fails when dst aliases a")
+		testutils.FatalUnless(t, bCopy[i].IsEqual(&expected[i]), "MulVec fails when dst aliases b")
+	}
+
+	testutils.FatalUnless(t, testutils.CheckPanic(MulVec, dst, a, b[1:]), "MulVec did not panic on length mismatch")
+	testutils.FatalUnless(t, testutils.CheckPanic(MulVec, dst[1:], a, b), "MulVec did not panic on length mismatch")
+	MulVec(nil, nil, nil)
+}
+
+func TestScaleVec(t *testing.T) {
+	const size = 50
+	var drng *rand.Rand = rand.New(rand.NewSource(102))
+	a := make([]bsFieldElement_64, size)
+	expected := make([]bsFieldElement_64, size)
+	var c bsFieldElement_64
+	c.SetRandomUnsafe(drng)
+	for i := 0; i < size; i++ {
+		a[i].SetRandomUnsafe(drng)
+		expected[i].Mul(&a[i], &c)
+	}
+	dst := make([]bsFieldElement_64, size)
+	ScaleVec(dst, a, &c)
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, dst[i].IsEqual(&expected[i]), "ScaleVec differs from Mul at index %v", i)
+	}
+
+	// aliasing, including c pointing into dst
+	aCopy := append([]bsFieldElement_64(nil), a...)
+	aCopy[size/2] = c
+	expected[size/2].Square(&c)
+	ScaleVec(aCopy, aCopy, &aCopy[size/2])
+	for i := 0; i < size; i++ {
+		testutils.FatalUnless(t, aCopy[i].IsEqual(&expected[i]), "ScaleVec fails under aliasing at index %v", i)
+	}
+
+	testutils.FatalUnless(t, testutils.CheckPanic(ScaleVec, dst, a[1:], &c), "ScaleVec did not panic on length mismatch")
+}
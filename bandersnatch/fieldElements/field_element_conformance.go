@@ -0,0 +1,154 @@
+package fieldElements
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// This file contains the conformance suite for field element backends.
+// It is not in a _test.go file, so that it can also be run from tests of other packages or by a backend selected via build tags.
+
+// FieldElementConformance checks that FieldElement, i.e. the backend selected by build tags, correctly implements
+// the operations of FieldElementBackend that the curve layer depends on. See BackendConformance for what is checked.
+func FieldElementConformance(t *testing.T) {
+	BackendConformance[FieldElement](t)
+}
+
+// BackendConformance checks that the implementation of field elements with pointer type FEPtr correctly implements
+// the operations of FieldElementBackend.
+// We compare against big.Int arithmetic modulo BaseFieldSize_Int on special and random values and also check aliasing of receiver and arguments.
+//
+// This allows validating an alternative backend before it is selected for FieldElement.
+func BackendConformance[FE any, FEPtr interface {
+	*FE
+	FieldElementBackend[FEPtr]
+}](t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	modulus := BaseFieldSize_Int
+	halfModulus := new(big.Int).Rsh(modulus, 1)
+
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(5), new(big.Int).Sub(modulus, big.NewInt(1)), halfModulus, new(big.Int).Add(halfModulus, big.NewInt(1))}
+	for i := 0; i < 50; i++ {
+		values = append(values, new(big.Int).Rand(drng, modulus))
+	}
+
+	makeFE := func(v *big.Int) FEPtr {
+		var ret FE
+		FEPtr(&ret).SetBigInt(v)
+		return &ret
+	}
+	check := func(z FEPtr, expected *big.Int, op string, args ...*big.Int) {
+		var expectedReduced *big.Int = new(big.Int).Mod(expected, modulus)
+		if z.ToBigInt().Cmp(expectedReduced) != 0 {
+			t.Fatalf("%v gave wrong result for %v: got %v, expected %v", op, args, z.ToBigInt(), expectedReduced)
+		}
+	}
+
+	// constants
+	var z FE
+	FEPtr(&z).SetZero()
+	testutils.FatalUnless(t, FEPtr(&z).IsZero() && !FEPtr(&z).IsOne(), "SetZero failed")
+	FEPtr(&z).SetOne()
+	testutils.FatalUnless(t, FEPtr(&z).IsOne() && !FEPtr(&z).IsZero(), "SetOne failed")
+	FEPtr(&z).SetUInt64(12345)
+	check(&z, big.NewInt(12345), "SetUInt64")
+
+	for _, xInt := range values {
+		x := makeFE(xInt)
+		check(x, xInt, "SetBigInt/ToBigInt", xInt)
+		testutils.FatalUnless(t, x.IsZero() == (xInt.Sign() == 0), "IsZero failed for %v", xInt)
+		testutils.FatalUnless(t, x.IsOne() == (xInt.Cmp(big.NewInt(1)) == 0), "IsOne failed for %v", xInt)
+		x.Normalize()
+		check(x, xInt, "Normalize", xInt)
+
+		// Sign and Jacobi
+		expectedSign := xInt.Sign()
+		if xInt.Cmp(halfModulus) > 0 {
+			expectedSign = -1
+		}
+		testutils.FatalUnless(t, x.Sign() == expectedSign, "Sign failed for %v", xInt)
+		testutils.FatalUnless(t, x.Jacobi() == big.Jacobi(xInt, modulus), "Jacobi failed for %v", xInt)
+
+		// unary operations
+		z := makeFE(big.NewInt(0))
+		z.Neg(x)
+		check(z, new(big.Int).Neg(xInt), "Neg", xInt)
+		z.Square(x)
+		check(z, new(big.Int).Mul(xInt, xInt), "Square", xInt)
+		if xInt.Sign() != 0 {
+			z.Inv(x)
+			check(z, new(big.Int).ModInverse(xInt, modulus), "Inv", xInt)
+		}
+		ok := z.SquareRoot(x)
+		if big.Jacobi(xInt, modulus) >= 0 {
+			testutils.FatalUnless(t, ok, "SquareRoot failed for square %v", xInt)
+			z.SquareEq()
+			check(z, xInt, "SquareRoot", xInt)
+		} else {
+			zCopy := *z
+			ok = z.SquareRoot(x)
+			testutils.FatalUnless(t, !ok, "SquareRoot succeeded for non-square %v", xInt)
+			testutils.FatalUnless(t, z.IsEqual(&zCopy), "SquareRoot modified receiver for non-square %v", xInt)
+		}
+
+		// unary in-place operations
+		z = makeFE(xInt)
+		z.NegEq()
+		check(z, new(big.Int).Neg(xInt), "NegEq", xInt)
+		z = makeFE(xInt)
+		z.SquareEq()
+		check(z, new(big.Int).Mul(xInt, xInt), "SquareEq", xInt)
+		if xInt.Sign() != 0 {
+			z = makeFE(xInt)
+			z.InvEq()
+			check(z, new(big.Int).ModInverse(xInt, modulus), "InvEq", xInt)
+		}
+
+		// binary operations
+		yInt := values[drng.Intn(len(values))]
+		y := makeFE(yInt)
+		testutils.FatalUnless(t, x.IsEqual(y) == (xInt.Cmp(yInt) == 0), "IsEqual failed for %v, %v", xInt, yInt)
+		z.Add(x, y)
+		check(z, new(big.Int).Add(xInt, yInt), "Add", xInt, yInt)
+		z.Sub(x, y)
+		check(z, new(big.Int).Sub(xInt, yInt), "Sub", xInt, yInt)
+		z.Mul(x, y)
+		check(z, new(big.Int).Mul(xInt, yInt), "Mul", xInt, yInt)
+		if yInt.Sign() != 0 {
+			z.Divide(x, y)
+			check(z, new(big.Int).Mul(xInt, new(big.Int).ModInverse(yInt, modulus)), "Divide", xInt, yInt)
+			z = makeFE(xInt)
+			z.DivideEq(y)
+			check(z, new(big.Int).Mul(xInt, new(big.Int).ModInverse(yInt, modulus)), "DivideEq", xInt, yInt)
+		}
+		z = makeFE(xInt)
+		z.AddEq(y)
+		check(z, new(big.Int).Add(xInt, yInt), "AddEq", xInt, yInt)
+		z = makeFE(xInt)
+		z.SubEq(y)
+		check(z, new(big.Int).Sub(xInt, yInt), "SubEq", xInt, yInt)
+		z = makeFE(xInt)
+		z.MulEq(y)
+		check(z, new(big.Int).Mul(xInt, yInt), "MulEq", xInt, yInt)
+
+		// aliasing of receiver and arguments
+		z = makeFE(xInt)
+		z.Add(z, z)
+		check(z, new(big.Int).Add(xInt, xInt), "Add (aliased)", xInt)
+		z = makeFE(xInt)
+		z.Sub(z, z)
+		check(z, big.NewInt(0), "Sub (aliased)", xInt)
+		z = makeFE(xInt)
+		z.Mul(z, z)
+		check(z, new(big.Int).Mul(xInt, xInt), "Mul (aliased)", xInt)
+		z = makeFE(xInt)
+		z.Square(z)
+		check(z, new(big.Int).Mul(xInt, xInt), "Square (aliased)", xInt)
+		z = makeFE(xInt)
+		z.Neg(z)
+		check(z, new(big.Int).Neg(xInt), "Neg (aliased)", xInt)
+	}
+}
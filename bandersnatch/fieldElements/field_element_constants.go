@@ -6,11 +6,6 @@ import (
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
 )
 
-// FieldElement is an element of the field of definition of the Bandersnatch curve.
-//
-// The size of this field matches (by design) the size of the prime-order subgroup of the BLS12-381 curve.
-type FieldElement = bsFieldElement_64
-
 // BaseFieldSize_untyped is the prime modulus (i.e. size) of the field of definition of Bandersnatch as untyped int.
 // Due to overflowing all standard types, this is only useful in constant expressions.
 // In most case, you want to use BaseFieldSize_Int of type big.Int instead
@@ -35,17 +30,9 @@ const BaseFieldByteLength = common.BaseFieldByteLength // == 32
 
 // An implementation of the base field might actually use more bytes; we don't.
 
-// NOTE: We intentionally expose copies of unexported variables here to prevent users
-// from modifying bsFieldElement_64_one etc. and to give the compiler at least a chance to observe that these are never modified.
-// Internal code should not use the exported variables.
-
-var (
-	// Important constants of type FieldElement
-	FieldElementOne      FieldElement = bsFieldElement_64_one
-	FieldElementZero     FieldElement = bsFieldElement_64_zero
-	FieldElementMinusOne FieldElement = bsFieldElement_64_minusone
-	FieldElementTwo      FieldElement = InitFieldElementFromString("2")
-)
+// FieldElementTwo is the constant 2 of type FieldElement.
+// FieldElementOne, FieldElementZero and FieldElementMinusOne are defined alongside the choice of backend in field_element_backend_64.go
+var FieldElementTwo FieldElement = InitFieldElementFromString("2")
 
 /*
 	These are used as constants in the multiplication algorithm.
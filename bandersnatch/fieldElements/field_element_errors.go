@@ -12,6 +12,7 @@ const ErrorPrefix = "bandersnatch / field element: "
 
 var ErrCannotRepresentAsUInt64 = errors.New(ErrorPrefix + "cannot represent field element as a uint64")
 var ErrDivisionByZero = errors.New(ErrorPrefix + "division by zero")
+var ErrLimbsOutOfRange = errors.New(ErrorPrefix + "limbs passed to SetLimbs do not represent a number in 0 <= . < BaseFieldSize")
 
 // These are the errors that can occur during (de)serialization.
 var (
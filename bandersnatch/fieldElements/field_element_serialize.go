@@ -1,6 +1,7 @@
 package fieldElements
 
 import (
+	"encoding/binary"
 	"io"
 	"math/bits"
 
@@ -180,7 +181,7 @@ func (z *bsFieldElement_64) DeserializeWithPrefix(input io.Reader, expectedPrefi
 
 	readPrefixBits := common.PrefixBits(little_endian_words[3] >> (64 - expectedPrefixLength))
 	if readPrefixBits != expectedPrefixBits {
-		testutils.Assert(!byteOrder.StartsWithMSB()) // We already checked the prefix above and should not have come this far.
+		testutils.DebugAssert(!byteOrder.StartsWithMSB()) // We already checked the prefix above and should not have come this far.
 		errPlain = ErrPrefixMismatch
 		return
 	}
@@ -222,3 +223,61 @@ func (z *bsFieldElement_64) Serialize(output io.Writer, byteOrder FieldElementEn
 	bytesWritten, err = z.SerializeWithPrefix(output, BitHeader{}, byteOrder)
 	return
 }
+
+// PutBytesWithPrefix is a variant of SerializeWithPrefix that writes directly to a 32-byte array rather than to an io.Writer.
+// This is meant for hot loops: it performs no heap allocations.
+//
+// The only possible error is ErrPrefixDoesNotFit, in which case dst is untouched.
+func (z *bsFieldElement_64) PutBytesWithPrefix(dst *[32]byte, prefix BitHeader, byteOrder FieldElementEndianness) error {
+	var low_endian_words [4]uint64 = z.undoMontgomery()
+	prefix_length := prefix.PrefixLen()
+	if bits.LeadingZeros64(low_endian_words[3]) < int(prefix_length) {
+		return ErrPrefixDoesNotFit
+	}
+	low_endian_words[3] |= (uint64(prefix.PrefixBits()) << (64 - prefix_length))
+
+	// We do not use byteOrder.PutUint256, because calling methods of the wrapped binary.ByteOrder interface value would make dst escape to the heap.
+	if byteOrder.StartsWithMSB() {
+		for i := 0; i < 4; i++ {
+			binary.BigEndian.PutUint64(dst[i*8:(i+1)*8], low_endian_words[3-i])
+		}
+	} else {
+		for i := 0; i < 4; i++ {
+			binary.LittleEndian.PutUint64(dst[i*8:(i+1)*8], low_endian_words[i])
+		}
+	}
+	return nil
+}
+
+// SetBytesWithPrefix is a variant of DeserializeWithPrefix that reads directly from a 32-byte array rather than from an io.Reader.
+// This is meant for hot loops: it performs no heap allocations.
+//
+// If the expected prefix is not present, we return ErrPrefixMismatch and z is untouched.
+// As with DeserializeWithPrefix, we return ErrNonNormalizedDeserialization if the number read is not the smallest representative modulo BaseFieldSize;
+// in this case, we still write the result (modulo BaseFieldSize) to z.
+func (z *bsFieldElement_64) SetBytesWithPrefix(src *[32]byte, expectedPrefix BitHeader, byteOrder FieldElementEndianness) (err error) {
+	var little_endian_words [4]uint64
+	// See PutBytesWithPrefix for why we do not use byteOrder.Uint256
+	if byteOrder.StartsWithMSB() {
+		for i := 0; i < 4; i++ {
+			little_endian_words[3-i] = binary.BigEndian.Uint64(src[i*8 : (i+1)*8])
+		}
+	} else {
+		for i := 0; i < 4; i++ {
+			little_endian_words[i] = binary.LittleEndian.Uint64(src[i*8 : (i+1)*8])
+		}
+	}
+	expectedPrefixLength := expectedPrefix.PrefixLen()
+	if common.PrefixBits(little_endian_words[3]>>(64-expectedPrefixLength)) != expectedPrefix.PrefixBits() {
+		return ErrPrefixMismatch
+	}
+	little_endian_words[3] &= 0xFFFFFFFF_FFFFFFFF >> expectedPrefixLength
+	z.words = little_endian_words
+
+	// Note: We need to call isNormalized before restoreMontgomery (because the latter would normalize).
+	if !z.isNormalized() {
+		err = ErrNonNormalizedDeserialization
+	}
+	z.restoreMontgomery()
+	return
+}
@@ -2,6 +2,7 @@ package fieldElements
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"math/bits"
 	"math/rand"
@@ -97,3 +98,69 @@ func TestSerializeFieldElements(t *testing.T) {
 		}
 	}
 }
+
+func TestPutAndSetBytesWithPrefix(t *testing.T) {
+	const iterations = 100
+	var drng *rand.Rand = rand.New(rand.NewSource(88))
+	for i := 0; i < iterations; i++ {
+		var fe, fe2 bsFieldElement_64
+		fe.SetRandomUnsafe(drng)
+		if fe.Sign() < 0 {
+			fe.NegEq()
+		}
+		var prefix common.BitHeader = common.MakeBitHeader((common.PrefixBits(i)/2)%4, 2)
+		var byteOrder FieldElementEndianness = LittleEndian
+		if i%2 == 0 {
+			byteOrder = BigEndian
+		}
+		var buf bytes.Buffer
+		_, errSerialize := fe.SerializeWithPrefix(&buf, prefix, byteOrder)
+		if errSerialize != nil {
+			t.Fatal(errSerialize)
+		}
+		var arr [32]byte
+		if err := fe.PutBytesWithPrefix(&arr, prefix, byteOrder); err != nil {
+			t.Fatal("PutBytesWithPrefix failed: ", err)
+		}
+		if !bytes.Equal(arr[:], buf.Bytes()) {
+			t.Fatal("PutBytesWithPrefix differs from SerializeWithPrefix")
+		}
+		if err := fe2.SetBytesWithPrefix(&arr, prefix, byteOrder); err != nil {
+			t.Fatal("SetBytesWithPrefix failed: ", err)
+		}
+		if !fe.IsEqual(&fe2) {
+			t.Fatal("Roundtripping via PutBytesWithPrefix and SetBytesWithPrefix failed")
+		}
+		wrongPrefix := common.MakeBitHeader((prefix.PrefixBits()+1)%4, 2)
+		if err := fe2.SetBytesWithPrefix(&arr, wrongPrefix, byteOrder); !errors.Is(err, ErrPrefixMismatch) {
+			t.Fatal("SetBytesWithPrefix did not detect prefix mismatch")
+		}
+	}
+
+	// prefix does not fit
+	var fe bsFieldElement_64
+	fe.SetUInt64(1)
+	fe.NegEq()
+	var arr [32]byte
+	if err := fe.PutBytesWithPrefix(&arr, common.MakeBitHeader(common.PrefixBits(0b01), 2), LittleEndian); !errors.Is(err, ErrPrefixDoesNotFit) {
+		t.Fatal("PutBytesWithPrefix did not detect that prefix does not fit")
+	}
+
+	// non-normalized input (BaseFieldSize itself)
+	var words [4]uint64 = [4]uint64{baseFieldSize_0, baseFieldSize_1, baseFieldSize_2, baseFieldSize_3}
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(arr[8*i:8*(i+1)], words[i])
+	}
+	if err := fe.SetBytesWithPrefix(&arr, common.BitHeader{}, LittleEndian); !errors.Is(err, ErrNonNormalizedDeserialization) || !fe.IsZero() {
+		t.Fatal("SetBytesWithPrefix did not detect non-normalized input")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		var arr [32]byte
+		_ = fe.PutBytesWithPrefix(&arr, common.BitHeader{}, DefaultEndian)
+		_ = fe.SetBytesWithPrefix(&arr, common.BitHeader{}, DefaultEndian)
+	})
+	if allocs != 0 {
+		t.Fatalf("PutBytesWithPrefix and SetBytesWithPrefix allocated %v times", allocs)
+	}
+}
@@ -33,3 +33,9 @@ func TestSanity(t *testing.T) {
 		t.Error("BaseFieldSize_untyped > 256 bits is not portable")
 	}
 }
+
+func TestFieldElementConformance(t *testing.T) {
+	FieldElementConformance(t)
+	BackendConformance[bsFieldElement_64](t)
+	BackendConformance[bsFieldElement_8](t)
+}
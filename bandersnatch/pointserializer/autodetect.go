@@ -0,0 +1,49 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains DeserializeAutoDetect, which deserializes a point in either the short or long Banderwagon format, detecting the format from the stream.
+
+// ErrCannotDetectFormat is returned (wrapped) by DeserializeAutoDetect if the bit header of the stream matches neither supported format.
+var ErrCannotDetectFormat = errors.New(ErrorPrefix + "could not detect serialization format")
+
+// DeserializeAutoDetect deserializes a single point from inputStream, which may be in either the short or the long Banderwagon format (as written by the default serializers).
+// trustLevel has the same meaning as for DeserializeCurvePoint. It returns the point read and the name of the detected format, i.e. FormatNameXTimesSignY or FormatNameYXTimesSignY.
+//
+// Both formats start with a field element whose most significant bits are a bit header: 1 for the short format, 00 for the long format.
+// Since the default endianness is little endian, these bits are in the last byte of the field element, so we need to read the full first field element to detect the format.
+// If the stream does not contain that many bytes, we return an error wrapping the read error (i.e. io.EOF or io.ErrUnexpectedEOF for a short stream).
+// If the bit header is 01, we return an error wrapping ErrCannotDetectFormat.
+// Other possible errors are those of the DeserializeCurvePoint method of the detected format's serializer. The format name is returned whenever detection succeeded.
+func DeserializeAutoDetect(inputStream io.Reader, trustLevel common.IsInputTrusted) (point curvePoints.Point_xtw_subgroup, formatName string, err error) {
+	var head [32]byte
+	bytesRead, errRead := io.ReadFull(inputStream, head[:])
+	if errRead != nil {
+		err = fmt.Errorf(ErrorPrefix+"could not detect serialization format: stream ended after %v bytes, before the first field element was complete. Read error was: %w", bytesRead, errRead)
+		return
+	}
+	var deserializer curvePointDeserializer_basic
+	long, ok := curvePoints.DetectBanderwagonFormat(&head)
+	switch {
+	case ok && !long:
+		deserializer, formatName = &basicBanderwagonShort, FormatNameXTimesSignY
+	case ok && long:
+		deserializer, formatName = &basicBanderwagonLong, FormatNameYXTimesSignY
+	default:
+		err = fmt.Errorf("%w: the first field element starts with the bit header 01", ErrCannotDetectFormat)
+		return
+	}
+	_, errDeserialize := deserializer.DeserializeCurvePoint(io.MultiReader(bytes.NewReader(head[:]), inputStream), trustLevel, &point)
+	if errDeserialize != nil {
+		err = errDeserialize
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeserializeAutoDetect(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		for _, serializer := range []curvePointSerializer_basic{&basicBanderwagonShort, &basicBanderwagonLong} {
+			expectedName, _ := FormatNameOf(serializer)
+			var buf bytes.Buffer
+			_, errSerialize := serializer.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, errSerialize == nil, "Serialization failed %v", errSerialize)
+			buf.WriteByte(0xFF) // trailing data must not be consumed
+			Q, name, err := DeserializeAutoDetect(&buf, common.UntrustedInput)
+			testutils.FatalUnless(t, err == nil, "DeserializeAutoDetect failed for %v: %v", expectedName, err)
+			testutils.FatalUnless(t, name == expectedName, "DeserializeAutoDetect detected %v, expected %v", name, expectedName)
+			testutils.FatalUnless(t, Q.IsEqual(&P), "DeserializeAutoDetect did not round-trip for %v", expectedName)
+			testutils.FatalUnless(t, buf.Len() == 1, "DeserializeAutoDetect consumed wrong number of bytes")
+		}
+	}
+
+	// stream too short to detect the format: this is reported as an I/O error
+	for _, input := range [][]byte{nil, {0x80}, make([]byte, 31)} {
+		_, name, err := DeserializeAutoDetect(bytes.NewReader(input), common.UntrustedInput)
+		testutils.FatalUnless(t, errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF), "DeserializeAutoDetect gave unexpected error for input of length %v: %v", len(input), err)
+		testutils.FatalUnless(t, name == "", "")
+	}
+
+	// unrecognized header
+	invalid := make([]byte, 64)
+	invalid[31] = 0b0100_0000
+	_, _, err := DeserializeAutoDetect(bytes.NewReader(invalid), common.UntrustedInput)
+	testutils.FatalUnless(t, errors.Is(err, ErrCannotDetectFormat), "DeserializeAutoDetect gave unexpected error for invalid header: %v", err)
+
+	// detected, but truncated long format
+	P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var buf bytes.Buffer
+	_, _ = basicBanderwagonLong.SerializeCurvePoint(&buf, &P)
+	_, name, err := DeserializeAutoDetect(bytes.NewReader(buf.Bytes()[0:40]), common.UntrustedInput)
+	testutils.FatalUnless(t, err != nil && !errors.Is(err, ErrCannotDetectFormat), "DeserializeAutoDetect gave unexpected error for truncated long format: %v", err)
+	testutils.FatalUnless(t, name == FormatNameYXTimesSignY, "")
+}
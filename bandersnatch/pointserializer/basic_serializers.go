@@ -1,8 +1,11 @@
 package pointserializer
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"io"
+	"math/big"
 
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
@@ -39,6 +42,11 @@ type curvePointDeserializer_basic interface {
 	Validate()                                    // internal self-check of parameters; this is exported because of reflect usage
 	RecognizedParameters() []string               // gives a list of recognized parameters
 	HasParameter(parameterName string) bool       // checks whether a given parameter is recognized
+
+	// IsCanonical checks whether data is the canonical encoding of a curve point, i.e. deserializing and re-serializing gives back data.
+	// This is a malleability check. The error is non-nil if data cannot be deserialized at all.
+	// Non-normalized field elements in data are reported as non-canonical without error.
+	IsCanonical(data []byte) (bool, error)
 }
 
 // modifyableSerializer is the interface part contains the generic methods used to modify parameters.
@@ -76,7 +84,7 @@ type modifyableSerializer_basic[SelfValue any, SelfPtr interface{ *SelfValue }]
 // Note: This function is is typically called before serializing (not for deserializing), where we do not have a trustLevel argument.
 // This means that we always check whether the point is in the subgroup for any writes if the serializer is subgroup-only.
 // Note for efficiency that this check is actually trivial if the type of point can only represent subgroup elements;
-// we assume that this is the most common usage scenario.
+// we assume that this is the most common usage scenario. We skip the call to IsInSubgroup() explicitly in this case.
 func checkPointSerializability(point curvePoints.CurvePointPtrInterfaceRead, performSubgroupCheck bool) (err error) {
 	if point.IsNaP() {
 		err = bandersnatchErrors.ErrCannotSerializeNaP
@@ -86,7 +94,7 @@ func checkPointSerializability(point curvePoints.CurvePointPtrInterfaceRead, per
 		err = bandersnatchErrors.ErrCannotSerializePointAtInfinity
 		return
 	}
-	if performSubgroupCheck {
+	if performSubgroupCheck && !point.CanOnlyRepresentSubgroup() {
 		if !point.IsInSubgroup() {
 			err = bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup
 			return
@@ -98,6 +106,147 @@ func checkPointSerializability(point curvePoints.CurvePointPtrInterfaceRead, per
 // type alias to non-exported type for struct embedding
 
 type subgroupRestriction = common.SubgroupRestriction
+
+// ErrIdentityRejected is returned (wrapped) by deserializers with the RejectIdentity parameter set if the input encodes the neutral element.
+var ErrIdentityRejected = errors.New(ErrorPrefix + "encountered encoding of the neutral element, which is rejected by this deserializer")
+
+// identityRejection is a type (intended for struct embedding into serializers) wrapping a bool
+// that determines whether the deserializer rejects encodings of the neutral element.
+// This is a hardening measure for protocols that must never accept the identity as input (e.g. public keys in key agreement).
+// The zero value accepts the neutral element.
+//
+// Note that this only affects deserialization; serializing the neutral element is always possible.
+type identityRejection struct {
+	rejectIdentity bool
+}
+
+// SetRejectIdentity sets whether deserialization rejects the neutral element.
+//
+// This function is only exported (and needed) for internal cross-package and reflect usage.
+func (ir *identityRejection) SetRejectIdentity(reject bool) {
+	ir.rejectIdentity = reject
+}
+
+// RejectsIdentity returns whether deserialization rejects the neutral element.
+func (ir *identityRejection) RejectsIdentity() bool {
+	return ir.rejectIdentity
+}
+
+func (ir *identityRejection) Validate() {}
+
+func (ir *identityRejection) RecognizedParameters() []string {
+	return []string{"RejectIdentity"}
+}
+
+// checkIdentity returns an error wrapping ErrIdentityRejected if identity rejection is enabled and point is the neutral element; otherwise, it returns nil.
+// bytesRead is the number of bytes read for the point, which is included in the returned error.
+func (ir *identityRejection) checkIdentity(point curvePoints.CurvePointPtrInterfaceRead, bytesRead int) bandersnatchErrors.DeserializationError {
+	if !ir.rejectIdentity || !point.IsNeutralElement() {
+		return nil
+	}
+	return errorsWithData.NewErrorWithParametersFromData(ErrIdentityRejected, "%w", &bandersnatchErrors.ReadErrorData{
+		PartialRead:  false,
+		BytesRead:    bytesRead,
+		ActuallyRead: nil,
+	})
+}
+
+// ErrWrongOrder is returned (wrapped) by deserializers with the RequireOrder parameter set if the decoded point does not have the required order.
+var ErrWrongOrder = errors.New(ErrorPrefix + "decoded point does not have the order required by this deserializer")
+
+// orderRequirement is a type (intended for struct embedding into serializers) wrapping an optional *big.Int
+// that determines the exact order that deserialized points are required to have.
+// This is a hardening measure against invalid-curve / small-subgroup attacks for deserializers that output points on the full curve:
+// setting the required order to GroupOrder rejects any point that has a component in the cofactor group, which is stricter than just clearing the cofactor.
+// The zero value (nil) accepts points of any order.
+//
+// Note that this only affects deserialization.
+type orderRequirement struct {
+	requiredOrder *big.Int // nil means no requirement. The pointed-to value is never modified, so shallow copies are fine.
+}
+
+// SetRequiredOrder sets the order that deserialized points are required to have. A nil argument disables the check.
+// The argument is copied.
+//
+// This function is only exported (and needed) for internal cross-package and reflect usage.
+func (or *orderRequirement) SetRequiredOrder(order *big.Int) {
+	if order == nil {
+		or.requiredOrder = nil
+		return
+	}
+	or.requiredOrder = new(big.Int).Set(order)
+}
+
+// GetRequiredOrder returns (a copy of) the order that deserialized points are required to have, or nil if there is no such requirement.
+func (or *orderRequirement) GetRequiredOrder() *big.Int {
+	if or.requiredOrder == nil {
+		return nil
+	}
+	return new(big.Int).Set(or.requiredOrder)
+}
+
+// Validate checks that the required order (if set) is positive. It panics otherwise.
+func (or *orderRequirement) Validate() {
+	if or.requiredOrder != nil && or.requiredOrder.Sign() <= 0 {
+		panic(ErrorPrefix + "required order of deserialized points must be positive")
+	}
+}
+
+func (or *orderRequirement) RecognizedParameters() []string {
+	return []string{"RequireOrder"}
+}
+
+// checkOrder returns an error wrapping ErrWrongOrder if an order requirement is set and point does not have the required order; otherwise, it returns nil.
+// bytesRead is the number of bytes read for the point, which is included in the returned error.
+func (or *orderRequirement) checkOrder(point curvePoints.CurvePointPtrInterfaceRead, bytesRead int) bandersnatchErrors.DeserializationError {
+	if or.requiredOrder == nil {
+		return nil
+	}
+	var P curvePoints.Point_xtw_full
+	P.SetFrom(point)
+	if P.Order().Cmp(or.requiredOrder) == 0 {
+		return nil
+	}
+	return errorsWithData.NewErrorWithParametersFromData(ErrWrongOrder, "%w", &bandersnatchErrors.ReadErrorData{
+		PartialRead:  false,
+		BytesRead:    bytesRead,
+		ActuallyRead: nil,
+	})
+}
+
+// ErrAllZeroEncoding is returned (wrapped) by the short Banderwagon deserializer if the input consists entirely of zero bytes.
+// Due to the choice of bit header, this is not a valid encoding of any point (in particular not of the neutral element).
+// We report this case separately, since all-zero input usually indicates uninitialized data rather than a corrupted point.
+var ErrAllZeroEncoding = errors.New(ErrorPrefix + "input consists entirely of zero bytes, which does not encode any point in this format")
+
+// readFullCheckAllZero reads exactly outputLength bytes from input. It returns an error if this fails or if all bytes read are zero; in the latter case, the error wraps ErrAllZeroEncoding.
+//
+// Note that we need to read the full input before parsing: Depending on endianness, parsing may abort after the first byte due to a bit header mismatch,
+// so we could not tell an all-zero input from any other input with the wrong bit header afterwards.
+func readFullCheckAllZero(input io.Reader, outputLength int) (buf []byte, err bandersnatchErrors.DeserializationError) {
+	buf = make([]byte, outputLength)
+	bytesRead, errPlain := io.ReadFull(input, buf)
+	if errPlain != nil {
+		err = errorsWithData.NewErrorWithParametersFromData(errPlain, "%w", &bandersnatchErrors.ReadErrorData{
+			PartialRead:  bytesRead != 0,
+			BytesRead:    bytesRead,
+			ActuallyRead: copyByteSlice(buf[:bytesRead]),
+		})
+		return
+	}
+	for _, b := range buf {
+		if b != 0 {
+			return
+		}
+	}
+	err = errorsWithData.NewErrorWithParametersFromData(ErrAllZeroEncoding, "%w", &bandersnatchErrors.ReadErrorData{
+		PartialRead:  false,
+		BytesRead:    bytesRead,
+		ActuallyRead: buf,
+	})
+	return
+}
+
 type subgroupOnly = common.SubgroupOnly
 
 // addErrorDataNoWrite turns an arbitrary error into a SerializationError; the additional data added is trivial.
@@ -112,6 +261,50 @@ func addErrorDataNoWrite(err error) bandersnatchErrors.SerializationError {
 	})
 }
 
+// isCanonicalEncoding deserializes data using s, re-serializes the result and checks whether this gives back data.
+// This is a format-agnostic way to detect malleable inputs: every point has exactly one encoding that round-trips.
+//
+// The returned error is non-nil iff data cannot be interpreted as a curve point at all (modulo non-normalized field elements, see below).
+// If data contains a field element that is not in normalized form, we report false with a nil error, since this is exactly the kind of malleability we want to detect.
+// Trailing data after the first s.OutputLength() bytes makes the encoding non-canonical.
+func isCanonicalEncoding(s curvePointSerializer_basic, data []byte) (bool, error) {
+	var point curvePoints.CurvePointPtrInterface
+	if s.IsSubgroupOnly() {
+		point = &curvePoints.Point_xtw_subgroup{}
+	} else {
+		point = &curvePoints.Point_xtw_full{}
+	}
+	_, err := s.DeserializeCurvePoint(uncountedInput(data), common.UntrustedInput, point)
+	if err != nil {
+		if errors.Is(err, fieldElements.ErrNonNormalizedDeserialization) {
+			return false, nil
+		}
+		return false, err
+	}
+	var buf bytes.Buffer
+	_, errSerialize := s.SerializeCurvePoint(&buf, point)
+	if errSerialize != nil {
+		// Cannot happen: We only deserialize points that we can serialize.
+		panic(errSerialize)
+	}
+	return bytes.Equal(buf.Bytes(), data), nil
+}
+
+// NeutralEncoding returns the encoding of the neutral element under the format given by s.
+// This pins down the canonical byte representation of the identity for each format.
+//
+// Note that this need not be all-zeros: e.g. for the Banderwagon formats, the bitHeaders are chosen such that an all-zero input is an error;
+// the neutral element is then encoded with X=0 and the header bits set.
+// The error is non-nil only if s cannot serialize the neutral element.
+func NeutralEncoding(s curvePointSerializer_basic) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := s.SerializeCurvePoint(&buf, &curvePoints.NeutralElement_xtw_subgroup)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // ***********************************************************************************************************************************************************
 
 // we now define some "basic" serializers, basic being in the sense that they only allow (de)serializing a single point.
@@ -124,6 +317,8 @@ func addErrorDataNoWrite(err error) bandersnatchErrors.SerializationError {
 type pointSerializerXY struct {
 	valuesSerializerHeaderFeHeaderFe
 	subgroupRestriction // wraps a bool
+	identityRejection   // wraps a bool
+	orderRequirement
 }
 
 // SerializeCurvePoint writes a single curve point to the given output.
@@ -146,6 +341,7 @@ func (s *pointSerializerXY) SerializeCurvePoint(output io.Writer, point curvePoi
 //
 // The format is X||Y for affine X and Y coordinates.
 func (s *pointSerializerXY) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
 	var X, Y fieldElements.FieldElement
 	// var errPlain error
 	bytesRead, err, X, Y = s.DeserializeValues(input)
@@ -167,6 +363,12 @@ func (s *pointSerializerXY) DeserializeCurvePoint(input io.Reader, trustLevel co
 			}
 			return
 		}
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	} else {
 		// using a temporary P here to ensure P is unchanged on error
@@ -183,6 +385,12 @@ func (s *pointSerializerXY) DeserializeCurvePoint(input io.Reader, trustLevel co
 			}
 			return
 		}
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	}
 	return
@@ -196,6 +404,13 @@ func (s *pointSerializerXY) DeserializeCurvePoint(input io.Reader, trustLevel co
 func (s *pointSerializerXY) Validate() {
 	s.valuesSerializerHeaderFeHeaderFe.Validate()
 	s.subgroupRestriction.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
+}
+
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerXY) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
 }
 
 // Clone creates an independent copy of the received serializer, returning a pointer.
@@ -211,7 +426,7 @@ func (s *pointSerializerXY) Clone() (ret *pointSerializerXY) {
 // WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
 // Invalid inputs cause a panic.
 //
-// Recognized params are: "Endianness", "SubgroupOnly", "BitHeader", "BitHeader2"
+// Recognized params are: "Endianness", "SubgroupOnly", "BitHeader", "BitHeader2", "RejectIdentity", "RequireOrder"
 func (s *pointSerializerXY) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerXY) {
 	return makeCopyWithParameters(s, param, newParam)
 }
@@ -231,14 +446,14 @@ func (s *pointSerializerXY) OutputLength() int32 { return 64 }
 
 // GetParameter returns the value of the internal parameter determined by parameterName
 //
-// recognized parameterNames are: "Endianness", "SubgroupOnly"., "BitHeader", "BitHeader2"
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "BitHeader", "BitHeader2", "RejectIdentity", "RequireOrder".
 func (s *pointSerializerXY) GetParameter(parameterName string) interface{} {
 	return getSerializerParameter(s, parameterName)
 }
 
 // RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
 func (s *pointSerializerXY) RecognizedParameters() []string {
-	return concatParameterList(s.valuesSerializerHeaderFeHeaderFe.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters())
+	return concatParameterList(s.valuesSerializerHeaderFeHeaderFe.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
 }
 
 // HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
@@ -251,9 +466,13 @@ func (s *pointSerializerXY) HasParameter(parameterName string) bool {
 // pointSerializerXAndSignY is a Serialializer that serializes the affine X coordinate and the sign of the Y coordinate. (Note that the latter is never 0)
 //
 // More precisely, we write a 1 bit into the msb of the output (if interpreteed as 256bit-number) if the sign of Y is negative.
+// Note that this bit is part of the number and not of the byte stream, so its physical position depends on the endianness:
+// For little endian (the default), it is the top bit of the last byte; for big endian, it is the top bit of the first byte.
 type pointSerializerXAndSignY struct {
 	valuesSerializerFeCompressedBit
 	subgroupRestriction
+	identityRejection
+	orderRequirement
 }
 
 // SerializeCurvePoint writes a single curve point to the given output.
@@ -279,6 +498,7 @@ func (s *pointSerializerXAndSignY) SerializeCurvePoint(output io.Writer, point c
 //
 // The format expected is Sign(Y)||X, with the sign bit (1 for negative, 0 for positive) embedded in the msb of X.
 func (s *pointSerializerXAndSignY) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
 	var X fieldElements.FieldElement
 	var signBit bool
 	bytesRead, err, X, signBit = s.DeserializeValues(input)
@@ -286,17 +506,9 @@ func (s *pointSerializerXAndSignY) DeserializeCurvePoint(input io.Reader, trustL
 		return
 	}
 
-	//  convert boolean sign bit to +/-1 - valued sign
-	var signInt int
-	if signBit {
-		signInt = -1
-	} else {
-		signInt = +1
-	}
-
 	if s.IsSubgroupOnly() || point.CanOnlyRepresentSubgroup() {
 		var P curvePoints.Point_axtw_subgroup
-		P, errCurvePoint := curvePoints.CurvePointFromXAndSignY_subgroup(&X, signInt, trustLevel)
+		P, errCurvePoint := curvePoints.CurvePointFromXAndSignYBit_subgroup(&X, signBit, trustLevel)
 		if errCurvePoint != nil {
 			err = errorsWithData.NewErrorWithParametersFromData(errCurvePoint, "%w", &bandersnatchErrors.ReadErrorData{
 				PartialRead:  false,
@@ -304,15 +516,21 @@ func (s *pointSerializerXAndSignY) DeserializeCurvePoint(input io.Reader, trustL
 				ActuallyRead: nil,
 			})
 			if trustLevel.Bool() {
-				panic(err) // should not happen, because CurvePointFromXAndSignY panics.
+				panic(err) // should not happen, because CurvePointFromXAndSignYBit panics.
 			}
 
 			return
 		}
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	} else {
 		var P curvePoints.Point_axtw_full
-		P, errCurvePoint := curvePoints.CurvePointFromXAndSignY_full(&X, signInt, trustLevel)
+		P, errCurvePoint := curvePoints.CurvePointFromXAndSignYBit_full(&X, signBit, trustLevel)
 		if errCurvePoint != nil {
 			err = errorsWithData.NewErrorWithParametersFromData(errCurvePoint, "%w", &bandersnatchErrors.ReadErrorData{
 				PartialRead:  false,
@@ -320,15 +538,26 @@ func (s *pointSerializerXAndSignY) DeserializeCurvePoint(input io.Reader, trustL
 				ActuallyRead: nil,
 			})
 			if trustLevel.Bool() {
-				panic(err) // should not happen, because CurvePointFromXAndSignY panics.
+				panic(err) // should not happen, because CurvePointFromXAndSignYBit panics.
 			}
 			return
 		}
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	}
 	return
 }
 
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerXAndSignY) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
 // Clone creates an independent copy of the received serializer, returning a pointer.
 //
 // Note that since serializers are immutable, library users should never need to call this;
@@ -342,7 +571,7 @@ func (s *pointSerializerXAndSignY) Clone() (ret *pointSerializerXAndSignY) {
 // WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
 // Invalid input cause a panic.
 //
-// Recognized params are: "Endianness", "SubgroupOnly"
+// Recognized params are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder"
 func (s *pointSerializerXAndSignY) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerXAndSignY) {
 	return makeCopyWithParameters(s, param, newParam)
 }
@@ -362,7 +591,7 @@ func (s *pointSerializerXAndSignY) OutputLength() int32 { return 32 }
 
 // GetParameter returns the value of the internal parameter determined by parameterName
 //
-// recognized parameterNames are: "Endianness", "SubgroupOnly".
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder".
 // GetParameter returns the value of the given parameterName.
 //
 // Accepted values for parameterName are "Endiannness", "SubgroupOnly"
@@ -378,11 +607,13 @@ func (s *pointSerializerXAndSignY) GetParameter(parameterName string) interface{
 func (s *pointSerializerXAndSignY) Validate() {
 	s.valuesSerializerFeCompressedBit.Validate()
 	s.fieldElementEndianness.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
 }
 
 // RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
 func (s *pointSerializerXAndSignY) RecognizedParameters() []string {
-	return concatParameterList(s.valuesSerializerFeCompressedBit.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters())
+	return concatParameterList(s.valuesSerializerFeCompressedBit.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
 }
 
 // HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
@@ -393,9 +624,13 @@ func (s *pointSerializerXAndSignY) HasParameter(parameterName string) bool {
 // ***********************************************************************************************************************************************************
 
 // pointSerializerYAndSignX serializes a point via its Y coordinate and the sign of X. (For X==0, we do not set the sign bit)
+//
+// As for pointSerializerXAndSignY, the sign bit is the msb of Y (as a 256-bit number), i.e. the top bit of the last byte for little endian and of the first byte for big endian.
 type pointSerializerYAndSignX struct {
 	valuesSerializerFeCompressedBit
 	subgroupRestriction
+	identityRejection
+	orderRequirement
 }
 
 // Validate perfoms a self-check of the internal parameters stored for the given serializer.
@@ -406,6 +641,8 @@ type pointSerializerYAndSignX struct {
 func (s *pointSerializerYAndSignX) Validate() {
 	s.valuesSerializerFeCompressedBit.Validate()
 	s.subgroupRestriction.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
 }
 
 // SerializeCurvePoint writes a single curve point to the given output.
@@ -430,6 +667,7 @@ func (s *pointSerializerYAndSignX) SerializeCurvePoint(output io.Writer, point c
 //
 // The format expected is Sign(X)||Y, where Sign(X) is a bit (0b1 iff X<0) stored inside the msb of Y for compression.
 func (s *pointSerializerYAndSignX) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
 	var Y fieldElements.FieldElement
 	var signBit bool
 	bytesRead, err, Y, signBit = s.DeserializeValues(input)
@@ -470,6 +708,12 @@ func (s *pointSerializerYAndSignX) DeserializeCurvePoint(input io.Reader, trustL
 			return
 		}
 
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	} else { // No subgroup check, we deserialize a point from the whole group.
 		var P curvePoints.Point_axtw_full
@@ -493,11 +737,22 @@ func (s *pointSerializerYAndSignX) DeserializeCurvePoint(input io.Reader, trustL
 				return
 			}
 		}
+		if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+			return
+		}
+		if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+			return
+		}
 		point.SetFrom(&P)
 	}
 	return
 }
 
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerYAndSignX) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
 // Clone creates an independent copy of the received serializer, returning a pointer.
 //
 // Note that since serializers are immutable, library users should never need to call this;
@@ -506,13 +761,15 @@ func (s *pointSerializerYAndSignX) Clone() (ret *pointSerializerYAndSignX) {
 	var sCopy pointSerializerYAndSignX
 	sCopy.fieldElementEndianness = s.fieldElementEndianness
 	sCopy.subgroupRestriction = s.subgroupRestriction
+	sCopy.identityRejection = s.identityRejection
+	sCopy.orderRequirement = s.orderRequirement
 	ret = &sCopy
 	return
 }
 
 // WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
 //
-// Recognized params are: "Endianness", "SubgroupOnly"
+// Recognized params are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder"
 func (s *pointSerializerYAndSignX) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerYAndSignX) {
 	return makeCopyWithParameters(s, param, newParam)
 }
@@ -532,14 +789,14 @@ func (s *pointSerializerYAndSignX) OutputLength() int32 { return 32 }
 
 // GetParameter returns the value of the internal parameter determined by parameterName
 //
-// recognized parameterNames are: "Endianness", "SubgroupOnly".
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder".
 func (s *pointSerializerYAndSignX) GetParameter(parameterName string) interface{} {
 	return getSerializerParameter(s, parameterName)
 }
 
 // RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
 func (s *pointSerializerYAndSignX) RecognizedParameters() []string {
-	return concatParameterList(s.valuesSerializerFeCompressedBit.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters())
+	return concatParameterList(s.valuesSerializerFeCompressedBit.RecognizedParameters(), s.subgroupRestriction.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
 }
 
 // HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
@@ -555,6 +812,8 @@ func (s *pointSerializerYAndSignX) HasParameter(parameterName string) bool {
 type pointSerializerXTimesSignY struct {
 	valuesSerializerHeaderFe
 	subgroupOnly
+	identityRejection
+	orderRequirement
 }
 
 // Validate perfoms a self-check of the internal parameters stored for the given serializer.
@@ -565,6 +824,8 @@ type pointSerializerXTimesSignY struct {
 func (s *pointSerializerXTimesSignY) Validate() {
 	s.valuesSerializerHeaderFe.Validate()
 	s.subgroupOnly.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
 }
 
 // SerializeCurvePoint writes a single curve point to the given output.
@@ -593,9 +854,22 @@ func (s *pointSerializerXTimesSignY) SerializeCurvePoint(output io.Writer, point
 //
 // The format expected is X*Sign(Y), where Sign(Y) is +1 or -1.
 func (s *pointSerializerXTimesSignY) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
+	buf, err := readFullCheckAllZero(input, int(s.OutputLength()))
+	if err != nil {
+		bytesRead = err.GetData().BytesRead
+		return
+	}
 	var XSignY fieldElements.FieldElement
-	bytesRead, err, XSignY = s.DeserializeValues(input)
+	_, err, XSignY = s.DeserializeValues(bytes.NewReader(buf))
+	bytesRead = len(buf)
 	if err != nil {
+		// parsing may have stopped early, but we already consumed all of buf from input.
+		err = errorsWithData.IncludeDataInError(err, &bandersnatchErrors.ReadErrorData{
+			PartialRead:  false,
+			BytesRead:    bytesRead,
+			ActuallyRead: buf,
+		})
 		return
 	}
 	var P curvePoints.Point_axtw_subgroup
@@ -611,10 +885,21 @@ func (s *pointSerializerXTimesSignY) DeserializeCurvePoint(input io.Reader, trus
 		}
 		return
 	}
+	if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+		return
+	}
+	if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+		return
+	}
 	point.SetFrom(&P)
 	return
 }
 
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerXTimesSignY) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
 // Clone creates an independent copy of the received serializer, returning a pointer.
 //
 // Note that since serializers are immutable, library users should never need to call this;
@@ -626,7 +911,7 @@ func (s *pointSerializerXTimesSignY) Clone() (ret *pointSerializerXTimesSignY) {
 
 // WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
 //
-// Recognized params are: "Endianness", "SubgroupOnly"
+// Recognized params are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder"
 // Note that "SubgroupOnly" only accepts true.
 func (s *pointSerializerXTimesSignY) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerXTimesSignY) {
 	return makeCopyWithParameters(s, param, newParam)
@@ -647,14 +932,14 @@ func (s *pointSerializerXTimesSignY) OutputLength() int32 { return 32 }
 
 // GetParameter returns the value of the internal parameter determined by parameterName
 //
-// recognized parameterNames are: "Endianness", "SubgroupOnly".
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder".
 func (s *pointSerializerXTimesSignY) GetParameter(parameterName string) interface{} {
 	return getSerializerParameter(s, parameterName)
 }
 
 // RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
 func (s *pointSerializerXTimesSignY) RecognizedParameters() []string {
-	return concatParameterList(s.valuesSerializerHeaderFe.RecognizedParameters(), s.subgroupOnly.RecognizedParameters())
+	return concatParameterList(s.valuesSerializerHeaderFe.RecognizedParameters(), s.subgroupOnly.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
 }
 
 // HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
@@ -670,6 +955,8 @@ func (s *pointSerializerXTimesSignY) HasParameter(parameterName string) bool {
 type pointSerializerYXTimesSignY struct {
 	valuesSerializerHeaderFeHeaderFe
 	subgroupOnly
+	identityRejection
+	orderRequirement
 }
 
 // Validate perfoms a self-check of the internal parameters stored for the given serializer.
@@ -680,6 +967,8 @@ type pointSerializerYXTimesSignY struct {
 func (s *pointSerializerYXTimesSignY) Validate() {
 	s.valuesSerializerHeaderFeHeaderFe.Validate()
 	s.subgroupOnly.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
 }
 
 // SerializeCurvePoint writes a single curve point to the given output.
@@ -709,6 +998,7 @@ func (s *pointSerializerYXTimesSignY) SerializeCurvePoint(output io.Writer, poin
 //
 // The format expected is Y*Sign(Y)||X*Sign(Y), with Sign(Y)=+1 or -1.
 func (s *pointSerializerYXTimesSignY) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
 	var XSignY, YSignY fieldElements.FieldElement
 	bytesRead, err, YSignY, XSignY = s.DeserializeValues(input)
 	if err != nil {
@@ -728,6 +1018,12 @@ func (s *pointSerializerYXTimesSignY) DeserializeCurvePoint(input io.Reader, tru
 		}
 		return
 	}
+	if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+		return
+	}
+	if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+		return
+	}
 	point.SetFrom(&P)
 	/*
 		-- removed : P's type ensures this
@@ -743,6 +1039,11 @@ func (s *pointSerializerYXTimesSignY) DeserializeCurvePoint(input io.Reader, tru
 	return
 }
 
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerYXTimesSignY) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
 // Clone creates an independent copy of the received serializer, returning a pointer.
 //
 // Note that since serializers are immutable, library users should never need to call this;
@@ -755,7 +1056,7 @@ func (s *pointSerializerYXTimesSignY) Clone() (ret *pointSerializerYXTimesSignY)
 
 // WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
 //
-// Recognized params are: "Endianness", "SubgroupOnly"
+// Recognized params are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder"
 // Note that SubgroupOnly only accepts true.
 func (s *pointSerializerYXTimesSignY) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerYXTimesSignY) {
 	return makeCopyWithParameters(s, param, newParam)
@@ -776,14 +1077,14 @@ func (s *pointSerializerYXTimesSignY) OutputLength() int32 { return 64 }
 
 // GetParameter returns the value of the internal parameter determined by parameterName
 //
-// recognized parameterNames are: "Endianness", "SubgroupOnly".
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder".
 func (s *pointSerializerYXTimesSignY) GetParameter(parameterName string) interface{} {
 	return getSerializerParameter(s, parameterName)
 }
 
 // RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
 func (s *pointSerializerYXTimesSignY) RecognizedParameters() []string {
-	return concatParameterList(s.valuesSerializerHeaderFeHeaderFe.RecognizedParameters(), s.subgroupOnly.RecognizedParameters())
+	return concatParameterList(s.valuesSerializerHeaderFeHeaderFe.RecognizedParameters(), s.subgroupOnly.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
 }
 
 // HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
@@ -3,9 +3,12 @@ package pointserializer
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"math/bits"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -13,6 +16,8 @@ import (
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/callcounters"
 	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 	"github.com/GottfriedHerold/Bandersnatch/internal/utils"
 )
@@ -37,11 +42,11 @@ var _ modifyableSerializer[pointSerializerYXTimesSignY, *pointSerializerYXTimesS
 
 var testBitHeader = common.MakeBitHeader(common.PrefixBits(0b1), 1)
 
-var ps_XY = pointSerializerXY{valuesSerializerHeaderFeHeaderFe{fieldElementEndianness: defaultEndianness, bitHeader: testBitHeader}, subgroupRestriction{}}
+var ps_XY = pointSerializerXY{valuesSerializerHeaderFeHeaderFe{fieldElementEndianness: defaultEndianness, bitHeader: testBitHeader}, subgroupRestriction{}, identityRejection{}, orderRequirement{}}
 var ps_XY_sub = ps_XY.WithParameter("SubgroupOnly", true)
-var ps_XSY = pointSerializerXAndSignY{valuesSerializerFeCompressedBit{fieldElementEndianness: defaultEndianness}, subgroupRestriction{}}
+var ps_XSY = pointSerializerXAndSignY{valuesSerializerFeCompressedBit{fieldElementEndianness: defaultEndianness}, subgroupRestriction{}, identityRejection{}, orderRequirement{}}
 var ps_XSY_sub = ps_XSY.WithParameter("SubgroupOnly", true)
-var ps_YSX = pointSerializerYAndSignX{valuesSerializerFeCompressedBit{fieldElementEndianness: defaultEndianness}, subgroupRestriction{}}
+var ps_YSX = pointSerializerYAndSignX{valuesSerializerFeCompressedBit{fieldElementEndianness: defaultEndianness}, subgroupRestriction{}, identityRejection{}, orderRequirement{}}
 var ps_YSX_sub = ps_YSX.WithParameter("SubgroupOnly", true)
 var ps_XxSY = basicBanderwagonShort
 var ps_XYxSY = basicBanderwagonLong
@@ -257,3 +262,533 @@ func TestBasicSerializersRoundtrip(t *testing.T) {
 	}
 
 }
+
+func TestBasicSerializersIsCanonical(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1025))
+	for _, basicSerializer := range allBasicSerializers {
+		serializerName := testutils.GetReflectName(reflect.TypeOf(basicSerializer))
+		for i := 0; i < 10; i++ {
+			var inputPoint curvePoints.CurvePointPtrInterface
+			if basicSerializer.IsSubgroupOnly() {
+				var point curvePoints.Point_xtw_subgroup = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+				inputPoint = &point
+			} else {
+				var point curvePoints.Point_xtw_full = curvePoints.MakeRandomPointUnsafe_xtw_full(drng)
+				inputPoint = &point
+			}
+			var buf bytes.Buffer
+			var err error
+			_, err = basicSerializer.SerializeCurvePoint(&buf, inputPoint)
+			if err != nil {
+				t.Fatalf("Unexpected serialization error for %v: %v", serializerName, err)
+			}
+			encoding := buf.Bytes()
+			ok, err := basicSerializer.IsCanonical(encoding)
+			if err != nil {
+				t.Fatalf("IsCanonical returned unexpected error for %v: %v", serializerName, err)
+			}
+			if !ok {
+				t.Fatalf("IsCanonical did not accept canonical encoding for %v", serializerName)
+			}
+			// trailing data is not canonical
+			ok, err = basicSerializer.IsCanonical(append(copyByteSlice(encoding), 0))
+			if err != nil {
+				t.Fatalf("IsCanonical returned unexpected error for %v on input with trailing data: %v", serializerName, err)
+			}
+			if ok {
+				t.Fatalf("IsCanonical accepted encoding with trailing data for %v", serializerName)
+			}
+		}
+		// truncated data is an error
+		ok, err := basicSerializer.IsCanonical(make([]byte, basicSerializer.OutputLength()-1))
+		if err == nil || ok {
+			t.Fatalf("IsCanonical did not report error on truncated input for %v", serializerName)
+		}
+	}
+
+	// Create a non-normalized encoding of the neutral element for ps_XSY: X == 0 gets replaced by X == BaseFieldSize.
+	var neutral curvePoints.Point_xtw_full
+	neutral.SetNeutral()
+	var buf bytes.Buffer
+	var err error
+	_, err = ps_XSY.SerializeCurvePoint(&buf, &neutral)
+	if err != nil {
+		t.Fatalf("Unexpected serialization error: %v", err)
+	}
+	encoding := buf.Bytes()
+	var fieldSizeBytes [32]byte
+	common.BaseFieldSize_Int.FillBytes(fieldSizeBytes[:])
+	fieldSizeWords := common.BigEndian.Uint256(fieldSizeBytes[:])
+	encodingWords := ps_XSY.GetEndianness().Uint256(encoding)
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		encodingWords[i], carry = bits.Add64(encodingWords[i], fieldSizeWords[i], carry)
+	}
+	testutils.Assert(carry == 0)
+	nonCanonical := make([]byte, 32)
+	ps_XSY.GetEndianness().PutUint256(nonCanonical, encodingWords)
+
+	// sanity check: the modified encoding still deserializes to the neutral element, modulo reporting non-normalization.
+	var P curvePoints.Point_xtw_full
+	_, err = ps_XSY.DeserializeCurvePoint(bytes.NewReader(nonCanonical), common.UntrustedInput, &P)
+	if !errors.Is(err, fieldElements.ErrNonNormalizedDeserialization) {
+		t.Fatalf("Test setup is broken: Expected non-normalized deserialization, got error %v", err)
+	}
+
+	ok, err := ps_XSY.IsCanonical(encoding)
+	if err != nil || !ok {
+		t.Fatalf("IsCanonical did not accept canonical encoding of neutral element: %v", err)
+	}
+	ok, err = ps_XSY.IsCanonical(nonCanonical)
+	if err != nil {
+		t.Fatalf("IsCanonical returned error on non-normalized input: %v", err)
+	}
+	if ok {
+		t.Fatalf("IsCanonical accepted non-normalized field element")
+	}
+}
+
+// The hex-string conversion functions of the curvePoints package are documented to use the short Banderwagon format. We check that this matches basicBanderwagonShort.
+func TestHexStringMatchesBanderwagonShort(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(666))
+	for i := 0; i < 20; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var buf bytes.Buffer
+		_, err := basicBanderwagonShort.SerializeCurvePoint(&buf, &P)
+		testutils.FatalUnless(t, err == nil, "Serialization failed %v", err)
+		s, err2 := P.ToHexString()
+		testutils.FatalUnless(t, err2 == nil, "ToHexString failed %v", err2)
+		testutils.FatalUnless(t, s == hex.EncodeToString(buf.Bytes()), "ToHexString does not match basicBanderwagonShort")
+	}
+}
+
+func TestDecafEncodingMatchesBanderwagonShort(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(667))
+	for i := 0; i < 20; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		var buf bytes.Buffer
+		_, err := basicBanderwagonShort.SerializeCurvePoint(&buf, &P)
+		testutils.FatalUnless(t, err == nil, "Serialization failed %v", err)
+		encoding := curvePoints.DecafEncode(&P)
+		testutils.FatalUnless(t, bytes.Equal(encoding[:], buf.Bytes()), "DecafEncode does not match basicBanderwagonShort")
+		Q, errDecode := curvePoints.DecafDecode(encoding, common.UntrustedInput)
+		testutils.FatalUnless(t, errDecode == nil, "DecafDecode failed %v", errDecode)
+		testutils.FatalUnless(t, Q.IsEqual(&P), "DecafDecode did not round-trip")
+
+		var arr [32]byte
+		errEncode := P.EncodeTo(&arr)
+		testutils.FatalUnless(t, errEncode == nil, "EncodeTo failed %v", errEncode)
+		testutils.FatalUnless(t, bytes.Equal(arr[:], buf.Bytes()), "EncodeTo does not match basicBanderwagonShort")
+		var R curvePoints.Point_xtw_subgroup
+		errDecode = R.DecodeFrom(&arr, common.UntrustedInput)
+		testutils.FatalUnless(t, errDecode == nil, "DecodeFrom failed %v", errDecode)
+		testutils.FatalUnless(t, R.IsEqual(&P), "DecodeFrom did not round-trip")
+	}
+}
+
+func TestNeutralEncoding(t *testing.T) {
+	for _, basicSerializer := range allBasicSerializers {
+		encoding, err := NeutralEncoding(basicSerializer)
+		testutils.FatalUnless(t, err == nil, "NeutralEncoding failed for %T: %v", basicSerializer, err)
+		testutils.FatalUnless(t, len(encoding) == int(basicSerializer.OutputLength()), "")
+		var P curvePoints.Point_xtw_full
+		_, errDeserialize := basicSerializer.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &P)
+		testutils.FatalUnless(t, errDeserialize == nil, "Could not deserialize neutral encoding for %T: %v", basicSerializer, errDeserialize)
+		testutils.FatalUnless(t, P.IsNeutralElement(), "NeutralEncoding does not deserialize to the neutral element for %T", basicSerializer)
+		ok, _ := basicSerializer.IsCanonical(encoding)
+		testutils.FatalUnless(t, ok, "NeutralEncoding is not canonical for %T", basicSerializer)
+	}
+
+	// For the Banderwagon formats, the neutral element is not encoded as all-zeros.
+	encoding, _ := NeutralEncoding(&basicBanderwagonShort)
+	testutils.FatalUnless(t, !bytes.Equal(encoding, make([]byte, 32)), "")
+}
+
+// subgroupCheckRecordingPoint wraps a Point_axtw_subgroup and records calls to IsInSubgroup.
+type subgroupCheckRecordingPoint struct {
+	*curvePoints.Point_axtw_subgroup
+	subgroupChecks *int
+}
+
+func (p subgroupCheckRecordingPoint) IsInSubgroup() bool {
+	*p.subgroupChecks++
+	return p.Point_axtw_subgroup.IsInSubgroup()
+}
+
+func TestBasicSerializersSkipSubgroupCheck(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	var P curvePoints.Point_axtw_subgroup
+	Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	P.SetFrom(&Q)
+	var subgroupChecks int
+	recordingPoint := subgroupCheckRecordingPoint{Point_axtw_subgroup: &P, subgroupChecks: &subgroupChecks}
+	for _, basicSerializer := range allSubgroupOnlySerializers {
+		var buf bytes.Buffer
+		_, err := basicSerializer.SerializeCurvePoint(&buf, recordingPoint)
+		testutils.FatalUnless(t, err == nil, "Serializing subgroup point failed for %T: %v", basicSerializer, err)
+	}
+	testutils.FatalUnless(t, subgroupChecks == 0, "Serializing a Point_axtw_subgroup called IsInSubgroup %v times", subgroupChecks)
+
+	// If call counters are active, we also verify that no Jacobi symbol is computed at all.
+	if fieldElements.CallCountersActive {
+		callcounters.ResetAllCounters()
+		for _, basicSerializer := range allSubgroupOnlySerializers {
+			var buf bytes.Buffer
+			_, err := basicSerializer.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, err == nil, "Serializing subgroup point failed for %T: %v", basicSerializer, err)
+		}
+		jacobiCalls, _ := callcounters.Id("Jacobi").Get()
+		testutils.FatalUnless(t, jacobiCalls == 0, "Serializing a Point_axtw_subgroup computed %v Jacobi symbols", jacobiCalls)
+	}
+}
+
+func TestBasicSerializersRejectIdentity(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, basicSerializer := range allBasicSerializers {
+		testutils.FatalUnless(t, basicSerializer.GetParameter("RejectIdentity") == false, "RejectIdentity is not false by default for %T", basicSerializer)
+		rejecting := withParameterBasic(basicSerializer, "RejectIdentity", true)
+		testutils.FatalUnless(t, rejecting.GetParameter("rejectidentity") == true, "")
+		testutils.FatalUnless(t, basicSerializer.GetParameter("RejectIdentity") == false, "WithParameter modified the original serializer")
+
+		neutralEncoding, errEncoding := NeutralEncoding(basicSerializer)
+		testutils.FatalUnless(t, errEncoding == nil, "")
+
+		// accepted by default
+		var P curvePoints.Point_xtw_full
+		_, err := basicSerializer.DeserializeCurvePoint(bytes.NewReader(neutralEncoding), common.UntrustedInput, &P)
+		testutils.FatalUnless(t, err == nil, "Neutral element not accepted by default for %T: %v", basicSerializer, err)
+		testutils.FatalUnless(t, P.IsNeutralElement(), "")
+
+		// rejected with RejectIdentity set, for any target type and trust level.
+		for _, trustLevel := range []common.IsInputTrusted{common.UntrustedInput, common.TrustedInput} {
+			for _, target := range []curvePoints.CurvePointPtrInterface{&curvePoints.Point_xtw_full{}, &curvePoints.Point_xtw_subgroup{}, &curvePoints.Point_axtw_subgroup{}} {
+				Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+				target.SetFrom(&Q)
+				bytesRead, err := rejecting.DeserializeCurvePoint(bytes.NewReader(neutralEncoding), trustLevel, target)
+				testutils.FatalUnless(t, errors.Is(err, ErrIdentityRejected), "Neutral element was not rejected for %T. Got error %v", basicSerializer, err)
+				testutils.FatalUnless(t, bytesRead == int(rejecting.OutputLength()), "")
+				testutils.FatalUnless(t, target.IsEqual(&Q), "Target point was modified on error")
+			}
+		}
+
+		// other points are still accepted.
+		Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var buf bytes.Buffer
+		_, errSerialize := rejecting.SerializeCurvePoint(&buf, &Q)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		var R curvePoints.Point_xtw_subgroup
+		_, err = rejecting.DeserializeCurvePoint(&buf, common.UntrustedInput, &R)
+		testutils.FatalUnless(t, err == nil, "Deserialization of non-neutral point failed with RejectIdentity for %T: %v", basicSerializer, err)
+		testutils.FatalUnless(t, R.IsEqual(&Q), "")
+	}
+}
+
+func TestBasicSerializersRequireOrder(t *testing.T) {
+	var generator curvePoints.Point_xtw_full
+	generator.SetFrom(&curvePoints.SubgroupGenerator_xtw_subgroup)
+	// generator + affine 2-torsion point has order 2*GroupOrder
+	var tainted curvePoints.Point_xtw_full
+	tainted.Add(&generator, &curvePoints.AffineOrderTwoPoint_xtw)
+	testutils.FatalUnless(t, tainted.Order().Cmp(new(big.Int).Lsh(curvePoints.GroupOrder_Int, 1)) == 0, "")
+
+	for _, basicSerializer := range allBasicSerializers {
+		testutils.FatalUnless(t, basicSerializer.GetParameter("RequireOrder").(*big.Int) == nil, "RequireOrder is not nil by default for %T", basicSerializer)
+		requiring := withParameterBasic(basicSerializer, "RequireOrder", curvePoints.GroupOrder_Int)
+		testutils.FatalUnless(t, requiring.GetParameter("requireorder").(*big.Int).Cmp(curvePoints.GroupOrder_Int) == 0, "")
+		testutils.FatalUnless(t, basicSerializer.GetParameter("RequireOrder").(*big.Int) == nil, "WithParameter modified the original serializer")
+
+		// the subgroup generator is accepted
+		var buf bytes.Buffer
+		_, errSerialize := requiring.SerializeCurvePoint(&buf, &generator)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		var P curvePoints.Point_xtw_full
+		_, err := requiring.DeserializeCurvePoint(&buf, common.UntrustedInput, &P)
+		testutils.FatalUnless(t, err == nil, "Subgroup generator rejected with RequireOrder for %T: %v", basicSerializer, err)
+		testutils.FatalUnless(t, P.IsEqual(&generator), "")
+
+		// requiring a different order rejects the generator
+		requiringTwo := withParameterBasic(basicSerializer, "RequireOrder", big.NewInt(2))
+		buf.Reset()
+		_, errSerialize = requiringTwo.SerializeCurvePoint(&buf, &generator)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		_, err = requiringTwo.DeserializeCurvePoint(&buf, common.UntrustedInput, &P)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongOrder), "Point of wrong order was not rejected for %T. Got error %v", basicSerializer, err)
+
+		// invalid orders are rejected when setting the parameter
+		didPanic := testutils.CheckPanic(withParameterBasic, basicSerializer, "RequireOrder", big.NewInt(0))
+		testutils.FatalUnless(t, didPanic, "Setting RequireOrder to 0 did not panic for %T", basicSerializer)
+	}
+
+	for _, basicSerializer := range allSerializersWithModifyableSubgroupOnly {
+		requiring := withParameterBasic(basicSerializer, "RequireOrder", curvePoints.GroupOrder_Int)
+		var buf bytes.Buffer
+		_, errSerialize := requiring.SerializeCurvePoint(&buf, &tainted)
+		testutils.FatalUnless(t, errSerialize == nil, "")
+		encoding := buf.Bytes()
+
+		// accepted without the order requirement
+		var P curvePoints.Point_xtw_full
+		_, err := basicSerializer.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &P)
+		testutils.FatalUnless(t, err == nil, "Point of order 2*GroupOrder not accepted by default for %T: %v", basicSerializer, err)
+
+		// rejected with the order requirement, target untouched
+		P = generator
+		bytesRead, err := requiring.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &P)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongOrder), "Point of order 2*GroupOrder was not rejected for %T. Got error %v", basicSerializer, err)
+		testutils.FatalUnless(t, bytesRead == int(requiring.OutputLength()), "")
+		testutils.FatalUnless(t, P.IsEqual(&generator), "Target point was modified on error")
+	}
+}
+
+// TestSerializerRoundTripMatrix checks that deserialize(serialize(P)) == P for every combination of
+// (basic serializer, type of P, type of the deserialization target) and for random subgroup points, random points outside the subgroup, the neutral element and the points at infinity.
+//
+// Combinations that are structurally impossible must fail with an error rather than round-trip incorrectly:
+//   - serializing a point outside the subgroup with a serializer that cannot represent it,
+//   - serializing a point at infinity with a serializer that cannot represent it,
+//   - deserializing a point outside the subgroup into a type that can only represent subgroup elements,
+//   - deserializing a point at infinity into a type that cannot represent it.
+func TestSerializerRoundTripMatrix(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+
+	// point types that we use both as the source for serialization and as target for deserialization.
+	pointTypes := []func() curvePoints.CurvePointPtrInterface{
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_xtw_full{} },
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_xtw_subgroup{} },
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_axtw_full{} },
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_axtw_subgroup{} },
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_efgh_full{} },
+		func() curvePoints.CurvePointPtrInterface { return &curvePoints.Point_efgh_subgroup{} },
+	}
+
+	serializers := append([]curvePointSerializer_basic{}, allBasicSerializers...)
+	serializers = append(serializers,
+		&pointSerializerFlagged{},
+		&pointSerializerMapToField{valuesSerializerFe: valuesSerializerFe{fieldElementEndianness: common.DefaultEndian}},
+		NewChecksumSerializer(&ps_XY, nil),
+		NewDomainTagSerializer(&ps_XxSY, []byte("tag")),
+	)
+
+	sourcePoints := []curvePoints.Point_xtw_full{curvePoints.NeutralElement_xtw_full, curvePoints.InfinitePoint1_xtw, curvePoints.InfinitePoint2_xtw, curvePoints.AffineOrderTwoPoint_xtw}
+	for i := 0; i < 5; i++ {
+		var P curvePoints.Point_xtw_full
+		subgroupPoint := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		P.SetFrom(&subgroupPoint)
+		sourcePoints = append(sourcePoints, P, curvePoints.MakeRandomPointUnsafe_xtw_full(drng))
+	}
+
+	for _, serializer := range serializers {
+		for _, P := range sourcePoints {
+			for _, makeSource := range pointTypes {
+				source := makeSource()
+				if _, canRepresentInfinity := source.(curvePoints.CurvePointPtrInterfaceDistinguishInfinity); P.IsAtInfinity() && !canRepresentInfinity {
+					continue // affine types cannot store points at infinity
+				}
+				if source.CanOnlyRepresentSubgroup() {
+					if !P.IsInSubgroup() {
+						continue // P cannot be stored in source
+					}
+					source.SetFromSubgroupPoint(&P, common.TrustedInput)
+				} else {
+					source.SetFrom(&P)
+				}
+
+				var buf bytes.Buffer
+				bytesWritten, errSerialize := serializer.SerializeCurvePoint(&buf, source)
+				if errSerialize != nil {
+					impossible := P.IsAtInfinity() || !P.IsInSubgroup()
+					testutils.FatalUnless(t, impossible, "%T failed to serialize %T %v: %v", serializer, source, P, errSerialize)
+					testutils.FatalUnless(t, errors.Is(errSerialize, bandersnatchErrors.ErrCannotSerializePointAtInfinity) || errors.Is(errSerialize, bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup),
+						"%T failed to serialize %T %v with unexpected error %v", serializer, source, P, errSerialize)
+					continue
+				}
+				testutils.FatalUnless(t, bytesWritten == int(serializer.OutputLength()) && buf.Len() == bytesWritten, "")
+				encoding := buf.Bytes()
+
+				for _, makeTarget := range pointTypes {
+					target := makeTarget()
+					bytesRead, errDeserialize := serializer.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, target)
+					if target.CanOnlyRepresentSubgroup() && !P.IsInSubgroup() {
+						testutils.FatalUnless(t, errDeserialize != nil, "%T deserialized %v into %T, which cannot represent it", serializer, P, target)
+						continue
+					}
+					if _, canRepresentInfinity := target.(curvePoints.CurvePointPtrInterfaceDistinguishInfinity); P.IsAtInfinity() && !canRepresentInfinity {
+						testutils.FatalUnless(t, errDeserialize != nil, "%T deserialized %v into %T, which cannot represent it", serializer, P, target)
+						continue
+					}
+					testutils.FatalUnless(t, errDeserialize == nil, "%T failed to deserialize %v (serialized from %T) into %T: %v", serializer, P, source, target, errDeserialize)
+					testutils.FatalUnless(t, bytesRead == bytesWritten, "")
+					testutils.FatalUnless(t, target.IsEqual(&P), "Round trip for %T with source %T and target %T failed for %v", serializer, source, target, P)
+				}
+			}
+		}
+	}
+}
+
+func TestBanderwagonShortAllZeroEncoding(t *testing.T) {
+	var P curvePoints.Point_xtw_subgroup
+	P.SetFrom(&curvePoints.SubgroupGenerator_xtw_subgroup)
+	PCopy := P
+
+	allZero := make([]byte, 32)
+	bytesRead, err := basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(allZero), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, errors.Is(err, ErrAllZeroEncoding), "All-zero input did not give ErrAllZeroEncoding. Got %v", err)
+	testutils.FatalUnless(t, !errors.Is(err, bandersnatchErrors.ErrNotOnCurve), "")
+	testutils.FatalUnless(t, bytesRead == 32, "")
+	testutils.FatalUnless(t, err.GetData().BytesRead == 32 && bytes.Equal(err.GetData().ActuallyRead, allZero), "")
+	testutils.FatalUnless(t, P.IsEqual(&PCopy), "Target point was modified on error")
+	testutils.FatalUnless(t, ClassifyDeserializationError(err) == DeserErrorMalformedEncoding, "")
+
+	// the genuine encoding of the neutral element is accepted
+	neutralEncoding, errEncoding := NeutralEncoding(&basicBanderwagonShort)
+	testutils.FatalUnless(t, errEncoding == nil, "")
+	_, err = basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(neutralEncoding), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, err == nil, "Neutral encoding not accepted: %v", err)
+	testutils.FatalUnless(t, P.IsNeutralElement(), "")
+
+	// other inputs with a wrong header are not reported as all-zero
+	almostZero := make([]byte, 32)
+	almostZero[0] = 1
+	_, err = basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(almostZero), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, err != nil && !errors.Is(err, ErrAllZeroEncoding), "Input with non-zero byte reported as all-zero. Got %v", err)
+
+	// short input is an io error, not an all-zero error
+	_, err = basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(make([]byte, 10)), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, err != nil && !errors.Is(err, ErrAllZeroEncoding), "Short input reported as all-zero. Got %v", err)
+
+	// with big endian, parsing a field element stops after the first byte if the bit header does not match; all-zero input must still be detected.
+	bigEndian := basicBanderwagonShort.WithEndianness(common.BigEndian)
+	P = PCopy
+	bytesRead, err = bigEndian.DeserializeCurvePoint(bytes.NewReader(allZero), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, errors.Is(err, ErrAllZeroEncoding), "All-zero input did not give ErrAllZeroEncoding for big endian. Got %v", err)
+	testutils.FatalUnless(t, bytesRead == 32 && err.GetData().BytesRead == 32, "")
+	testutils.FatalUnless(t, P.IsEqual(&PCopy), "Target point was modified on error")
+	_, err = bigEndian.DeserializeCurvePoint(bytes.NewReader(almostZero), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, err != nil && !errors.Is(err, ErrAllZeroEncoding), "Input with non-zero byte reported as all-zero. Got %v", err)
+	testutils.FatalUnless(t, err.GetData().BytesRead == 32, "")
+}
+
+func TestXTWSerializeShortLongMatchesBanderwagon(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(668))
+	for i := 0; i < 20; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		var PFull curvePoints.Point_xtw_full
+		PFull.SetFrom(&P)
+
+		var expectedShort, expectedLong bytes.Buffer
+		_, err := basicBanderwagonShort.SerializeCurvePoint(&expectedShort, &P)
+		testutils.FatalUnless(t, err == nil, "Serialization failed %v", err)
+		_, err = basicBanderwagonLong.SerializeCurvePoint(&expectedLong, &P)
+		testutils.FatalUnless(t, err == nil, "Serialization failed %v", err)
+
+		for _, point := range []interface {
+			SerializeShort(io.Writer) (int, bandersnatchErrors.SerializationError)
+			SerializeLong(io.Writer) (int, bandersnatchErrors.SerializationError)
+		}{&P, &PFull} {
+			var buf bytes.Buffer
+			bytesWritten, err := point.SerializeShort(&buf)
+			testutils.FatalUnless(t, err == nil, "SerializeShort failed for %T: %v", point, err)
+			testutils.FatalUnless(t, bytesWritten == 32, "SerializeShort wrote %v bytes for %T", bytesWritten, point)
+			testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expectedShort.Bytes()), "SerializeShort does not match basicBanderwagonShort for %T", point)
+
+			buf.Reset()
+			bytesWritten, err = point.SerializeLong(&buf)
+			testutils.FatalUnless(t, err == nil, "SerializeLong failed for %T: %v", point, err)
+			testutils.FatalUnless(t, bytesWritten == 64, "SerializeLong wrote %v bytes for %T", bytesWritten, point)
+			testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expectedLong.Bytes()), "SerializeLong does not match basicBanderwagonLong for %T", point)
+		}
+
+		// failing writer in the middle of the long format
+		designatedErr := errors.New("some error")
+		_, err = P.SerializeLong(testutils.NewFaultyBuffer(40, designatedErr))
+		testutils.FatalUnless(t, errors.Is(err, designatedErr), "")
+		testutils.FatalUnless(t, err.GetData().PartialWrite, "")
+	}
+
+	var NaP curvePoints.Point_xtw_full
+	var buf bytes.Buffer
+	_, err := NaP.SerializeShort(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "SerializeShort on NaP gave %v", err)
+	_, err = NaP.SerializeLong(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "SerializeLong on NaP gave %v", err)
+
+	infinity := curvePoints.InfinitePoint1_xtw
+	_, err = infinity.SerializeShort(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity), "SerializeShort on point at infinity gave %v", err)
+	_, err = infinity.SerializeLong(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializePointAtInfinity), "SerializeLong on point at infinity gave %v", err)
+
+	A := curvePoints.AffineOrderTwoPoint_xtw
+	_, err = A.SerializeShort(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup), "SerializeShort on point outside subgroup gave %v", err)
+	testutils.FatalUnless(t, buf.Len() == 0, "Failed serialization wrote data")
+}
+
+// The sign bit of the compressed serializers is the msb of the 256-bit number, so it must end up in the last byte for little endian and in the first byte for big endian.
+// Since the bit sits at the top of the number, the two encodings of the same point are byte-reversals of each other; this is what other libraries expect.
+func TestSignBitPlacementRespectsEndianness(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, XAndSignY := range []bool{true, false} {
+		for i := 0; i < 20; i++ {
+			P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+			X, Y := P.XY_affine()
+			var coordinate *fieldElements.FieldElement
+			var signBit bool
+			if XAndSignY {
+				coordinate, signBit = &X, Y.Sign() < 0
+			} else {
+				coordinate, signBit = &Y, X.Sign() < 0
+			}
+
+			var encodings [2][]byte
+			for j, endianness := range []common.FieldElementEndianness{common.LittleEndian, common.BigEndian} {
+				var s curvePointSerializer_basic
+				if XAndSignY {
+					serializer := ps_XSY.WithEndianness(endianness)
+					s = &serializer
+				} else {
+					serializer := ps_YSX.WithEndianness(endianness)
+					s = &serializer
+				}
+
+				var buf bytes.Buffer
+				bytesWritten, errSerialize := s.SerializeCurvePoint(&buf, &P)
+				testutils.FatalUnless(t, errSerialize == nil, "%T failed to serialize: %v", s, errSerialize)
+				testutils.FatalUnless(t, bytesWritten == 32, "")
+				encoding := buf.Bytes()
+
+				var signByteIndex int
+				if endianness.StartsWithMSB() {
+					signByteIndex = 0
+				} else {
+					signByteIndex = 31
+				}
+				testutils.FatalUnless(t, (encoding[signByteIndex]&0x80 != 0) == signBit, "Sign bit for %T with endianness %v is not in the msb of byte %v", s, endianness, signByteIndex)
+
+				// Apart from the sign bit, we get the plain encoding of the coordinate.
+				var expected bytes.Buffer
+				_, errSerialize = coordinate.Serialize(&expected, endianness)
+				testutils.FatalUnless(t, errSerialize == nil, "")
+				encodingWithoutSign := append([]byte{}, encoding...)
+				encodingWithoutSign[signByteIndex] &= 0x7F
+				testutils.FatalUnless(t, bytes.Equal(encodingWithoutSign, expected.Bytes()), "Encoding of %T with endianness %v does not match the encoding of the coordinate", s, endianness)
+
+				var Q curvePoints.Point_xtw_subgroup
+				bytesRead, errDeserialize := s.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+				testutils.FatalUnless(t, errDeserialize == nil, "%T with endianness %v failed to deserialize: %v", s, endianness, errDeserialize)
+				testutils.FatalUnless(t, bytesRead == 32, "")
+				testutils.FatalUnless(t, Q.IsEqual(&P), "Roundtrip failed for %T with endianness %v", s, endianness)
+				encodings[j] = encoding
+			}
+			for k := 0; k < 32; k++ {
+				testutils.FatalUnless(t, encodings[0][k] == encodings[1][31-k], "Little and big endian encodings are not byte-reversals of each other")
+			}
+		}
+	}
+}
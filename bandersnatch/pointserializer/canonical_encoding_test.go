@@ -0,0 +1,74 @@
+package pointserializer
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// Point_xtw_subgroup internally works modulo the affine 2-torsion point A, so a point P may internally be stored as (the coordinates of) P+A.
+// The <foo>_decaf_<bar> accessors used by the serializers expose this choice. We make sure that this internal state does not leak into the serialized output.
+//
+// Since the flipped state cannot be produced reliably via the exported API, we simulate it with flippedDecafPoint.
+
+// flippedDecafPoint wraps a Point_xtw_subgroup P and behaves exactly like it, except that the <foo>_decaf_<bar> accessors return the coordinates of P+A.
+// Note that if P has coordinates X:Y:T:Z, then P+A has coordinates -X:-Y:T:Z.
+type flippedDecafPoint struct {
+	curvePoints.Point_xtw_subgroup
+}
+
+func (p *flippedDecafPoint) X_decaf_projective() curvePoints.FieldElement {
+	ret := p.Point_xtw_subgroup.X_decaf_projective()
+	ret.NegEq()
+	return ret
+}
+
+func (p *flippedDecafPoint) Y_decaf_projective() curvePoints.FieldElement {
+	ret := p.Point_xtw_subgroup.Y_decaf_projective()
+	ret.NegEq()
+	return ret
+}
+
+func (p *flippedDecafPoint) X_decaf_affine() curvePoints.FieldElement {
+	ret := p.Point_xtw_subgroup.X_decaf_affine()
+	ret.NegEq()
+	return ret
+}
+
+func (p *flippedDecafPoint) Y_decaf_affine() curvePoints.FieldElement {
+	ret := p.Point_xtw_subgroup.Y_decaf_affine()
+	ret.NegEq()
+	return ret
+}
+
+func TestSerializationIndependentOfDecafRepresentation(t *testing.T) {
+	serializers := []curvePointSerializer_basic{
+		&pointSerializerFlagged{},
+		NewChecksumSerializer(&basicBanderwagonShort, nil),
+		&pointSerializerMapToField{valuesSerializerFe: valuesSerializerFe{fieldElementEndianness: common.DefaultEndian}},
+	}
+	for _, s := range defaultSerializersByName {
+		serializers = append(serializers, s)
+	}
+
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		PFlipped := flippedDecafPoint{Point_xtw_subgroup: P}
+		for _, s := range serializers {
+			var buf, bufFlipped bytes.Buffer
+			_, err := s.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, err == nil, "Serialization failed: %v", err)
+			_, err = s.SerializeCurvePoint(&bufFlipped, &PFlipped)
+			testutils.FatalUnless(t, err == nil, "Serialization failed: %v", err)
+			testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), bufFlipped.Bytes()), "serializer of type %T leaks internal decaf representation", s)
+		}
+	}
+}
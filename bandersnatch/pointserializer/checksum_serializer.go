@@ -0,0 +1,161 @@
+package pointserializer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+)
+
+// This file defines pointSerializerWithChecksum, which wraps a basic serializer and appends a CRC32 checksum to each point.
+
+// checksumLength is the number of bytes that pointSerializerWithChecksum appends to the output of the wrapped serializer.
+const checksumLength = crc32.Size
+
+var ErrChecksumMismatch = errors.New(ErrorPrefix + "checksum of read data does not match")
+
+// pointSerializerWithChecksum serializes a curve point using the wrapped basic serializer and appends a 4-byte CRC32 checksum of the encoding (as a big-endian uint32).
+// On deserialization, the checksum is verified before the wrapped serializer interprets the data; a mismatch gives an error wrapping ErrChecksumMismatch.
+//
+// The intended use case is detecting corruption of stored data. Note that the validity checks of the wrapped serializer do not catch corruptions that happen to give another valid encoding.
+// The checksum is not a cryptographic integrity guarantee: anyone able to modify the data can also recompute the checksum.
+//
+// The parameters (endianness, subgroup-only) are those of the wrapped serializer.
+type pointSerializerWithChecksum struct {
+	inner curvePointSerializer_basic
+	table *crc32.Table
+}
+
+// NewChecksumSerializer returns a serializer that wraps inner and appends a CRC32 checksum to each encoded point.
+// table determines the CRC32 polynomial; nil means the IEEE polynomial, as used by crc32.ChecksumIEEE.
+//
+// The returned serializer uses inner.OutputLength() + 4 bytes per point.
+func NewChecksumSerializer(inner curvePointSerializer_basic, table *crc32.Table) *pointSerializerWithChecksum {
+	if table == nil {
+		table = crc32.IEEETable
+	}
+	ret := pointSerializerWithChecksum{inner: inner, table: table}
+	ret.Validate()
+	return &ret
+}
+
+// SerializeCurvePoint writes a single curve point to the given output, followed by the checksum of its encoding.
+//
+// Possible errors are the same as for the wrapped serializer.
+func (s *pointSerializerWithChecksum) SerializeCurvePoint(output io.Writer, point curvePoints.CurvePointPtrInterfaceRead) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	// We serialize into a buffer first, because we need the encoding to compute the checksum.
+	var buf bytes.Buffer
+	_, err = s.inner.SerializeCurvePoint(&buf, point)
+	if err != nil {
+		// writing to a bytes.Buffer does not fail, so nothing was written.
+		return
+	}
+	var checksum [checksumLength]byte
+	binary.BigEndian.PutUint32(checksum[:], crc32.Checksum(buf.Bytes(), s.table))
+	buf.Write(checksum[:])
+
+	bytesWritten, errPlain := output.Write(buf.Bytes())
+	if errPlain != nil {
+		bandersnatchErrors.UnexpectEOF(&errPlain)
+		err = errorsWithData.NewErrorWithParametersFromData(errPlain, "%w", &bandersnatchErrors.WriteErrorData{
+			BytesWritten: bytesWritten,
+			PartialWrite: bytesWritten != 0,
+		})
+	}
+	return
+}
+
+// DeserializeCurvePoint reads from input, verifies the checksum, interprets the data using the wrapped serializer and overwrites point.
+// On error, point is untouched.
+//
+// Possible errors are io errors, an error wrapping ErrChecksumMismatch or the errors of the wrapped serializer.
+func (s *pointSerializerWithChecksum) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	buf := make([]byte, s.OutputLength())
+	bytesRead, errPlain := io.ReadFull(input, buf)
+	if errPlain != nil {
+		err = errorsWithData.NewErrorWithParametersFromData(errPlain, "%w", &bandersnatchErrors.ReadErrorData{
+			PartialRead:  bytesRead != 0,
+			BytesRead:    bytesRead,
+			ActuallyRead: copyByteSlice(buf[:bytesRead]),
+		})
+		return
+	}
+	innerLength := len(buf) - checksumLength
+	if crc32.Checksum(buf[:innerLength], s.table) != binary.BigEndian.Uint32(buf[innerLength:]) {
+		err = errorsWithData.NewErrorWithParametersFromData(ErrChecksumMismatch, "%w", &bandersnatchErrors.ReadErrorData{
+			PartialRead:  false,
+			BytesRead:    bytesRead,
+			ActuallyRead: buf,
+		})
+		return
+	}
+	_, err = s.inner.DeserializeCurvePoint(innerInput(input, buf[:innerLength]), trustLevel, point)
+	return
+}
+
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+// In particular, a checksum mismatch is reported as an error.
+func (s *pointSerializerWithChecksum) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
+// Clone creates an independent copy of the received serializer, returning a pointer.
+//
+// Note that since serializers are immutable, library users should never need to call this;
+// this is an internal function that is exported due to cross-package and reflect usage.
+func (s *pointSerializerWithChecksum) Clone() (ret *pointSerializerWithChecksum) {
+	var sCopy pointSerializerWithChecksum = *s
+	return &sCopy
+}
+
+// OutputLength returns the number of bytes read/written per curve point.
+//
+// This is the output length of the wrapped serializer plus 4 bytes for the checksum.
+func (s *pointSerializerWithChecksum) OutputLength() int32 {
+	return s.inner.OutputLength() + checksumLength
+}
+
+// GetEndianness returns the endianness used for field element serialization by the wrapped serializer.
+//
+// Note that this does not affect the checksum, which is always written as a big-endian uint32.
+func (s *pointSerializerWithChecksum) GetEndianness() common.FieldElementEndianness {
+	return s.inner.GetEndianness()
+}
+
+// IsSubgroupOnly indicates whether the wrapped serializer only works for subgroup elements.
+func (s *pointSerializerWithChecksum) IsSubgroupOnly() bool {
+	return s.inner.IsSubgroupOnly()
+}
+
+// GetParameter returns the value of the internal parameter determined by parameterName of the wrapped serializer.
+func (s *pointSerializerWithChecksum) GetParameter(parameterName string) interface{} {
+	return s.inner.GetParameter(parameterName)
+}
+
+// Validate perfoms a self-check of the internal parameters stored for the given serializer, including the wrapped serializer.
+// It panics on failure.
+func (s *pointSerializerWithChecksum) Validate() {
+	if s.inner == nil {
+		panic(ErrorPrefix + "checksum serializer has no wrapped serializer")
+	}
+	if s.table == nil {
+		panic(ErrorPrefix + "checksum serializer has no CRC32 table")
+	}
+	s.inner.Validate()
+}
+
+// RecognizedParameters returns a list of all parameter names accepted by GetParameter. These are the parameters of the wrapped serializer.
+func (s *pointSerializerWithChecksum) RecognizedParameters() []string {
+	return s.inner.RecognizedParameters()
+}
+
+// HasParameter checks whether the given parameter name is accepted by GetParameter.
+func (s *pointSerializerWithChecksum) HasParameter(parameterName string) bool {
+	return s.inner.HasParameter(parameterName)
+}
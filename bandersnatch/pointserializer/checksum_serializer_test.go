@@ -0,0 +1,89 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ curvePointDeserializer_basic = &pointSerializerWithChecksum{}
+var _ curvePointSerializer_basic = &pointSerializerWithChecksum{}
+
+func TestChecksumSerializerRoundTrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, inner := range allBasicSerializers {
+		for _, table := range []*crc32.Table{nil, crc32.MakeTable(crc32.Castagnoli)} {
+			s := NewChecksumSerializer(inner, table)
+			testutils.FatalUnless(t, s.OutputLength() == inner.OutputLength()+4, "")
+			testutils.FatalUnless(t, s.IsSubgroupOnly() == inner.IsSubgroupOnly(), "")
+			testutils.FatalUnless(t, s.GetEndianness() == inner.GetEndianness(), "")
+			sClone := s.Clone()
+			sClone.Validate()
+
+			P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+			var buf bytes.Buffer
+			bytesWritten, errSerialize := s.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, errSerialize == nil, "Serialization failed for %T: %v", inner, errSerialize)
+			testutils.FatalUnless(t, bytesWritten == int(s.OutputLength()) && buf.Len() == bytesWritten, "")
+			encoding := buf.Bytes()
+
+			var innerBuf bytes.Buffer
+			inner.SerializeCurvePoint(&innerBuf, &P)
+			testutils.FatalUnless(t, bytes.Equal(innerBuf.Bytes(), encoding[:innerBuf.Len()]), "Checksum serializer does not start with encoding of wrapped serializer")
+
+			ok, errCanonical := s.IsCanonical(encoding)
+			testutils.FatalUnless(t, ok && errCanonical == nil, "Encoding not recognized as canonical for %T: %v", inner, errCanonical)
+
+			var Q curvePoints.Point_xtw_subgroup
+			bytesRead, err := s.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+			testutils.FatalUnless(t, err == nil, "Deserialization failed for %T: %v", inner, err)
+			testutils.FatalUnless(t, bytesRead == bytesWritten, "")
+			testutils.FatalUnless(t, Q.IsEqual(&P), "Round-trip failed for %T", inner)
+		}
+	}
+}
+
+func TestChecksumSerializerDetectsBitFlips(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, inner := range allBasicSerializers {
+		s := NewChecksumSerializer(inner, nil)
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var buf bytes.Buffer
+		s.SerializeCurvePoint(&buf, &P)
+		encoding := buf.Bytes()
+		for bit := 0; bit < 8*len(encoding); bit++ {
+			corrupted := copyByteSlice(encoding)
+			corrupted[bit/8] ^= 1 << (bit % 8)
+			Q := curvePoints.NeutralElement_xtw_subgroup
+			bytesRead, err := s.DeserializeCurvePoint(bytes.NewReader(corrupted), common.UntrustedInput, &Q)
+			testutils.FatalUnless(t, errors.Is(err, ErrChecksumMismatch), "Flipping bit %v was not detected for %T. Got error %v", bit, inner, err)
+			testutils.FatalUnless(t, bytesRead == len(encoding), "")
+			testutils.FatalUnless(t, errorsWithData.HasParameter(err, FIELDNAME_PARTIAL_READ), "")
+			testutils.FatalUnless(t, Q.IsNeutralElement(), "Point was modified on error")
+		}
+	}
+}
+
+func TestChecksumSerializerEOF(t *testing.T) {
+	s := NewChecksumSerializer(&basicBanderwagonShort, nil)
+	var buf bytes.Buffer
+	s.SerializeCurvePoint(&buf, &curvePoints.NeutralElement_xtw_subgroup)
+	encoding := buf.Bytes()
+
+	var Q curvePoints.Point_xtw_subgroup
+	_, err := s.DeserializeCurvePoint(bytes.NewReader(nil), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, errors.Is(err, io.EOF), "Got %v", err)
+	bytesRead, err := s.DeserializeCurvePoint(bytes.NewReader(encoding[:len(encoding)-1]), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Got %v", err)
+	testutils.FatalUnless(t, bytesRead == len(encoding)-1, "")
+	partialRead, _ := errorsWithData.GetParameterFromError(err, FIELDNAME_PARTIAL_READ)
+	testutils.FatalUnless(t, partialRead == true, "")
+}
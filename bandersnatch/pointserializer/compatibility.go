@@ -0,0 +1,37 @@
+package pointserializer
+
+import (
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains IsCompatible, which checks whether a deserializer can write all of its valid inputs into a given type of curve point.
+
+// infinityDeserializer is an optional interface for basic deserializers. It is satisfied by deserializers that may output points at infinity.
+type infinityDeserializer interface {
+	CanDeserializeInfinity() bool
+}
+
+// CanDeserializeInfinity returns true, since this serializer type can (de)serialize the two points at infinity.
+func (s *pointSerializerFlagged) CanDeserializeInfinity() bool { return true }
+
+// IsCompatible checks whether every encoding accepted by s can be deserialized into pt. If not, we return false and a reason.
+//
+// Incompatible combinations do not make DeserializeCurvePoint misbehave: it just fails on inputs that pt cannot represent.
+// Still, such a combination is usually a configuration error, which this function allows to detect early.
+//
+// In particular, a subgroup-only deserializer is compatible with every point type, but a deserializer that accepts points outside the subgroup
+// is only compatible with types that can represent these points. Only the type of pt matters, not its value.
+func IsCompatible(s curvePointDeserializer_basic, pt curvePoints.CurvePointPtrInterfaceWrite) (bool, string) {
+	if pt == nil {
+		return false, "target point is a nil interface"
+	}
+	if !s.IsSubgroupOnly() && pt.CanOnlyRepresentSubgroup() {
+		return false, "deserializer accepts points outside the prime-order subgroup, but the target point type can only represent subgroup points"
+	}
+	if sInfinity, ok := s.(infinityDeserializer); ok && sInfinity.CanDeserializeInfinity() {
+		if !pt.CanRepresentInfinity() || pt.CanOnlyRepresentSubgroup() {
+			return false, "deserializer accepts points at infinity, but the target point type cannot represent them"
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,48 @@
+package pointserializer
+
+import (
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestIsCompatible(t *testing.T) {
+	xtwFull := &curvePoints.Point_xtw_full{}
+	axtwFull := &curvePoints.Point_axtw_full{}
+	xtwSubgroup := &curvePoints.Point_xtw_subgroup{}
+	axtwSubgroup := &curvePoints.Point_axtw_subgroup{}
+	subgroupTargets := []curvePoints.CurvePointPtrInterfaceWrite{xtwSubgroup, axtwSubgroup}
+
+	// subgroup-only deserializers are compatible with everything
+	for _, s := range allSubgroupOnlySerializers {
+		for _, pt := range []curvePoints.CurvePointPtrInterfaceWrite{xtwFull, axtwFull, xtwSubgroup, axtwSubgroup} {
+			ok, reason := IsCompatible(s, pt)
+			testutils.FatalUnless(t, ok && reason == "", "IsCompatible(%T, %T) returned false: %v", s, pt, reason)
+		}
+	}
+
+	// full deserializers are compatible with full targets only
+	for _, s := range []curvePointSerializer_basic{&ps_XY, &ps_XSY, &ps_YSX} {
+		ok, _ := IsCompatible(s, xtwFull)
+		testutils.FatalUnless(t, ok, "")
+		ok, _ = IsCompatible(s, axtwFull)
+		testutils.FatalUnless(t, ok, "")
+		for _, pt := range subgroupTargets {
+			ok, reason := IsCompatible(s, pt)
+			testutils.FatalUnless(t, !ok && reason != "", "IsCompatible(%T, %T) returned true", s, pt)
+		}
+	}
+
+	// points at infinity need targets that can represent them
+	var flagged pointSerializerFlagged
+	ok, _ := IsCompatible(&flagged, xtwFull)
+	testutils.FatalUnless(t, ok, "")
+	for _, pt := range []curvePoints.CurvePointPtrInterfaceWrite{axtwFull, xtwSubgroup, axtwSubgroup} {
+		ok, reason := IsCompatible(&flagged, pt)
+		testutils.FatalUnless(t, !ok && reason != "", "IsCompatible(%T, %T) returned true", &flagged, pt)
+	}
+
+	ok, _ = IsCompatible(&basicBanderwagonShort, nil)
+	testutils.FatalUnless(t, !ok, "")
+}
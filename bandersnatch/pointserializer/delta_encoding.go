@@ -0,0 +1,36 @@
+package pointserializer
+
+import (
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains delta encoding of subgroup points, where a point is stored as its difference to a previous point.
+
+// SerializeDelta writes the short Banderwagon encoding of cur - prev to w. This uses 32 bytes.
+//
+// The possible errors are the same as for the short Banderwagon serializer. If prev or cur is a NaP, the difference is a NaP and we return an error wrapping ErrCannotSerializeNaP.
+func SerializeDelta(w io.Writer, prev, cur *curvePoints.Point_xtw_subgroup) (int, error) {
+	var delta curvePoints.Point_xtw_subgroup
+	delta.Sub(cur, prev)
+	bytesWritten, err := basicBanderwagonShort.SerializeCurvePoint(w, &delta)
+	if err != nil {
+		return bytesWritten, err
+	}
+	return bytesWritten, nil
+}
+
+// DeserializeDelta reads an encoding of a difference as written by SerializeDelta from r and sets cur = prev + difference.
+//
+// On error, cur is untouched. cur and prev may alias, which allows to update a running point in-place.
+func DeserializeDelta(r io.Reader, trustLevel common.IsInputTrusted, prev, cur *curvePoints.Point_xtw_subgroup) (int, error) {
+	var delta curvePoints.Point_xtw_subgroup
+	bytesRead, err := basicBanderwagonShort.DeserializeCurvePoint(r, trustLevel, &delta)
+	if err != nil {
+		return bytesRead, err
+	}
+	cur.Add(prev, &delta)
+	return bytesRead, nil
+}
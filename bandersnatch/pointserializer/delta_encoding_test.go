@@ -0,0 +1,55 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeltaEncoding(t *testing.T) {
+	const chainLength = 20
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	chain := make([]curvePoints.Point_xtw_subgroup, chainLength)
+	for i := range chain {
+		chain[i] = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	chain[3] = chain[2] // zero delta
+	chain[5].SetNeutral()
+
+	// encode the chain, starting from the neutral element
+	var buf bytes.Buffer
+	var prev curvePoints.Point_xtw_subgroup
+	prev.SetNeutral()
+	for i := range chain {
+		bytesWritten, err := SerializeDelta(&buf, &prev, &chain[i])
+		testutils.FatalUnless(t, err == nil, "SerializeDelta failed at index %v: %v", i, err)
+		testutils.FatalUnless(t, bytesWritten == 32, "")
+		prev = chain[i]
+	}
+	testutils.FatalUnless(t, buf.Len() == 32*chainLength, "")
+
+	// decode the chain, updating the running point in-place
+	var running curvePoints.Point_xtw_subgroup
+	running.SetNeutral()
+	for i := range chain {
+		bytesRead, err := DeserializeDelta(&buf, common.UntrustedInput, &running, &running)
+		testutils.FatalUnless(t, err == nil, "DeserializeDelta failed at index %v: %v", i, err)
+		testutils.FatalUnless(t, bytesRead == 32, "")
+		testutils.FatalUnless(t, running.IsEqual(&chain[i]), "Delta encoding did not round-trip at index %v", i)
+	}
+
+	// errors
+	var NaP curvePoints.Point_xtw_subgroup
+	_, err := SerializeDelta(&buf, &NaP, &chain[0])
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error %v", err)
+	cur := chain[0]
+	_, err = DeserializeDelta(bytes.NewReader(make([]byte, 10)), common.UntrustedInput, &chain[1], &cur)
+	testutils.FatalUnless(t, err != nil, "DeserializeDelta did not fail on short input")
+	testutils.FatalUnless(t, cur.IsEqual(&chain[0]), "DeserializeDelta modified point on error")
+}
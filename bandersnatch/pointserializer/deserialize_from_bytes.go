@@ -0,0 +1,38 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+)
+
+// This file contains DeserializeFromBytes, which deserializes a single curve point from a byte slice rather than from a stream.
+
+// ErrWrongInputLength is returned (wrapped) by DeserializeFromBytes if the length of the given byte slice does not match the length expected by the deserializer.
+//
+// This is distinct from io.ErrUnexpectedEOF, which indicates that a stream was truncated: A wrong-length slice is a mistake by the caller rather than a property of the data.
+var ErrWrongInputLength = errors.New(ErrorPrefix + "byte slice to deserialize from has wrong length")
+
+// DeserializeFromBytes deserializes a single curve point from data, using the given deserializer. The result is written to point.
+// trustLevel has the same meaning as for DeserializeCurvePoint.
+//
+// data must have length exactly s.OutputLength(); otherwise, we return an error wrapping ErrWrongInputLength without reading anything.
+// On error, point is untouched.
+func DeserializeFromBytes(s curvePointDeserializer_basic, data []byte, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) bandersnatchErrors.DeserializationError {
+	expectedLength := int(s.OutputLength())
+	if len(data) != expectedLength {
+		message := fmt.Sprintf("%%w. Expected %v bytes, got %v", expectedLength, len(data))
+		return errorsWithData.NewErrorWithParametersFromData(ErrWrongInputLength, message, &bandersnatchErrors.ReadErrorData{
+			PartialRead:  false,
+			BytesRead:    0,
+			ActuallyRead: nil,
+		})
+	}
+	_, err := s.DeserializeCurvePoint(bytes.NewReader(data), trustLevel, point)
+	return err
+}
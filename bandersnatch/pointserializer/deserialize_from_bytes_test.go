@@ -0,0 +1,43 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeserializeFromBytes(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var buf bytes.Buffer
+	_, errSerialize := basicBanderwagonShort.SerializeCurvePoint(&buf, &P)
+	testutils.FatalUnless(t, errSerialize == nil, "")
+	encoding := buf.Bytes()
+	testutils.FatalUnless(t, len(encoding) == 32, "")
+
+	var Q curvePoints.Point_xtw_subgroup
+	err := DeserializeFromBytes(&basicBanderwagonShort, encoding, common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, err == nil, "DeserializeFromBytes failed: %v", err)
+	testutils.FatalUnless(t, Q.IsEqual(&P), "DeserializeFromBytes did not round-trip")
+
+	tooShort := encoding[0:31]
+	tooLong := append(copyByteSlice(encoding), 0)
+	for _, wrongLength := range [][]byte{tooShort, tooLong, nil} {
+		Q.SetNeutral()
+		err = DeserializeFromBytes(&basicBanderwagonShort, wrongLength, common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongInputLength), "Unexpected error for input of length %v: %v", len(wrongLength), err)
+		testutils.FatalUnless(t, !errors.Is(err, io.ErrUnexpectedEOF), "Wrong length reported as truncation")
+		testutils.FatalUnless(t, Q.IsNeutralElement(), "DeserializeFromBytes modified point on error")
+	}
+
+	// For comparison: the stream-based path reports truncation instead
+	_, err = basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(tooShort), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Unexpected error %v", err)
+	testutils.FatalUnless(t, !errors.Is(err, ErrWrongInputLength), "")
+}
@@ -0,0 +1,30 @@
+package pointserializer
+
+import (
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains DeserializeInto, which deserializes a known number of curve points into a caller-provided slice.
+
+// DeserializeInto reads exactly len(dst) curve points from inputStream, using the given basic deserializer, and writes them to dst in order.
+// trustLevel has the same meaning as for DeserializeCurvePoint.
+//
+// As opposed to DeserializeSlice, the number of points is not read from the stream and DeserializeInto never allocates a new slice;
+// this is meant for hot paths where the caller already knows the count and owns the backing array.
+//
+// DeserializeInto stops at the first error and returns it unchanged; n is the number of points that were successfully written to dst.
+// In this case, dst[n] is untouched (by the guarantees of DeserializeCurvePoint) and dst[n+1:] is not accessed.
+// Note that the error data (PartialRead, BytesRead) only refers to the failing point, not to the total input consumed.
+func DeserializeInto(s curvePointDeserializer_basic, inputStream io.Reader, trustLevel common.IsInputTrusted, dst []curvePoints.Point_xtw_subgroup) (n int, err bandersnatchErrors.DeserializationError) {
+	for n = 0; n < len(dst); n++ {
+		_, err = s.DeserializeCurvePoint(inputStream, trustLevel, &dst[n])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
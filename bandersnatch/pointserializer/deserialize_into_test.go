@@ -0,0 +1,57 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeserializeInto(t *testing.T) {
+	const num = 10
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	var points [num]curvePoints.Point_xtw_subgroup
+	var buf bytes.Buffer
+	for i := 0; i < num; i++ {
+		points[i] = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		_, err := basicBanderwagonShort.SerializeCurvePoint(&buf, &points[i])
+		testutils.FatalUnless(t, err == nil, "Serialization failed %v", err)
+	}
+	data := buf.Bytes()
+
+	// good input
+	var dst [num]curvePoints.Point_xtw_subgroup
+	n, err := DeserializeInto(&basicBanderwagonShort, bytes.NewReader(data), common.UntrustedInput, dst[:])
+	testutils.FatalUnless(t, err == nil, "DeserializeInto failed: %v", err)
+	testutils.FatalUnless(t, n == num, "DeserializeInto reported %v points, expected %v", n, num)
+	for i := 0; i < num; i++ {
+		testutils.FatalUnless(t, dst[i].IsEqual(&points[i]), "DeserializeInto gave wrong point at index %v", i)
+	}
+
+	// empty dst reads nothing
+	reader := bytes.NewReader(data)
+	n, err = DeserializeInto(&basicBanderwagonShort, reader, common.UntrustedInput, nil)
+	testutils.FatalUnless(t, err == nil && n == 0, "DeserializeInto on empty dst: n = %v, err = %v", n, err)
+	testutils.FatalUnless(t, reader.Len() == len(data), "DeserializeInto on empty dst consumed input")
+
+	// truncated input
+	var dst2 [num]curvePoints.Point_xtw_subgroup
+	for i := range dst2 {
+		dst2[i].SetNeutral()
+	}
+	truncated := data[0 : len(data)-40] // 8 full points, followed by a partial one
+	n, err = DeserializeInto(&basicBanderwagonShort, bytes.NewReader(truncated), common.UntrustedInput, dst2[:])
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Unexpected error on truncated input: %v", err)
+	testutils.FatalUnless(t, n == num-2, "DeserializeInto reported %v points on truncated input, expected %v", n, num-2)
+	for i := 0; i < n; i++ {
+		testutils.FatalUnless(t, dst2[i].IsEqual(&points[i]), "DeserializeInto gave wrong point at index %v", i)
+	}
+	for i := n; i < num; i++ {
+		testutils.FatalUnless(t, dst2[i].IsNeutralElement(), "DeserializeInto modified point at index %v after error", i)
+	}
+}
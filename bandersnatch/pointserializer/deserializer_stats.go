@@ -0,0 +1,95 @@
+package pointserializer
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+)
+
+// This file contains optional counters for rejections of points by the basic deserializers.
+// These are meant for monitoring services that deserialize untrusted points, e.g. to detect a spike in subgroup rejections.
+//
+// Counting is disabled by default and needs to be enabled via EnableDeserializerStats.
+
+// deserializerStatsEnabled is 1 if the counters are enabled, 0 otherwise. Accessed atomically.
+var deserializerStatsEnabled int32
+
+// rejection counters. Accessed atomically.
+var (
+	deserializerStatsOnCurveRejects       uint64
+	deserializerStatsNotInSubgroupRejects uint64
+	deserializerStatsNonCanonicalRejects  uint64
+)
+
+// EnableDeserializerStats enables or disables counting of rejections by the basic deserializers. Counting is disabled by default.
+// Disabling does not reset the counters; use ResetDeserializerStats for that.
+func EnableDeserializerStats(enable bool) {
+	var value int32
+	if enable {
+		value = 1
+	}
+	atomic.StoreInt32(&deserializerStatsEnabled, value)
+}
+
+// DeserializerStats returns the number of points rejected by the basic deserializers (since the last reset) because
+//   - the input did not correspond to a point on the curve (onCurveRejects)
+//   - the input corresponded to a point on the curve, but not in the prime-order subgroup (notInSubgroupRejects)
+//   - the input used a non-canonical encoding, i.e. a non-normalized field element or a non-canonical sign (nonCanonicalRejects)
+//
+// Rejections are only counted while enabled via EnableDeserializerStats. Serializers wrapping basic serializers (e.g. with checksums) do not count separately.
+// Note that trusted inputs are not checked, so these can only be counted for untrusted input.
+func DeserializerStats() (onCurveRejects, notInSubgroupRejects, nonCanonicalRejects uint64) {
+	onCurveRejects = atomic.LoadUint64(&deserializerStatsOnCurveRejects)
+	notInSubgroupRejects = atomic.LoadUint64(&deserializerStatsNotInSubgroupRejects)
+	nonCanonicalRejects = atomic.LoadUint64(&deserializerStatsNonCanonicalRejects)
+	return
+}
+
+// ResetDeserializerStats sets all counters reported by DeserializerStats to zero.
+func ResetDeserializerStats() {
+	atomic.StoreUint64(&deserializerStatsOnCurveRejects, 0)
+	atomic.StoreUint64(&deserializerStatsNotInSubgroupRejects, 0)
+	atomic.StoreUint64(&deserializerStatsNonCanonicalRejects, 0)
+}
+
+// recordDeserializationError updates the counters for DeserializerStats according to the reason for the given error, if enabled.
+// Errors of other kinds (such as I/O errors) and err == nil are ignored, as is input marked via uncountedInput or innerInput.
+//
+// This is called (deferred) by the DeserializeCurvePoint methods of the basic deserializers. Serializers wrapping them (e.g. with checksums) do not call it themselves;
+// the wrapped serializer counts the rejection instead.
+func recordDeserializationError(input io.Reader, err error) {
+	if err == nil || atomic.LoadInt32(&deserializerStatsEnabled) == 0 {
+		return
+	}
+	if _, uncounted := input.(uncountedReader); uncounted {
+		return
+	}
+	switch ClassifyDeserializationError(err) {
+	case DeserErrorNotOnCurve:
+		atomic.AddUint64(&deserializerStatsOnCurveRejects, 1)
+	case DeserErrorNotInSubgroup:
+		atomic.AddUint64(&deserializerStatsNotInSubgroupRejects, 1)
+	case DeserErrorNonCanonical:
+		atomic.AddUint64(&deserializerStatsNonCanonicalRejects, 1)
+	}
+}
+
+// uncountedReader marks input whose rejection must not affect the counters for DeserializerStats.
+type uncountedReader struct {
+	io.Reader
+}
+
+// uncountedInput returns a reader for data that is not counted for DeserializerStats when deserialized.
+// Internal probes that deserialize (such as isCanonicalEncoding) use this, so they do not affect the counters.
+func uncountedInput(data []byte) io.Reader {
+	return uncountedReader{Reader: bytes.NewReader(data)}
+}
+
+// innerInput returns a reader for data, which a wrapping serializer has read from input and passes on to the wrapped serializer.
+// The result is counted for DeserializerStats iff input is.
+func innerInput(input io.Reader, data []byte) io.Reader {
+	if _, uncounted := input.(uncountedReader); uncounted {
+		return uncountedInput(data)
+	}
+	return bytes.NewReader(data)
+}
@@ -0,0 +1,114 @@
+package pointserializer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestDeserializerStats(t *testing.T) {
+	defer EnableDeserializerStats(false)
+	defer ResetDeserializerStats()
+	ResetDeserializerStats()
+
+	full := pointSerializerXY{valuesSerializerHeaderFeHeaderFe: valuesSerializerHeaderFeHeaderFe{fieldElementEndianness: common.DefaultEndian}}
+	subgroup := full
+	subgroup.SetSubgroupRestriction(true)
+
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	var P curvePoints.Point_xtw_full
+	for {
+		P = curvePoints.MakeRandomPointUnsafe_xtw_full(drng)
+		if !P.IsInSubgroup() {
+			break
+		}
+	}
+	var buf bytes.Buffer
+	_, errSerialize := full.SerializeCurvePoint(&buf, &P)
+	testutils.FatalUnless(t, errSerialize == nil, "Serialization failed: %v", errSerialize)
+	notInSubgroup := buf.Bytes()
+
+	notOnCurve := make([]byte, 64)
+	notOnCurve[0] = 1
+	notOnCurve[32] = 2
+
+	// X = BaseFieldSize + 1 is not normalized (the default endianness is little endian)
+	nonCanonical := make([]byte, 64)
+	var xNonNormalized big.Int
+	xNonNormalized.Add(fieldElements.BaseFieldSize_Int, big.NewInt(1))
+	xNonNormalized.FillBytes(nonCanonical[0:32])
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		nonCanonical[i], nonCanonical[j] = nonCanonical[j], nonCanonical[i]
+	}
+	nonCanonical[32] = 1
+
+	var Q curvePoints.Point_xtw_full
+	deserialize := func(s *pointSerializerXY, input []byte) {
+		_, err := s.DeserializeCurvePoint(bytes.NewReader(input), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, err != nil, "Deserialization unexpectedly succeeded")
+	}
+
+	// disabled by default: nothing is counted
+	deserialize(&full, notOnCurve)
+	onCurve, inSubgroup, canonical := DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 0 && inSubgroup == 0 && canonical == 0, "rejections counted while disabled")
+
+	EnableDeserializerStats(true)
+	deserialize(&full, notOnCurve)
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 1 && inSubgroup == 0 && canonical == 0, "unexpected stats %v %v %v", onCurve, inSubgroup, canonical)
+
+	deserialize(&subgroup, notInSubgroup)
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 1 && inSubgroup == 1 && canonical == 0, "unexpected stats %v %v %v", onCurve, inSubgroup, canonical)
+
+	deserialize(&full, nonCanonical)
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 1 && inSubgroup == 1 && canonical == 1, "unexpected stats %v %v %v", onCurve, inSubgroup, canonical)
+
+	// I/O errors are not counted
+	_, err := full.DeserializeCurvePoint(bytes.NewReader(nil), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, err != nil, "Deserialization from empty input unexpectedly succeeded")
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 1 && inSubgroup == 1 && canonical == 1, "unexpected stats %v %v %v", onCurve, inSubgroup, canonical)
+
+	// internal probes are not counted
+	for _, c := range []struct {
+		s     *pointSerializerXY
+		input []byte
+	}{{&full, notOnCurve}, {&subgroup, notInSubgroup}, {&full, nonCanonical}} {
+		ok, _ := c.s.IsCanonical(c.input)
+		testutils.FatalUnless(t, !ok, "IsCanonical accepted invalid input")
+	}
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 1 && inSubgroup == 1 && canonical == 1, "internal probes were counted: %v %v %v", onCurve, inSubgroup, canonical)
+
+	// wrapping serializers count the rejection by the wrapped serializer once
+	withChecksum := NewChecksumSerializer(&full, nil)
+	checksummed := append(append([]byte{}, notOnCurve...), make([]byte, 4)...)
+	binary.BigEndian.PutUint32(checksummed[64:], crc32.ChecksumIEEE(notOnCurve))
+	_, err = withChecksum.DeserializeCurvePoint(bytes.NewReader(checksummed), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, ClassifyDeserializationError(err) == DeserErrorNotOnCurve, "unexpected error %v", err)
+	_, _ = withChecksum.IsCanonical(checksummed)
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 2 && inSubgroup == 1 && canonical == 1, "unexpected stats %v %v %v", onCurve, inSubgroup, canonical)
+
+	withDomainTag := NewDomainTagSerializer(&full, []byte("tag"))
+	tagged := append([]byte("tag"), notOnCurve...)
+	ok, _ := withDomainTag.IsCanonical(tagged)
+	testutils.FatalUnless(t, !ok, "IsCanonical accepted invalid input")
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 2 && inSubgroup == 1 && canonical == 1, "internal probes through a wrapping serializer were counted: %v %v %v", onCurve, inSubgroup, canonical)
+
+	ResetDeserializerStats()
+	onCurve, inSubgroup, canonical = DeserializerStats()
+	testutils.FatalUnless(t, onCurve == 0 && inSubgroup == 0 && canonical == 0, "ResetDeserializerStats did not reset")
+}
@@ -0,0 +1,101 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ curvePointDeserializer_basic = &pointSerializerWithDomainTag{}
+var _ curvePointSerializer_basic = &pointSerializerWithDomainTag{}
+
+func TestDomainTagSerializerRoundTrip(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, inner := range allBasicSerializers {
+		for _, tag := range [][]byte{nil, []byte("protocol A")} {
+			s := NewDomainTagSerializer(inner, tag)
+			testutils.FatalUnless(t, s.OutputLength() == inner.OutputLength()+int32(len(tag)), "")
+			testutils.FatalUnless(t, s.IsSubgroupOnly() == inner.IsSubgroupOnly(), "")
+			testutils.FatalUnless(t, s.GetEndianness() == inner.GetEndianness(), "")
+			testutils.FatalUnless(t, bytes.Equal(s.GetParameter("DomainTag").([]byte), tag), "")
+			sClone := s.Clone()
+			sClone.Validate()
+
+			P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+			var buf bytes.Buffer
+			bytesWritten, errSerialize := s.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, errSerialize == nil, "Serialization failed for %T: %v", inner, errSerialize)
+			testutils.FatalUnless(t, bytesWritten == int(s.OutputLength()) && buf.Len() == bytesWritten, "")
+			encoding := buf.Bytes()
+			testutils.FatalUnless(t, bytes.HasPrefix(encoding, tag), "Encoding does not start with domain tag")
+
+			var innerBuf bytes.Buffer
+			inner.SerializeCurvePoint(&innerBuf, &P)
+			testutils.FatalUnless(t, bytes.Equal(innerBuf.Bytes(), encoding[len(tag):]), "Domain tag serializer does not end with encoding of wrapped serializer")
+
+			ok, errCanonical := s.IsCanonical(encoding)
+			testutils.FatalUnless(t, ok && errCanonical == nil, "Encoding not recognized as canonical for %T: %v", inner, errCanonical)
+
+			var Q curvePoints.Point_xtw_subgroup
+			bytesRead, err := s.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+			testutils.FatalUnless(t, err == nil, "Deserialization failed for %T: %v", inner, err)
+			testutils.FatalUnless(t, bytesRead == bytesWritten, "")
+			testutils.FatalUnless(t, Q.IsEqual(&P), "Round-trip failed for %T", inner)
+		}
+	}
+}
+
+func TestDomainTagSerializerRejectsOtherTag(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, inner := range allBasicSerializers {
+		sA := NewDomainTagSerializer(inner, []byte("protocol A"))
+		sB := sA.WithParameter("DomainTag", []byte("protocol B"))
+		testutils.FatalUnless(t, bytes.Equal(sA.GetDomainTag(), []byte("protocol A")), "WithParameter modified the original serializer")
+		testutils.FatalUnless(t, bytes.Equal(sB.GetDomainTag(), []byte("protocol B")), "")
+
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var buf bytes.Buffer
+		sA.SerializeCurvePoint(&buf, &P)
+		encoding := buf.Bytes()
+
+		Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		QCopy := Q
+		bytesRead, err := sB.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongDomainTag), "Point serialized under another tag was not rejected for %T. Got error %v", inner, err)
+		testutils.FatalUnless(t, bytesRead == int(sB.OutputLength()), "")
+		testutils.FatalUnless(t, Q.IsEqual(&QCopy), "Target point was modified on error")
+
+		// the raw encoding of the wrapped serializer is rejected, too.
+		buf.Reset()
+		inner.SerializeCurvePoint(&buf, &P)
+		buf.Write(make([]byte, len("protocol A"))) // pad to the right length
+		_, err = sA.DeserializeCurvePoint(&buf, common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongDomainTag), "Untagged encoding was not rejected for %T. Got error %v", inner, err)
+	}
+}
+
+func TestDomainTagSerializerParameters(t *testing.T) {
+	s := NewDomainTagSerializer(&ps_XY, []byte("tag"))
+	testutils.FatalUnless(t, s.HasParameter("domaintag"), "")
+	testutils.FatalUnless(t, s.HasParameter("Endianness"), "")
+
+	// the domain tag is copied, both on construction and when getting it
+	tag := []byte("tag")
+	s = NewDomainTagSerializer(&ps_XY, tag)
+	tag[0] = 'x'
+	s.GetDomainTag()[1] = 'x'
+	testutils.FatalUnless(t, bytes.Equal(s.GetDomainTag(), []byte("tag")), "Domain tag was not copied")
+
+	// parameters of the wrapped serializer can be changed
+	sBig := s.WithParameter("Endianness", common.BigEndian)
+	testutils.FatalUnless(t, sBig.GetEndianness() == common.BigEndian, "")
+	testutils.FatalUnless(t, bytes.Equal(sBig.GetDomainTag(), []byte("tag")), "")
+	testutils.FatalUnless(t, s.GetEndianness() == ps_XY.GetEndianness(), "WithParameter modified the original serializer")
+
+	testutils.FatalUnless(t, testutils.CheckPanic(NewDomainTagSerializer, nil, []byte("tag")), "NewDomainTagSerializer did not panic on nil wrapped serializer")
+}
@@ -0,0 +1,61 @@
+package pointserializer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains EncodingsEqual, which compares encoded curve points without deserializing them whenever the format allows it.
+
+// hasUniqueEncodings reports whether the format given by s is known to have at most one accepted encoding per curve point.
+// For such formats, two valid encodings represent the same point iff they are equal as byte slices.
+//
+// This is the case for all formats defined in this package: The deserializers reject non-normalized field elements, wrong headers and,
+// for the formats with sign bits, the sign bit for X==0 resp. the non-canonical sign of 0.
+// For unknown types, we conservatively return false.
+func hasUniqueEncodings(s curvePointDeserializer_basic) bool {
+	switch s := s.(type) {
+	case *pointSerializerXY, *pointSerializerXAndSignY, *pointSerializerYAndSignX, *pointSerializerXTimesSignY, *pointSerializerYXTimesSignY, *pointSerializerFlagged, *pointSerializerMapToField:
+		return true
+	case *pointSerializerWithChecksum:
+		return hasUniqueEncodings(s.inner)
+	default:
+		return false
+	}
+}
+
+// EncodingsEqual checks whether a and b are encodings of the same curve point under the format given by s.
+// a and b must both have length s.OutputLength(); otherwise, we return an error wrapping ErrWrongInputLength.
+//
+// If the format has unique encodings (which is the case for all formats defined in this package), we just compare a and b as byte slices.
+// Note that in this case, we do NOT check that a and b are valid encodings at all, so the result for invalid encodings is meaningless.
+// Callers who need validity should deserialize (at least one of) the inputs.
+//
+// For formats not known to have unique encodings, we fall back to deserializing both a and b (as untrusted input) and comparing the resulting points.
+// In this case, we also return an error if either a or b cannot be deserialized.
+func EncodingsEqual(s curvePointDeserializer_basic, a, b []byte) (bool, error) {
+	expectedLength := int(s.OutputLength())
+	if len(a) != expectedLength || len(b) != expectedLength {
+		return false, fmt.Errorf("%w. Expected %v bytes, got %v and %v", ErrWrongInputLength, expectedLength, len(a), len(b))
+	}
+	if hasUniqueEncodings(s) {
+		return bytes.Equal(a, b), nil
+	}
+
+	var pointA, pointB curvePoints.CurvePointPtrInterface
+	if s.IsSubgroupOnly() {
+		pointA, pointB = &curvePoints.Point_xtw_subgroup{}, &curvePoints.Point_xtw_subgroup{}
+	} else {
+		pointA, pointB = &curvePoints.Point_xtw_full{}, &curvePoints.Point_xtw_full{}
+	}
+	if _, err := s.DeserializeCurvePoint(uncountedInput(a), common.UntrustedInput, pointA); err != nil {
+		return false, err
+	}
+	if _, err := s.DeserializeCurvePoint(uncountedInput(b), common.UntrustedInput, pointB); err != nil {
+		return false, err
+	}
+	return pointA.IsEqual(pointB), nil
+}
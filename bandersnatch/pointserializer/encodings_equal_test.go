@@ -0,0 +1,108 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+// paddedTestSerializer wraps a basic serializer and appends a padding byte that is ignored on deserialization.
+// This gives a format without unique encodings, which we use to test the fallback of EncodingsEqual.
+type paddedTestSerializer struct {
+	curvePointSerializer_basic
+}
+
+func (s *paddedTestSerializer) OutputLength() int32 {
+	return s.curvePointSerializer_basic.OutputLength() + 1
+}
+
+func (s *paddedTestSerializer) SerializeCurvePoint(output io.Writer, point curvePoints.CurvePointPtrInterfaceRead) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	var buf bytes.Buffer
+	_, err = s.curvePointSerializer_basic.SerializeCurvePoint(&buf, point)
+	if err != nil {
+		return
+	}
+	buf.WriteByte(0)
+	bytesWritten, _ = output.Write(buf.Bytes())
+	return
+}
+
+func (s *paddedTestSerializer) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	bytesRead, err = s.curvePointSerializer_basic.DeserializeCurvePoint(input, trustLevel, point)
+	if err != nil {
+		return
+	}
+	var pad [1]byte
+	n, _ := io.ReadFull(input, pad[:])
+	bytesRead += n
+	return
+}
+
+func encodeForTest(t *testing.T, s curvePointSerializer_basic, point curvePoints.CurvePointPtrInterfaceRead) []byte {
+	var buf bytes.Buffer
+	_, err := s.SerializeCurvePoint(&buf, point)
+	testutils.FatalUnless(t, err == nil, "Serialization failed for %T: %v", s, err)
+	return buf.Bytes()
+}
+
+func TestEncodingsEqual(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	serializers := []curvePointSerializer_basic{&pointSerializerFlagged{}, NewChecksumSerializer(&basicBanderwagonLong, nil)}
+	serializers = append(serializers, allBasicSerializers...)
+	for _, s := range serializers {
+		testutils.FatalUnless(t, hasUniqueEncodings(s), "Format %T not recognized as having unique encodings", s)
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		var PFlipped curvePoints.Point_xtw_subgroup
+		PFlipped.Neg(&P)
+		PFlipped.NegEq() // same point, possibly in another internal representation
+		encP, encP2, encQ := encodeForTest(t, s, &P), encodeForTest(t, s, &PFlipped), encodeForTest(t, s, &Q)
+
+		for _, pair := range [][2][]byte{{encP, encP2}, {encP, encQ}, {encQ, encP}} {
+			equal, err := EncodingsEqual(s, pair[0], pair[1])
+			testutils.FatalUnless(t, err == nil, "EncodingsEqual failed for %T: %v", s, err)
+			var R1, R2 curvePoints.Point_xtw_subgroup
+			errDeserialize := DeserializeFromBytes(s, pair[0], common.UntrustedInput, &R1)
+			testutils.FatalUnless(t, errDeserialize == nil, "")
+			errDeserialize = DeserializeFromBytes(s, pair[1], common.UntrustedInput, &R2)
+			testutils.FatalUnless(t, errDeserialize == nil, "")
+			testutils.FatalUnless(t, equal == R1.IsEqual(&R2), "EncodingsEqual disagrees with deserialization for %T", s)
+		}
+
+		_, err := EncodingsEqual(s, encP, encP[1:])
+		testutils.FatalUnless(t, errors.Is(err, ErrWrongInputLength), "EncodingsEqual did not report wrong length for %T. Got %v", s, err)
+	}
+
+	// Fallback for formats without unique encodings.
+	for _, inner := range allBasicSerializers {
+		s := &paddedTestSerializer{curvePointSerializer_basic: inner}
+		testutils.FatalUnless(t, !hasUniqueEncodings(s), "")
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		encP, encQ := encodeForTest(t, s, &P), encodeForTest(t, s, &Q)
+		encPPadded := copyByteSlice(encP)
+		encPPadded[len(encPPadded)-1] = 0xFF
+
+		equal, err := EncodingsEqual(s, encP, encPPadded)
+		testutils.FatalUnless(t, err == nil, "EncodingsEqual fallback failed for %T: %v", inner, err)
+		testutils.FatalUnless(t, equal, "EncodingsEqual fallback does not recognize differently padded encodings of the same point for %T", inner)
+		equal, err = EncodingsEqual(s, encPPadded, encQ)
+		testutils.FatalUnless(t, err == nil, "EncodingsEqual fallback failed for %T: %v", inner, err)
+		testutils.FatalUnless(t, !equal, "EncodingsEqual fallback gives true for different points for %T", inner)
+
+		// invalid encodings are reported in the fallback.
+		invalid := make([]byte, len(encP))
+		for i := range invalid {
+			invalid[i] = 0xFF
+		}
+		_, err = EncodingsEqual(s, encP, invalid)
+		testutils.FatalUnless(t, err != nil, "EncodingsEqual fallback did not report invalid encoding for %T", inner)
+	}
+}
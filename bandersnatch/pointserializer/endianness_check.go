@@ -0,0 +1,75 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// ErrEndiannessInconsistent is returned (wrapped) by CheckEndiannessConsistency if little endian and big endian serialization are inconsistent.
+var ErrEndiannessInconsistent = errors.New(ErrorPrefix + "little endian and big endian serialization are inconsistent")
+
+// CheckEndiannessConsistency is a helper intended for testing.
+// It serializes p in both the short and long Banderwagon formats with both little endian and big endian field element encoding and checks that
+//   - for each format, each 32-byte block of the little endian output is the byte-reversal of the corresponding block of the big endian output.
+//   - each output deserializes back to p.
+//
+// Note that the header bits are part of the msb of the encoded field element, so they are reversed along with the value.
+// On failure, we return an error wrapping ErrEndiannessInconsistent (or a serialization/deserialization error); on success, we return nil.
+//
+// p must not be a NaP; we panic otherwise.
+func CheckEndiannessConsistency(p *curvePoints.Point_xtw_subgroup) error {
+	if p.IsNaP() {
+		panic(ErrorPrefix + "CheckEndiannessConsistency called on NaP")
+	}
+	shortLittle := basicBanderwagonShort.WithEndianness(common.LittleEndian)
+	shortBig := basicBanderwagonShort.WithEndianness(common.BigEndian)
+	if err := checkEndiannessConsistencyFor(FormatNameXTimesSignY, &shortLittle, &shortBig, p); err != nil {
+		return err
+	}
+	longLittle := basicBanderwagonLong.WithEndianness(common.LittleEndian)
+	longBig := basicBanderwagonLong.WithEndianness(common.BigEndian)
+	return checkEndiannessConsistencyFor(FormatNameYXTimesSignY, &longLittle, &longBig, p)
+}
+
+// checkEndiannessConsistencyFor performs the checks of CheckEndiannessConsistency for a given pair of serializers that only differ in endianness.
+// formatName is only used in error messages.
+func checkEndiannessConsistencyFor(formatName string, little, big curvePointSerializer_basic, p *curvePoints.Point_xtw_subgroup) error {
+	var bufLittle, bufBig bytes.Buffer
+	if _, err := little.SerializeCurvePoint(&bufLittle, p); err != nil {
+		return err
+	}
+	if _, err := big.SerializeCurvePoint(&bufBig, p); err != nil {
+		return err
+	}
+	encodedLittle := bufLittle.Bytes()
+	encodedBig := bufBig.Bytes()
+	if len(encodedLittle) != len(encodedBig) || len(encodedLittle)%32 != 0 {
+		return fmt.Errorf("%w: format %v produced outputs of lengths %v (little endian) and %v (big endian)", ErrEndiannessInconsistent, formatName, len(encodedLittle), len(encodedBig))
+	}
+	for block := 0; block < len(encodedLittle); block += 32 {
+		for i := 0; i < 32; i++ {
+			if encodedLittle[block+i] != encodedBig[block+31-i] {
+				return fmt.Errorf("%w: format %v produced outputs %x (little endian) and %x (big endian), which are not byte-reversals of each other", ErrEndiannessInconsistent, formatName, encodedLittle, encodedBig)
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		s          curvePointSerializer_basic
+		encoded    []byte
+		endianness string
+	}{{little, encodedLittle, "little endian"}, {big, encodedBig, "big endian"}} {
+		var q curvePoints.Point_xtw_subgroup
+		if _, err := c.s.DeserializeCurvePoint(uncountedInput(c.encoded), common.UntrustedInput, &q); err != nil {
+			return err
+		}
+		if !q.IsEqual(p) {
+			return fmt.Errorf("%w: format %v with %v encoding did not deserialize back to the original point", ErrEndiannessInconsistent, formatName, c.endianness)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package pointserializer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestCheckEndiannessConsistency(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		if i == 0 {
+			P.SetNeutral()
+		}
+		err := CheckEndiannessConsistency(&P)
+		testutils.FatalUnless(t, err == nil, "Endianness inconsistency for point %v: %v", P, err)
+	}
+	var nap curvePoints.Point_xtw_subgroup
+	testutils.FatalUnless(t, testutils.CheckPanic(CheckEndiannessConsistency, &nap), "CheckEndiannessConsistency did not panic on NaP")
+}
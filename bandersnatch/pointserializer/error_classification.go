@@ -0,0 +1,70 @@
+package pointserializer
+
+import (
+	"errors"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+)
+
+// DeserErrorClass is the high-level reason for a deserialization failure, as determined by ClassifyDeserializationError.
+type DeserErrorClass int
+
+const (
+	DeserErrorNone              DeserErrorClass = iota // no error (err == nil)
+	DeserErrorIO                                       // the input stream ended prematurely
+	DeserErrorMalformedEncoding                        // the input is not a validly formatted encoding (wrong headers, invalid flags, checksum mismatch etc.)
+	DeserErrorNotOnCurve                               // the input is well-formed, but does not correspond to a point on the curve
+	DeserErrorNotInSubgroup                            // the input corresponds to a point on the curve, but not in the prime-order subgroup
+	DeserErrorNonCanonical                             // the input uses a non-canonical encoding, e.g. a non-normalized field element or a non-canonical sign
+	DeserErrorOther                                    // any other error, including errors from the underlying io.Reader
+)
+
+// String returns a human-readable name for the error class.
+func (c DeserErrorClass) String() string {
+	switch c {
+	case DeserErrorNone:
+		return "None"
+	case DeserErrorIO:
+		return "IOError"
+	case DeserErrorMalformedEncoding:
+		return "MalformedEncoding"
+	case DeserErrorNotOnCurve:
+		return "NotOnCurve"
+	case DeserErrorNotInSubgroup:
+		return "NotInSubgroup"
+	case DeserErrorNonCanonical:
+		return "NonCanonical"
+	case DeserErrorOther:
+		return "Other"
+	default:
+		return "invalid DeserErrorClass"
+	}
+}
+
+// ClassifyDeserializationError determines the high-level reason for a deserialization failure by inspecting the error chain of err via errors.Is.
+// This allows callers to write a switch statement instead of a chain of errors.Is checks against the individual error values.
+//
+// Note that errors returned by the underlying io.Reader (other than io.EOF and io.ErrUnexpectedEOF) cannot be recognized and are classified as DeserErrorOther.
+func ClassifyDeserializationError(err error) DeserErrorClass {
+	if err == nil {
+		return DeserErrorNone
+	}
+	switch {
+	case errors.Is(err, bandersnatchErrors.ErrNotOnCurve), errors.Is(err, bandersnatchErrors.ErrXNotOnCurve), errors.Is(err, bandersnatchErrors.ErrYNotOnCurve):
+		return DeserErrorNotOnCurve
+	case errors.Is(err, bandersnatchErrors.ErrNotInSubgroup), errors.Is(err, bandersnatchErrors.ErrXNotInSubgroup):
+		return DeserErrorNotInSubgroup
+	case errors.Is(err, fieldElements.ErrNonNormalizedDeserialization), errors.Is(err, ErrNonCanonicalFlaggedEncoding), errors.Is(err, bandersnatchErrors.ErrUnexpectedNegativeZero):
+		return DeserErrorNonCanonical
+	case errors.Is(err, fieldElements.ErrPrefixMismatch), errors.Is(err, bandersnatchErrors.ErrDidNotReadExpectedString), errors.Is(err, bandersnatchErrors.ErrInvalidSign),
+		errors.Is(err, bandersnatchErrors.ErrWrongSignY), errors.Is(err, bandersnatchErrors.ErrInvalidZeroSignX), errors.Is(err, bandersnatchErrors.ErrCannotDeserializeNaP),
+		errors.Is(err, ErrInvalidInfinityEncoding), errors.Is(err, ErrChecksumMismatch), errors.Is(err, ErrWrongInputLength), errors.Is(err, ErrCannotDetectFormat), errors.Is(err, ErrAllZeroEncoding):
+		return DeserErrorMalformedEncoding
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return DeserErrorIO
+	default:
+		return DeserErrorOther
+	}
+}
@@ -0,0 +1,60 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestClassifyDeserializationError(t *testing.T) {
+	testutils.FatalUnless(t, ClassifyDeserializationError(nil) == DeserErrorNone, "")
+
+	expected := map[error]DeserErrorClass{
+		io.EOF:                                         DeserErrorIO,
+		io.ErrUnexpectedEOF:                            DeserErrorIO,
+		bandersnatchErrors.ErrNotOnCurve:               DeserErrorNotOnCurve,
+		bandersnatchErrors.ErrXNotOnCurve:              DeserErrorNotOnCurve,
+		bandersnatchErrors.ErrYNotOnCurve:              DeserErrorNotOnCurve,
+		bandersnatchErrors.ErrNotInSubgroup:            DeserErrorNotInSubgroup,
+		bandersnatchErrors.ErrXNotInSubgroup:           DeserErrorNotInSubgroup,
+		fieldElements.ErrNonNormalizedDeserialization:  DeserErrorNonCanonical,
+		ErrNonCanonicalFlaggedEncoding:                 DeserErrorNonCanonical,
+		bandersnatchErrors.ErrUnexpectedNegativeZero:   DeserErrorNonCanonical,
+		fieldElements.ErrPrefixMismatch:                DeserErrorMalformedEncoding,
+		bandersnatchErrors.ErrDidNotReadExpectedString: DeserErrorMalformedEncoding,
+		bandersnatchErrors.ErrInvalidSign:              DeserErrorMalformedEncoding,
+		bandersnatchErrors.ErrWrongSignY:               DeserErrorMalformedEncoding,
+		bandersnatchErrors.ErrInvalidZeroSignX:         DeserErrorMalformedEncoding,
+		bandersnatchErrors.ErrCannotDeserializeNaP:     DeserErrorMalformedEncoding,
+		ErrInvalidInfinityEncoding:                     DeserErrorMalformedEncoding,
+		ErrChecksumMismatch:                            DeserErrorMalformedEncoding,
+		ErrWrongInputLength:                            DeserErrorMalformedEncoding,
+		ErrCannotDetectFormat:                          DeserErrorMalformedEncoding,
+		ErrIdentityRejected:                            DeserErrorOther,
+		errors.New("some reader failure"):              DeserErrorOther,
+	}
+	for err, class := range expected {
+		testutils.FatalUnless(t, ClassifyDeserializationError(err) == class, "error %v classified as %v, expected %v", err, ClassifyDeserializationError(err), class)
+		wrapped := fmt.Errorf("wrapped: %w", err)
+		testutils.FatalUnless(t, ClassifyDeserializationError(wrapped) == class, "wrapped error %v classified as %v, expected %v", err, ClassifyDeserializationError(wrapped), class)
+	}
+
+	// errors actually returned by deserializers
+	var P curvePoints.Point_xtw_subgroup
+	_, err := basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(make([]byte, 10)), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, ClassifyDeserializationError(err) == DeserErrorIO, "truncated input classified as %v", ClassifyDeserializationError(err))
+	_, err = basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(make([]byte, 32)), common.UntrustedInput, &P)
+	testutils.FatalUnless(t, ClassifyDeserializationError(err) == DeserErrorMalformedEncoding, "input with wrong header classified as %v", ClassifyDeserializationError(err))
+	_, _, errAutoDetect := DeserializeAutoDetect(bytes.NewReader(make([]byte, 10)), common.UntrustedInput)
+	testutils.FatalUnless(t, ClassifyDeserializationError(errAutoDetect) == DeserErrorIO, "truncated input to DeserializeAutoDetect classified as %v", ClassifyDeserializationError(errAutoDetect))
+
+	testutils.FatalUnless(t, DeserErrorNotInSubgroup.String() == "NotInSubgroup", "")
+}
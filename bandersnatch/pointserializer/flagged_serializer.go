@@ -0,0 +1,189 @@
+package pointserializer
+
+import (
+	"errors"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/utils"
+)
+
+// This file defines pointSerializerFlagged, a basic serializer with a fixed 32-byte little-endian format,
+// whose top 2 bits are flags for points at infinity and the sign of Y.
+
+// pointSerializerFlagged serializes a curve point into exactly 32 bytes, interpreted as a little-endian 256-bit number.
+// The 2 most significant bits are flags; the remaining 254 bits hold a field element F.
+//
+//   - bit 255 (isInfinity) is set iff the point is at infinity. In this case, bit 254 is 0 for E1 and 1 for E2 and F == 0.
+//   - otherwise, F == |X*Sign(Y)| and bit 254 (signY) is set iff X*Sign(Y) is negative, i.e. we store X*Sign(Y) in sign-magnitude form.
+//
+// Note that the base field has 255 bits, so we cannot fit an arbitrary X coordinate into 254 bits.
+// We only store X*Sign(Y) in the range 0 <= . <= (p-1)/2 (which fits) instead, which determines the point modulo the affine point of order 2, as in the Banderwagon format.
+// As a consequence, this format can represent exactly the points in the prime-order subgroup and the two points at infinity.
+// Serializing other points results in an error wrapping ErrWillNotSerializePointOutsideSubgroup and deserialization always gives a point in the subgroup or at infinity.
+//
+// The format has no parameters. In particular, it is always little-endian and IsSubgroupOnly() returns false (because of the points at infinity).
+type pointSerializerFlagged struct{}
+
+// flags used by pointSerializerFlagged, as a 2-bit prefix of the field element.
+const (
+	flaggedSerializerFlagSignY      common.PrefixBits = 0b01
+	flaggedSerializerFlagIsInfinity common.PrefixBits = 0b10
+	flaggedSerializerNumFlagBits    uint8             = 2
+)
+
+var (
+	ErrInvalidInfinityEncoding       = errors.New(ErrorPrefix + "encountered encoding of a point at infinity with non-zero field element")
+	ErrNonCanonicalFlaggedEncoding   = errors.New(ErrorPrefix + "encountered encoding of X*Sign(Y) with a non-canonical sign")
+	ErrCannotDeserializeInfinityHere = errors.New(ErrorPrefix + "encountered encoding of a point at infinity, but the point to deserialize into cannot represent it")
+)
+
+// SerializeCurvePoint writes a single curve point to the given output. This works for points in the subgroup and for points at infinity.
+func (s *pointSerializerFlagged) SerializeCurvePoint(output io.Writer, point curvePoints.CurvePointPtrInterfaceRead) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	if point.IsNaP() {
+		err = addErrorDataNoWrite(bandersnatchErrors.ErrCannotSerializeNaP)
+		return
+	}
+	var F fieldElements.FieldElement // zero-initialized
+	var flags common.PrefixBits
+	if point.IsAtInfinity() {
+		flags = flaggedSerializerFlagIsInfinity
+		if point.(curvePoints.CurvePointPtrInterfaceDistinguishInfinity).IsE2() {
+			flags |= flaggedSerializerFlagSignY
+		}
+	} else {
+		if !point.IsInSubgroup() {
+			err = addErrorDataNoWrite(bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup)
+			return
+		}
+		// X*Sign(Y) is invariant under (X,Y) -> (-X,-Y), so we may use the decaf coordinates.
+		X, Y := point.X_decaf_affine(), point.Y_decaf_affine()
+		if Y.Sign() < 0 {
+			X.NegEq()
+		}
+		// X now holds X*Sign(Y).
+		if X.Sign() < 0 {
+			X.NegEq()
+			flags = flaggedSerializerFlagSignY
+		}
+		F = X
+	}
+	bytesWritten, err = F.SerializeWithPrefix(output, common.MakeBitHeader(flags, flaggedSerializerNumFlagBits), common.LittleEndian)
+	return
+}
+
+// DeserializeCurvePoint reads from input, interprets it and overwrites point.
+// On error, point is untouched.
+//
+// Deserializing a point at infinity fails with an error wrapping ErrCannotDeserializeInfinityHere if point cannot represent points at infinity.
+func (s *pointSerializerFlagged) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
+	var F fieldElements.FieldElement
+	var flags common.PrefixBits
+	bytesRead, flags, err = F.DeserializeAndGetPrefix(input, flaggedSerializerNumFlagBits, common.LittleEndian)
+	// Note: F < 2^254 is always normalized, so we never get ErrNonNormalizedDeserialization here.
+	if err != nil {
+		return
+	}
+
+	var errPlain error
+	defer func() {
+		if errPlain != nil {
+			err = errorsWithData.NewErrorWithParametersFromData(errPlain, "%w", &bandersnatchErrors.ReadErrorData{
+				PartialRead:  false,
+				BytesRead:    int(s.OutputLength()),
+				ActuallyRead: nil,
+			})
+		}
+	}()
+
+	if flags&flaggedSerializerFlagIsInfinity != 0 {
+		if !F.IsZero() {
+			errPlain = ErrInvalidInfinityEncoding
+			return
+		}
+		if point.CanOnlyRepresentSubgroup() || !point.CanRepresentInfinity() {
+			errPlain = ErrCannotDeserializeInfinityHere
+			return
+		}
+		if flags&flaggedSerializerFlagSignY == 0 {
+			point.SetFrom(&curvePoints.InfinitePoint1_xtw)
+		} else {
+			point.SetFrom(&curvePoints.InfinitePoint2_xtw)
+		}
+		return
+	}
+
+	// F must be the canonical absolute value. For F == 0, the sign flag must not be set.
+	if F.Sign() < 0 || (F.IsZero() && flags&flaggedSerializerFlagSignY != 0) {
+		errPlain = ErrNonCanonicalFlaggedEncoding
+		return
+	}
+	if flags&flaggedSerializerFlagSignY != 0 {
+		F.NegEq()
+	}
+	P, errConversionToCurvePoint := curvePoints.CurvePointFromXTimesSignY_subgroup(&F, trustLevel)
+	if errConversionToCurvePoint != nil {
+		errPlain = errConversionToCurvePoint
+		return
+	}
+	point.SetFrom(&P)
+	return
+}
+
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+// Note that for this format, all valid encodings are canonical.
+func (s *pointSerializerFlagged) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
+// Clone creates an independent copy of the received serializer, returning a pointer.
+//
+// Note that since serializers are immutable, library users should never need to call this;
+// this is an internal function that is exported due to cross-package and reflect usage.
+func (s *pointSerializerFlagged) Clone() (ret *pointSerializerFlagged) {
+	var sCopy pointSerializerFlagged = *s
+	return &sCopy
+}
+
+// OutputLength returns the number of bytes read/written per curve point.
+//
+// It returns 32 for this serializer type.
+func (s *pointSerializerFlagged) OutputLength() int32 { return 32 }
+
+// GetEndianness returns the endianness used for field element serialization. This is always common.LittleEndian for this serializer type.
+func (s *pointSerializerFlagged) GetEndianness() common.FieldElementEndianness {
+	return common.LittleEndian
+}
+
+// IsSubgroupOnly returns false for this serializer type, because it can (de)serialize points at infinity.
+//
+// Note that it still cannot (de)serialize any other points outside the subgroup.
+func (s *pointSerializerFlagged) IsSubgroupOnly() bool { return false }
+
+// GetParameter returns the value of the internal parameter determined by parameterName.
+//
+// This serializer type has no parameters, so this always panics.
+func (s *pointSerializerFlagged) GetParameter(parameterName string) interface{} {
+	return getSerializerParameter(s, parameterName)
+}
+
+// Validate perfoms a self-check of the internal parameters stored for the given serializer.
+// It panics on failure.
+//
+// Since this serializer type has no parameters, this does nothing.
+func (s *pointSerializerFlagged) Validate() {}
+
+// RecognizedParameters returns a list of all parameter names accepted by GetParameter. This is empty for this serializer type.
+func (s *pointSerializerFlagged) RecognizedParameters() []string {
+	return []string{}
+}
+
+// HasParameter checks whether the given parameter name is accepted by GetParameter.
+func (s *pointSerializerFlagged) HasParameter(parameterName string) bool {
+	return utils.ElementInList(parameterName, s.RecognizedParameters(), normalizeParameter)
+}
@@ -0,0 +1,158 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ curvePointDeserializer_basic = &pointSerializerFlagged{}
+var _ curvePointSerializer_basic = &pointSerializerFlagged{}
+
+func TestFlaggedSerializerRoundTrip(t *testing.T) {
+	var s pointSerializerFlagged
+	s.Validate()
+	testutils.FatalUnless(t, s.OutputLength() == 32, "")
+	testutils.FatalUnless(t, !s.IsSubgroupOnly(), "")
+	testutils.FatalUnless(t, s.GetEndianness() == common.LittleEndian, "")
+	testutils.FatalUnless(t, len(s.RecognizedParameters()) == 0, "")
+
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	points := []curvePoints.Point_xtw_subgroup{curvePoints.NeutralElement_xtw_subgroup}
+	for i := 0; i < 100; i++ {
+		points = append(points, curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng))
+	}
+	for _, P := range points {
+		var buf bytes.Buffer
+		bytesWritten, errSerialize := s.SerializeCurvePoint(&buf, &P)
+		testutils.FatalUnless(t, errSerialize == nil, "Serialization failed with error %v", errSerialize)
+		testutils.FatalUnless(t, bytesWritten == 32 && buf.Len() == 32, "")
+		encoding := buf.Bytes()
+		testutils.FatalUnless(t, encoding[31]&0x80 == 0, "isInfinity flag set for finite point")
+
+		ok, errCanonical := s.IsCanonical(encoding)
+		testutils.FatalUnless(t, ok && errCanonical == nil, "Encoding not recognized as canonical: %v", errCanonical)
+
+		var Q curvePoints.Point_xtw_subgroup
+		bytesRead, err := s.DeserializeCurvePoint(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, err == nil, "Deserialization failed with error %v", err)
+		testutils.FatalUnless(t, bytesRead == 32, "")
+		testutils.FatalUnless(t, Q.IsEqual(&P), "Round-trip failed")
+
+		var QFull curvePoints.Point_xtw_full
+		_, err = s.DeserializeCurvePoint(bytes.NewReader(encoding), common.TrustedInput, &QFull)
+		testutils.FatalUnless(t, err == nil, "Deserialization into full point failed with error %v", err)
+		testutils.FatalUnless(t, QFull.IsEqual(&P), "Round-trip into full point failed")
+
+		// The encoding is in sign-magnitude form of X*Sign(Y).
+		var F fieldElements.FieldElement
+		var flippedEncoding [32]byte
+		copy(flippedEncoding[:], encoding)
+		flippedEncoding[31] &= 0x3F
+		_, errFieldElement := F.Deserialize(bytes.NewReader(flippedEncoding[:]), common.LittleEndian)
+		testutils.FatalUnless(t, errFieldElement == nil, "")
+		if encoding[31]&0x40 != 0 {
+			F.NegEq()
+		}
+		XSignY := P.X_decaf_affine()
+		if Y := P.Y_decaf_affine(); Y.Sign() < 0 {
+			XSignY.NegEq()
+		}
+		testutils.FatalUnless(t, F.IsEqual(&XSignY), "Encoded value does not match X*Sign(Y)")
+	}
+
+	// The neutral element encodes to all-zeros
+	var buf bytes.Buffer
+	_, errSerialize := s.SerializeCurvePoint(&buf, &curvePoints.NeutralElement_xtw_full)
+	testutils.FatalUnless(t, errSerialize == nil, "")
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), make([]byte, 32)), "Neutral element does not encode to all-zeros")
+}
+
+func TestFlaggedSerializerInfinity(t *testing.T) {
+	var s pointSerializerFlagged
+	for i, infinite := range []curvePoints.Point_xtw_full{curvePoints.InfinitePoint1_xtw, curvePoints.InfinitePoint2_xtw} {
+		var buf bytes.Buffer
+		_, errSerialize := s.SerializeCurvePoint(&buf, &infinite)
+		testutils.FatalUnless(t, errSerialize == nil, "Could not serialize point at infinity: %v", errSerialize)
+		var expected [32]byte
+		expected[31] = 0x80 | byte(i<<6)
+		testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expected[:]), "Unexpected encoding of point at infinity: %v", buf.Bytes())
+
+		var Q curvePoints.Point_xtw_full
+		_, err := s.DeserializeCurvePoint(bytes.NewReader(expected[:]), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, err == nil, "Could not deserialize point at infinity: %v", err)
+		testutils.FatalUnless(t, Q.IsEqual(&infinite), "Round-trip failed for point at infinity")
+		ok, errCanonical := s.IsCanonical(expected[:])
+		testutils.FatalUnless(t, ok && errCanonical == nil, "")
+
+		// subgroup-only types cannot hold points at infinity
+		var QSub curvePoints.Point_xtw_subgroup = curvePoints.NeutralElement_xtw_subgroup
+		_, err = s.DeserializeCurvePoint(bytes.NewReader(expected[:]), common.UntrustedInput, &QSub)
+		testutils.FatalUnless(t, errors.Is(err, ErrCannotDeserializeInfinityHere), "Unexpected error %v", err)
+		testutils.FatalUnless(t, QSub.IsNeutralElement(), "Deserialization modified point on error")
+
+		// non-zero payload with infinity flag set
+		invalid := expected
+		invalid[0] = 1
+		_, err = s.DeserializeCurvePoint(bytes.NewReader(invalid[:]), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidInfinityEncoding), "Unexpected error %v", err)
+	}
+}
+
+func TestFlaggedSerializerErrors(t *testing.T) {
+	var s pointSerializerFlagged
+	var buf bytes.Buffer
+
+	// points outside the subgroup
+	_, errSerialize := s.SerializeCurvePoint(&buf, &curvePoints.AffineOrderTwoPoint_xtw)
+	testutils.FatalUnless(t, errors.Is(errSerialize, bandersnatchErrors.ErrWillNotSerializePointOutsideSubgroup), "Unexpected error %v", errSerialize)
+	var NaP curvePoints.Point_xtw_full
+	_, errSerialize = s.SerializeCurvePoint(&buf, &NaP)
+	testutils.FatalUnless(t, errors.Is(errSerialize, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error %v", errSerialize)
+	testutils.FatalUnless(t, buf.Len() == 0, "")
+
+	// negative zero
+	var negativeZero [32]byte
+	negativeZero[31] = 0x40
+	var Q curvePoints.Point_xtw_full
+	_, err := s.DeserializeCurvePoint(bytes.NewReader(negativeZero[:]), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, errors.Is(err, ErrNonCanonicalFlaggedEncoding), "Unexpected error %v", err)
+
+	// magnitude larger than (p-1)/2. 1/2 == (p+1)/2 is the smallest such value.
+	var F fieldElements.FieldElement
+	F.SetUInt64(2)
+	F.InvEq()
+	testutils.FatalUnless(t, F.Sign() < 0, "")
+	var bufF bytes.Buffer
+	F.Serialize(&bufF, common.LittleEndian)
+	_, err = s.DeserializeCurvePoint(bytes.NewReader(bufF.Bytes()), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, errors.Is(err, ErrNonCanonicalFlaggedEncoding), "Unexpected error %v", err)
+
+	// not on curve. We search for a small value that is not X*Sign(Y) for any subgroup point.
+	var found bool
+	for i := uint64(1); i < 100; i++ {
+		var candidate fieldElements.FieldElement
+		candidate.SetUInt64(i)
+		if _, errCurve := curvePoints.CurvePointFromXTimesSignY_subgroup(&candidate, common.UntrustedInput); errCurve == nil {
+			continue
+		}
+		found = true
+		var encoding bytes.Buffer
+		candidate.Serialize(&encoding, common.LittleEndian)
+		_, err = s.DeserializeCurvePoint(bytes.NewReader(encoding.Bytes()), common.UntrustedInput, &Q)
+		testutils.FatalUnless(t, err != nil, "Deserialization of invalid point did not fail")
+		break
+	}
+	testutils.FatalUnless(t, found, "")
+
+	// short input
+	_, err = s.DeserializeCurvePoint(bytes.NewReader(make([]byte, 31)), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, err != nil, "")
+}
@@ -0,0 +1,169 @@
+package pointserializer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+)
+
+// This file contains FormatDescriptor, which allows peers in a protocol to exchange and agree on a serialization format.
+
+// FormatDescriptor identifies a serialization format of a basic serializer by its format name, endianness and subgroup restriction.
+//
+// FormatDescriptors can be converted to and from a compact wire token via EncodeDescriptor and DecodeDescriptor.
+// The matching serializer can be constructed with Serializer(), which uses the by-name factory SerializerByName.
+//
+// Note that a FormatDescriptor does not capture any other parameters (such as bit headers); the default as given by SerializerByName is used for those.
+type FormatDescriptor struct {
+	FormatName   string                        // one of the FormatName... constants
+	Endianness   common.FieldElementEndianness // endianness of field elements
+	SubgroupOnly bool                          // whether the serializer is restricted to the prime-order subgroup
+}
+
+var (
+	ErrInvalidFormatDescriptor = errors.New(ErrorPrefix + "invalid format descriptor")
+	ErrNotDescribable          = errors.New(ErrorPrefix + "serializer cannot be described by a FormatDescriptor")
+)
+
+// formatDescriptorVersion is the first byte of encoded format descriptors.
+const formatDescriptorVersion = 1
+
+// formatDescriptorLength is the length of encoded format descriptors in bytes.
+const formatDescriptorLength = 3
+
+// formatDescriptorIDs assigns a (wire) identifier to each format name. These must never change.
+var formatDescriptorIDs = map[string]byte{
+	FormatNameXY:           1,
+	FormatNameXAndSignY:    2,
+	FormatNameYAndSignX:    3,
+	FormatNameXTimesSignY:  4,
+	FormatNameYXTimesSignY: 5,
+}
+
+// flags in the last byte of encoded format descriptors. All other bits must be zero.
+const (
+	formatDescriptorFlagBigEndian    byte = 1 << 0
+	formatDescriptorFlagSubgroupOnly byte = 1 << 1
+	formatDescriptorFlagsAll              = formatDescriptorFlagBigEndian | formatDescriptorFlagSubgroupOnly
+)
+
+// DescriptorOf returns the FormatDescriptor for the given basic serializer.
+//
+// It returns an error wrapping ErrNotDescribable if the serializer cannot be reconstructed from its descriptor, e.g. because it uses non-default bit headers.
+func DescriptorOf(serializer curvePointSerializer_basic) (descriptor FormatDescriptor, err error) {
+	descriptor.FormatName, err = FormatNameOf(serializer)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrNotDescribable, err)
+		return
+	}
+	descriptor.Endianness = serializer.GetEndianness()
+	descriptor.SubgroupOnly = serializer.IsSubgroupOnly()
+	reconstructed, errReconstruct := descriptor.Serializer()
+	if errReconstruct != nil {
+		// not supposed to be possible
+		panic(fmt.Errorf(ErrorPrefix+"DescriptorOf could not construct serializer from descriptor %v: %v", descriptor, errReconstruct))
+	}
+	if !SameFormat(serializer, reconstructed) {
+		err = fmt.Errorf("%w: serializer of format %v uses non-default parameters", ErrNotDescribable, descriptor.FormatName)
+		descriptor = FormatDescriptor{}
+		return
+	}
+	return
+}
+
+// Serializer constructs a serializer matching the descriptor via SerializerByName.
+//
+// It returns an error wrapping ErrInvalidFormatDescriptor if the descriptor is invalid, e.g. because the format name is unknown or
+// because the descriptor asks for a subgroup-only format without subgroup restriction.
+func (descriptor FormatDescriptor) Serializer() (curvePointSerializer_basic, error) {
+	if err := descriptor.validate(); err != nil {
+		return nil, err
+	}
+	serializer, err := SerializerByName(descriptor.FormatName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormatDescriptor, err) // not supposed to be possible after validate()
+	}
+	serializer = withParameterBasic(serializer, "Endianness", descriptor.Endianness)
+	if descriptor.SubgroupOnly != serializer.IsSubgroupOnly() {
+		serializer = withParameterBasic(serializer, "SubgroupOnly", descriptor.SubgroupOnly)
+	}
+	return serializer, nil
+}
+
+// validate checks the descriptor for validity. On failure, it returns an error wrapping ErrInvalidFormatDescriptor.
+func (descriptor FormatDescriptor) validate() error {
+	if _, ok := formatDescriptorIDs[descriptor.FormatName]; !ok {
+		return fmt.Errorf("%w: unknown format name %q", ErrInvalidFormatDescriptor, descriptor.FormatName)
+	}
+	if descriptor.Endianness != common.LittleEndian && descriptor.Endianness != common.BigEndian {
+		return fmt.Errorf("%w: invalid endianness", ErrInvalidFormatDescriptor)
+	}
+	defaultSerializer, _ := SerializerByName(descriptor.FormatName)
+	if defaultSerializer.IsSubgroupOnly() && !descriptor.SubgroupOnly {
+		return fmt.Errorf("%w: format %v is only available for subgroup elements", ErrInvalidFormatDescriptor, descriptor.FormatName)
+	}
+	return nil
+}
+
+// EncodeDescriptor encodes the descriptor as a compact wire token. It panics if the descriptor is invalid.
+//
+// The token currently consists of 3 bytes: a version byte, a byte identifying the format and a byte of flags.
+func (descriptor FormatDescriptor) EncodeDescriptor() []byte {
+	if err := descriptor.validate(); err != nil {
+		panic(err)
+	}
+	var flags byte
+	if descriptor.Endianness == common.BigEndian {
+		flags |= formatDescriptorFlagBigEndian
+	}
+	if descriptor.SubgroupOnly {
+		flags |= formatDescriptorFlagSubgroupOnly
+	}
+	return []byte{formatDescriptorVersion, formatDescriptorIDs[descriptor.FormatName], flags}
+}
+
+// DecodeDescriptor decodes a wire token as output by EncodeDescriptor.
+//
+// Unknown or malformed tokens are rejected with an error wrapping ErrInvalidFormatDescriptor; this is safe to call on untrusted input.
+func DecodeDescriptor(data []byte) (descriptor FormatDescriptor, err error) {
+	if len(data) != formatDescriptorLength {
+		err = fmt.Errorf("%w: encoded descriptor has length %v, expected %v", ErrInvalidFormatDescriptor, len(data), formatDescriptorLength)
+		return
+	}
+	if data[0] != formatDescriptorVersion {
+		err = fmt.Errorf("%w: unknown descriptor version %v", ErrInvalidFormatDescriptor, data[0])
+		return
+	}
+	formatName, ok := formatNameFromDescriptorID(data[1])
+	if !ok {
+		err = fmt.Errorf("%w: unknown format identifier %v", ErrInvalidFormatDescriptor, data[1])
+		return
+	}
+	flags := data[2]
+	if flags&^formatDescriptorFlagsAll != 0 {
+		err = fmt.Errorf("%w: unknown flags %08b", ErrInvalidFormatDescriptor, flags)
+		return
+	}
+	descriptor.FormatName = formatName
+	if flags&formatDescriptorFlagBigEndian != 0 {
+		descriptor.Endianness = common.BigEndian
+	} else {
+		descriptor.Endianness = common.LittleEndian
+	}
+	descriptor.SubgroupOnly = flags&formatDescriptorFlagSubgroupOnly != 0
+	if err = descriptor.validate(); err != nil {
+		descriptor = FormatDescriptor{}
+	}
+	return
+}
+
+// formatNameFromDescriptorID is the inverse of the formatDescriptorIDs map.
+func formatNameFromDescriptorID(id byte) (string, bool) {
+	for name, nameID := range formatDescriptorIDs {
+		if id == nameID {
+			return name, true
+		}
+	}
+	return "", false
+}
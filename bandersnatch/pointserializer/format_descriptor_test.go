@@ -0,0 +1,86 @@
+package pointserializer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestFormatDescriptorRoundtrip(t *testing.T) {
+	formatNames := []string{FormatNameXY, FormatNameXAndSignY, FormatNameYAndSignX, FormatNameXTimesSignY, FormatNameYXTimesSignY}
+	seenEncodings := make(map[string]bool)
+	for _, formatName := range formatNames {
+		for _, endianness := range []common.FieldElementEndianness{common.LittleEndian, common.BigEndian} {
+			for _, subgroupOnly := range []bool{false, true} {
+				descriptor := FormatDescriptor{FormatName: formatName, Endianness: endianness, SubgroupOnly: subgroupOnly}
+				serializer, err := descriptor.Serializer()
+				if formatName == FormatNameXTimesSignY || formatName == FormatNameYXTimesSignY {
+					if !subgroupOnly {
+						testutils.FatalUnless(t, errors.Is(err, ErrInvalidFormatDescriptor), "Serializer() did not reject subgroup-only format without subgroup restriction")
+						testutils.FatalUnless(t, testutils.CheckPanic(descriptor.EncodeDescriptor), "EncodeDescriptor did not panic on invalid descriptor")
+						continue
+					}
+				}
+				testutils.FatalUnless(t, err == nil, "Serializer() failed for %v: %v", descriptor, err)
+				testutils.FatalUnless(t, serializer.GetEndianness() == endianness, "Serializer() did not set endianness")
+				testutils.FatalUnless(t, serializer.IsSubgroupOnly() == subgroupOnly, "Serializer() did not set subgroup restriction")
+
+				encoded := descriptor.EncodeDescriptor()
+				testutils.FatalUnless(t, !seenEncodings[string(encoded)], "EncodeDescriptor is not injective")
+				seenEncodings[string(encoded)] = true
+
+				decoded, err := DecodeDescriptor(encoded)
+				testutils.FatalUnless(t, err == nil, "DecodeDescriptor failed: %v", err)
+				testutils.FatalUnless(t, decoded == descriptor, "Encode / Decode of descriptor is not a roundtrip")
+				decodedSerializer, err := decoded.Serializer()
+				testutils.FatalUnless(t, err == nil, "Serializer() failed for decoded serializer: %v", err)
+				testutils.FatalUnless(t, SameFormat(serializer, decodedSerializer), "decoded descriptor does not build the same format")
+
+				descriptorOfSerializer, err := DescriptorOf(serializer)
+				testutils.FatalUnless(t, err == nil, "DescriptorOf failed: %v", err)
+				testutils.FatalUnless(t, descriptorOfSerializer == descriptor, "DescriptorOf(descriptor.Serializer()) != descriptor")
+			}
+		}
+	}
+}
+
+func TestFormatDescriptorOfSerializers(t *testing.T) {
+	for _, serializer := range []curvePointSerializer_basic{&ps_XSY, &ps_XSY_sub, &ps_YSX, &ps_YSX_sub, &ps_XxSY, &ps_XYxSY} {
+		descriptor, err := DescriptorOf(serializer)
+		testutils.FatalUnless(t, err == nil, "DescriptorOf failed: %v", err)
+		decoded, err := DecodeDescriptor(descriptor.EncodeDescriptor())
+		testutils.FatalUnless(t, err == nil, "DecodeDescriptor failed: %v", err)
+		reconstructed, err := decoded.Serializer()
+		testutils.FatalUnless(t, err == nil, "Serializer() failed: %v", err)
+		testutils.FatalUnless(t, SameFormat(serializer, reconstructed), "Serializer obtained via descriptor round-trip has different format")
+	}
+	// ps_XY uses a non-default bit header
+	_, err := DescriptorOf(&ps_XY)
+	testutils.FatalUnless(t, errors.Is(err, ErrNotDescribable), "DescriptorOf did not detect non-default parameters. Got %v", err)
+}
+
+func TestDecodeInvalidDescriptor(t *testing.T) {
+	valid := FormatDescriptor{FormatName: FormatNameXY, Endianness: common.BigEndian, SubgroupOnly: true}.EncodeDescriptor()
+	invalidInputs := [][]byte{
+		nil,
+		{},
+		valid[:2],
+		append(append([]byte{}, valid...), 0),
+		{0, valid[1], valid[2]},   // wrong version
+		{valid[0], 0, valid[2]},   // unknown format
+		{valid[0], 200, valid[2]}, // unknown format
+		{valid[0], valid[1], 0x80},
+		{valid[0], formatDescriptorIDs[FormatNameXTimesSignY], 0}, // subgroup-only format without subgroup flag
+	}
+	for _, invalid := range invalidInputs {
+		descriptor, err := DecodeDescriptor(invalid)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidFormatDescriptor), "DecodeDescriptor did not reject invalid input %v", invalid)
+		testutils.FatalUnless(t, descriptor == FormatDescriptor{}, "DecodeDescriptor returned non-zero descriptor on error")
+	}
+	_, err := FormatDescriptor{FormatName: "NoSuchFormat", Endianness: common.LittleEndian}.Serializer()
+	testutils.FatalUnless(t, errors.Is(err, ErrInvalidFormatDescriptor), "Serializer() accepted unknown format name")
+	_, err = FormatDescriptor{FormatName: FormatNameXY}.Serializer()
+	testutils.FatalUnless(t, errors.Is(err, ErrInvalidFormatDescriptor), "Serializer() accepted zero endianness")
+}
@@ -0,0 +1,175 @@
+package pointserializer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+)
+
+// This file contains a by-name factory for basic serializers.
+// This allows selecting the serialization format at runtime, e.g. from configuration files or during protocol negotiation.
+
+// Names of the formats of the basic serializers. Format names are case-insensitive.
+const (
+	FormatNameXY           = "XY"           // X and Y coordinates
+	FormatNameXAndSignY    = "XAndSignY"    // X coordinate and sign of Y, compressed into one field element
+	FormatNameYAndSignX    = "YAndSignX"    // Y coordinate and sign of X, compressed into one field element
+	FormatNameXTimesSignY  = "XTimesSignY"  // X * Sign(Y). Subgroup only. This is the short Banderwagon format.
+	FormatNameYXTimesSignY = "YXTimesSignY" // Y * Sign(Y) and X * Sign(Y). Subgroup only. This is the long Banderwagon format.
+)
+
+// Aliases for format names. These are accepted by SerializerByName, but never output.
+const (
+	FormatNameBanderwagonShort = "BanderwagonShort" // alias for FormatNameXTimesSignY
+	FormatNameBanderwagonLong  = "BanderwagonLong"  // alias for FormatNameYXTimesSignY
+)
+
+var ErrUnknownFormatName = errors.New(ErrorPrefix + "unknown serialization format name")
+
+// defaultSerializersByName maps normalized format names to the default serializers for this format.
+// The entries must never be modified; SerializerByName returns copies.
+//
+// All defaults use common.DefaultEndian and are not restricted to the subgroup (unless the format requires it).
+var defaultSerializersByName = map[string]curvePointSerializer_basic{
+	normalizeParameter(FormatNameXY):               &pointSerializerXY{valuesSerializerHeaderFeHeaderFe: valuesSerializerHeaderFeHeaderFe{fieldElementEndianness: common.DefaultEndian}, subgroupRestriction: subgroupRestriction{}},
+	normalizeParameter(FormatNameXAndSignY):        &pointSerializerXAndSignY{valuesSerializerFeCompressedBit: valuesSerializerFeCompressedBit{fieldElementEndianness: common.DefaultEndian}, subgroupRestriction: subgroupRestriction{}},
+	normalizeParameter(FormatNameYAndSignX):        &pointSerializerYAndSignX{valuesSerializerFeCompressedBit: valuesSerializerFeCompressedBit{fieldElementEndianness: common.DefaultEndian}, subgroupRestriction: subgroupRestriction{}},
+	normalizeParameter(FormatNameXTimesSignY):      &basicBanderwagonShort,
+	normalizeParameter(FormatNameYXTimesSignY):     &basicBanderwagonLong,
+	normalizeParameter(FormatNameBanderwagonShort): &basicBanderwagonShort,
+	normalizeParameter(FormatNameBanderwagonLong):  &basicBanderwagonLong,
+}
+
+func init() {
+	for _, serializer := range defaultSerializersByName {
+		serializer.Validate()
+	}
+}
+
+// SerializerByName returns (an independent copy of) the default basic serializer for the format with the given (case-insensitive) name.
+// Use the FormatName... constants for valid names.
+//
+// The returned serializer uses common.DefaultEndian and is not restricted to the subgroup unless the format requires this.
+// The only possible error is (an error wrapping) ErrUnknownFormatName.
+func SerializerByName(name string) (curvePointSerializer_basic, error) {
+	serializer, ok := defaultSerializersByName[normalizeParameter(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormatName, name)
+	}
+	return cloneBasicSerializer(serializer), nil
+}
+
+// ErrEncodedSizeOverflow is returned (wrapped) by EncodedSize if the requested size does not fit into an int64 or the number of points is negative.
+var ErrEncodedSizeOverflow = errors.New(ErrorPrefix + "requested encoded size is out of range")
+
+// EncodedSize returns the exact number of bytes needed to encode numPoints many points in the format with the given (case-insensitive) name,
+// written back-to-back with the default serializer for this format, as returned by SerializerByName.
+// This allows pre-sizing files and buffers without instantiating a serializer.
+//
+// Composite serializers such as those returned by NewChecksumSerializer or NewDomainTagSerializer add extra bytes per point and have no format name;
+// use EncodedSizeOf for those. For serializers with slice headers, use their SliceOutputLength method instead.
+//
+// Possible errors are (errors wrapping) ErrUnknownFormatName and ErrEncodedSizeOverflow.
+func EncodedSize(format string, numPoints int) (int64, error) {
+	serializer, ok := defaultSerializersByName[normalizeParameter(format)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownFormatName, format)
+	}
+	return EncodedSizeOf(serializer, numPoints)
+}
+
+// EncodedSizeOf returns the exact number of bytes needed to encode numPoints many points written back-to-back with the given serializer.
+// The size of a single point is serializer.OutputLength(), which includes any per-point headers or prefixes (such as checksums or domain tags) of composite serializers.
+//
+// The only possible error is (an error wrapping) ErrEncodedSizeOverflow.
+func EncodedSizeOf(serializer curvePointDeserializer_basic, numPoints int) (int64, error) {
+	if numPoints < 0 {
+		return 0, fmt.Errorf("%w: negative number of points %v", ErrEncodedSizeOverflow, numPoints)
+	}
+	pointSize := int64(serializer.OutputLength())
+	if pointSize != 0 && int64(numPoints) > math.MaxInt64/pointSize {
+		return 0, fmt.Errorf("%w: %v points of size %v do not fit into an int64", ErrEncodedSizeOverflow, numPoints, pointSize)
+	}
+	return int64(numPoints) * pointSize, nil
+}
+
+// FormatNameOf returns the name of the format used by the given basic serializer. This is one of the FormatName... constants (but never an alias).
+//
+// Note that the format name does not capture any parameters of the serializer, such as endianness or bit headers.
+func FormatNameOf(serializer curvePointDeserializer_basic) (string, error) {
+	switch serializer.(type) {
+	case *pointSerializerXY:
+		return FormatNameXY, nil
+	case *pointSerializerXAndSignY:
+		return FormatNameXAndSignY, nil
+	case *pointSerializerYAndSignX:
+		return FormatNameYAndSignX, nil
+	case *pointSerializerXTimesSignY:
+		return FormatNameXTimesSignY, nil
+	case *pointSerializerYXTimesSignY:
+		return FormatNameYXTimesSignY, nil
+	default:
+		return "", fmt.Errorf("%w: serializer of type %T has no known format name", ErrUnknownFormatName, serializer)
+	}
+}
+
+// cloneBasicSerializer returns an independent copy of the given basic serializer.
+func cloneBasicSerializer(serializer curvePointSerializer_basic) curvePointSerializer_basic {
+	switch serializer := serializer.(type) {
+	case *pointSerializerXY:
+		return serializer.Clone()
+	case *pointSerializerXAndSignY:
+		return serializer.Clone()
+	case *pointSerializerYAndSignX:
+		return serializer.Clone()
+	case *pointSerializerXTimesSignY:
+		return serializer.Clone()
+	case *pointSerializerYXTimesSignY:
+		return serializer.Clone()
+	default:
+		panic(fmt.Errorf(ErrorPrefix+"cloneBasicSerializer called with unsupported serializer type %T", serializer))
+	}
+}
+
+// withParameterBasic is a non-generic version of the WithParameter methods of the basic serializers.
+// It returns a modified copy of serializer with the given parameter changed to newParam. Invalid inputs cause a panic.
+func withParameterBasic(serializer curvePointSerializer_basic, parameterName string, newParam any) curvePointSerializer_basic {
+	switch serializer := serializer.(type) {
+	case *pointSerializerXY:
+		ret := serializer.WithParameter(parameterName, newParam)
+		return &ret
+	case *pointSerializerXAndSignY:
+		ret := serializer.WithParameter(parameterName, newParam)
+		return &ret
+	case *pointSerializerYAndSignX:
+		ret := serializer.WithParameter(parameterName, newParam)
+		return &ret
+	case *pointSerializerXTimesSignY:
+		ret := serializer.WithParameter(parameterName, newParam)
+		return &ret
+	case *pointSerializerYXTimesSignY:
+		ret := serializer.WithParameter(parameterName, newParam)
+		return &ret
+	default:
+		panic(fmt.Errorf(ErrorPrefix+"withParameterBasic called with unsupported serializer type %T", serializer))
+	}
+}
+
+// SameFormat checks whether two basic (de)serializers use exactly the same format, i.e. they have the same type and agree on all parameters.
+func SameFormat(serializer1, serializer2 curvePointDeserializer_basic) bool {
+	if reflect.TypeOf(serializer1) != reflect.TypeOf(serializer2) {
+		return false
+	}
+	for _, parameterName := range serializer1.RecognizedParameters() {
+		if !serializer2.HasParameter(parameterName) {
+			return false
+		}
+		if !reflect.DeepEqual(serializer1.GetParameter(parameterName), serializer2.GetParameter(parameterName)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestSerializerByName(t *testing.T) {
+	for name, expected := range map[string]curvePointSerializer_basic{
+		FormatNameXY:               nil,
+		FormatNameXAndSignY:        nil,
+		FormatNameYAndSignX:        nil,
+		FormatNameXTimesSignY:      &basicBanderwagonShort,
+		FormatNameYXTimesSignY:     &basicBanderwagonLong,
+		"banderwagonshort":         &basicBanderwagonShort,
+		FormatNameBanderwagonLong:  &basicBanderwagonLong,
+		FormatNameBanderwagonShort: &basicBanderwagonShort,
+	} {
+		serializer, err := SerializerByName(name)
+		testutils.FatalUnless(t, err == nil, "SerializerByName(%v) failed: %v", name, err)
+		serializer.Validate()
+		testutils.FatalUnless(t, serializer.GetEndianness() == common.DefaultEndian, "SerializerByName does not use default endianness")
+		if expected != nil {
+			testutils.FatalUnless(t, SameFormat(serializer, expected), "SerializerByName(%v) does not give expected format", name)
+		}
+		formatName, err := FormatNameOf(serializer)
+		testutils.FatalUnless(t, err == nil, "FormatNameOf failed: %v", err)
+		serializer2, _ := SerializerByName(formatName)
+		testutils.FatalUnless(t, SameFormat(serializer, serializer2), "SerializerByName(FormatNameOf(.)) is not consistent")
+
+		// returned serializers are independent copies
+		serializer3, _ := SerializerByName(name)
+		testutils.FatalUnless(t, serializer != serializer3, "SerializerByName does not return independent copies")
+	}
+	_, err := SerializerByName("NoSuchFormat")
+	testutils.FatalUnless(t, errors.Is(err, ErrUnknownFormatName), "SerializerByName did not report unknown name. Got %v", err)
+}
+
+func TestSameFormat(t *testing.T) {
+	for i, s1 := range allBasicSerializers {
+		for j, s2 := range allBasicSerializers {
+			testutils.FatalUnless(t, SameFormat(s1, s2) == (i == j), "SameFormat gives wrong result for %v and %v", i, j)
+		}
+	}
+	XYBigEndian := ps_XY.WithEndianness(common.BigEndian)
+	testutils.FatalUnless(t, !SameFormat(&ps_XY, &XYBigEndian), "SameFormat does not detect differing endianness")
+	XYClone := ps_XY.Clone()
+	testutils.FatalUnless(t, SameFormat(&ps_XY, XYClone), "SameFormat does not recognize clones")
+}
+
+func TestEncodedSize(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, name := range []string{FormatNameXY, FormatNameXAndSignY, FormatNameYAndSignX, FormatNameXTimesSignY, FormatNameYXTimesSignY, FormatNameBanderwagonShort, FormatNameBanderwagonLong} {
+		serializer, err := SerializerByName(name)
+		testutils.FatalUnless(t, err == nil, "")
+		for _, numPoints := range []int{0, 1, 5} {
+			var buf bytes.Buffer
+			for i := 0; i < numPoints; i++ {
+				P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+				_, errSerialize := serializer.SerializeCurvePoint(&buf, &P)
+				testutils.FatalUnless(t, errSerialize == nil, "")
+			}
+			size, err := EncodedSize(name, numPoints)
+			testutils.FatalUnless(t, err == nil, "EncodedSize(%v, %v) failed: %v", name, numPoints, err)
+			testutils.FatalUnless(t, size == int64(buf.Len()), "EncodedSize(%v, %v) == %v, but actual output has size %v", name, numPoints, size, buf.Len())
+		}
+	}
+
+	// composite serializers add a checksum or a domain tag to each point.
+	domainTag := []byte("some domain tag")
+	for _, serializer := range []curvePointSerializer_basic{NewChecksumSerializer(&ps_XY, nil), NewChecksumSerializer(&ps_XxSY, nil), NewDomainTagSerializer(&ps_XSY, domainTag), NewDomainTagSerializer(&ps_XYxSY, nil)} {
+		for _, numPoints := range []int{0, 1, 5} {
+			var buf bytes.Buffer
+			for i := 0; i < numPoints; i++ {
+				P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+				_, errSerialize := serializer.SerializeCurvePoint(&buf, &P)
+				testutils.FatalUnless(t, errSerialize == nil, "")
+			}
+			size, err := EncodedSizeOf(serializer, numPoints)
+			testutils.FatalUnless(t, err == nil, "EncodedSizeOf(%T, %v) failed: %v", serializer, numPoints, err)
+			testutils.FatalUnless(t, size == int64(buf.Len()), "EncodedSizeOf(%T, %v) == %v, but actual output has size %v", serializer, numPoints, size, buf.Len())
+		}
+	}
+	sizeChecksum, _ := EncodedSizeOf(NewChecksumSerializer(&ps_XxSY, nil), 1)
+	sizeDomainTag, _ := EncodedSizeOf(NewDomainTagSerializer(&ps_XxSY, domainTag), 1)
+	testutils.FatalUnless(t, sizeChecksum == 32+4 && sizeDomainTag == int64(32+len(domainTag)), "")
+
+	_, err := EncodedSize("NoSuchFormat", 1)
+	testutils.FatalUnless(t, errors.Is(err, ErrUnknownFormatName), "EncodedSize did not report unknown name. Got %v", err)
+	_, err = EncodedSize(FormatNameXY, -1)
+	testutils.FatalUnless(t, errors.Is(err, ErrEncodedSizeOverflow), "EncodedSize did not report negative number of points. Got %v", err)
+	if strconv.IntSize == 64 {
+		_, err = EncodedSize(FormatNameXY, math.MaxInt)
+		testutils.FatalUnless(t, errors.Is(err, ErrEncodedSizeOverflow), "EncodedSize did not report overflow. Got %v", err)
+		_, err = EncodedSizeOf(NewChecksumSerializer(&ps_XY, nil), math.MaxInt)
+		testutils.FatalUnless(t, errors.Is(err, ErrEncodedSizeOverflow), "EncodedSizeOf did not report overflow. Got %v", err)
+	}
+}
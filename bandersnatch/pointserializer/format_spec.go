@@ -0,0 +1,109 @@
+package pointserializer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+)
+
+// This file contains SerializerFromSpec, which constructs a basic serializer from a compact, single-string specification.
+// This is intended for declarative configuration, e.g. via YAML files or command line flags.
+
+var ErrInvalidSerializerSpec = errors.New(ErrorPrefix + "invalid serializer specification")
+
+// Tokens recognized by SerializerFromSpec in addition to format names. All tokens are case-insensitive.
+const (
+	specTokenBanderwagon  = "banderwagon"   // must be followed by specTokenShort or specTokenLong
+	specTokenShort        = "short"         // banderwagon,short is equivalent to FormatNameBanderwagonShort
+	specTokenLong         = "long"          // banderwagon,long is equivalent to FormatNameBanderwagonLong
+	specTokenBigEndian    = "big-endian"    // use common.BigEndian
+	specTokenLittleEndian = "little-endian" // use common.LittleEndian
+	specTokenSubgroup     = "subgroup"      // restrict to the prime-order subgroup
+	specTokenFull         = "full"          // do not restrict to the prime-order subgroup. This is an error for subgroup-only formats.
+)
+
+// SerializerFromSpec constructs a basic serializer from a comma-separated specification string such as "banderwagon,short,big-endian,subgroup".
+//
+// The spec must contain exactly one format, given either as a (case-insensitive) format name as accepted by SerializerByName or as
+// "banderwagon" followed by "short" or "long". Optionally, it may contain at most one of "big-endian" and "little-endian" and at most one of "subgroup" and "full".
+// The order of tokens does not matter, except that "short" / "long" must directly follow "banderwagon". Whitespace around tokens is ignored.
+// Parameters that are not specified use the defaults of SerializerByName.
+//
+// On failure, we return an error wrapping ErrInvalidSerializerSpec that describes the offending token.
+func SerializerFromSpec(spec string) (curvePointSerializer_basic, error) {
+	var formatName string
+	var endianness common.FieldElementEndianness
+	var endiannessSet bool
+	var subgroupOnly bool
+	var subgroupOnlySet bool
+
+	tokens := strings.Split(spec, ",")
+	for i := 0; i < len(tokens); i++ {
+		token := normalizeParameter(strings.TrimSpace(tokens[i]))
+		switch token {
+		case "":
+			return nil, fmt.Errorf("%w: empty token at position %v in %q", ErrInvalidSerializerSpec, i, spec)
+		case specTokenBigEndian, specTokenLittleEndian:
+			if endiannessSet {
+				return nil, fmt.Errorf("%w: endianness specified more than once in %q", ErrInvalidSerializerSpec, spec)
+			}
+			endiannessSet = true
+			if token == specTokenBigEndian {
+				endianness = common.BigEndian
+			} else {
+				endianness = common.LittleEndian
+			}
+		case specTokenSubgroup, specTokenFull:
+			if subgroupOnlySet {
+				return nil, fmt.Errorf("%w: subgroup restriction specified more than once in %q", ErrInvalidSerializerSpec, spec)
+			}
+			subgroupOnlySet = true
+			subgroupOnly = (token == specTokenSubgroup)
+		default:
+			var newFormatName string
+			if token == specTokenBanderwagon {
+				if i+1 < len(tokens) {
+					switch normalizeParameter(strings.TrimSpace(tokens[i+1])) {
+					case specTokenShort:
+						newFormatName = FormatNameBanderwagonShort
+					case specTokenLong:
+						newFormatName = FormatNameBanderwagonLong
+					}
+				}
+				if newFormatName == "" {
+					return nil, fmt.Errorf("%w: %q must be followed by %q or %q in %q", ErrInvalidSerializerSpec, specTokenBanderwagon, specTokenShort, specTokenLong, spec)
+				}
+				i++
+			} else {
+				if _, err := SerializerByName(token); err != nil {
+					return nil, fmt.Errorf("%w: unrecognized token %q in %q", ErrInvalidSerializerSpec, strings.TrimSpace(tokens[i]), spec)
+				}
+				newFormatName = token
+			}
+			if formatName != "" {
+				return nil, fmt.Errorf("%w: format specified more than once in %q", ErrInvalidSerializerSpec, spec)
+			}
+			formatName = newFormatName
+		}
+	}
+	if formatName == "" {
+		return nil, fmt.Errorf("%w: no format specified in %q", ErrInvalidSerializerSpec, spec)
+	}
+
+	serializer, err := SerializerByName(formatName)
+	if err != nil {
+		panic(err) // cannot happen, we checked above
+	}
+	if endiannessSet {
+		serializer = withParameterBasic(serializer, "Endianness", endianness)
+	}
+	if subgroupOnlySet && subgroupOnly != serializer.IsSubgroupOnly() {
+		if !subgroupOnly {
+			return nil, fmt.Errorf("%w: format %v is only available for subgroup elements, but %q was specified in %q", ErrInvalidSerializerSpec, formatName, specTokenFull, spec)
+		}
+		serializer = withParameterBasic(serializer, "SubgroupOnly", true)
+	}
+	return serializer, nil
+}
@@ -0,0 +1,52 @@
+package pointserializer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestSerializerFromSpec(t *testing.T) {
+	banderwagonShortBigEndian := basicBanderwagonShort.WithEndianness(common.BigEndian)
+	XSYLittleEndian := ps_XSY.WithEndianness(common.LittleEndian)
+	XSYLittleEndianSubgroup := XSYLittleEndian.WithParameter("SubgroupOnly", true)
+	XYDefault, _ := SerializerByName(FormatNameXY)
+	for spec, expected := range map[string]curvePointSerializer_basic{
+		"banderwagon,short,big-endian,subgroup": &banderwagonShortBigEndian,
+		"banderwagon,short":                     &basicBanderwagonShort,
+		" Banderwagon , LONG ":                  &basicBanderwagonLong,
+		"subgroup,banderwagon,long":             &basicBanderwagonLong,
+		"BanderwagonShort,big-endian":           &banderwagonShortBigEndian,
+		"XTimesSignY,subgroup,big-endian":       &banderwagonShortBigEndian,
+		"xandsigny,little-endian,subgroup":      &XSYLittleEndianSubgroup,
+		"XY":                                    XYDefault,
+		"XY,full":                               XYDefault,
+	} {
+		serializer, err := SerializerFromSpec(spec)
+		testutils.FatalUnless(t, err == nil, "SerializerFromSpec(%q) failed: %v", spec, err)
+		serializer.Validate()
+		testutils.FatalUnless(t, SameFormat(serializer, expected), "SerializerFromSpec(%q) does not give expected serializer", spec)
+	}
+
+	for _, invalidSpec := range []string{
+		"",
+		"big-endian",
+		"banderwagon",
+		"banderwagon,big-endian",
+		"banderwagon,medium",
+		"short",
+		"banderwagon,short,banderwagon,long",
+		"XY,XAndSignY",
+		"XY,big-endian,little-endian",
+		"XY,subgroup,full",
+		"banderwagon,short,full",
+		"XY,,big-endian",
+		"XY,big-endian,",
+		"XY,middle-endian",
+	} {
+		_, err := SerializerFromSpec(invalidSpec)
+		testutils.FatalUnless(t, errors.Is(err, ErrInvalidSerializerSpec), "SerializerFromSpec(%q) did not fail as expected. Got error %v", invalidSpec, err)
+	}
+}
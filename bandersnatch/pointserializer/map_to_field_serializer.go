@@ -0,0 +1,140 @@
+package pointserializer
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/errorsWithData"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/utils"
+)
+
+// This file defines pointSerializerMapToField, a basic serializer that writes the image of a point under curvePoints.MapToFieldElement.
+
+// pointSerializerMapToField is a basic serializer that serializes a point P via the single field element X/Y = MapToFieldElement(P).
+// This serializer only works for subgroup elements: MapToFieldElement is only injective on the subgroup.
+//
+// As opposed to the short Banderwagon format X*Sign(Y), there is no need for a sign (or any header bits);
+// note that the neutral element is encoded as 0, i.e. as 32 zero bytes.
+type pointSerializerMapToField struct {
+	valuesSerializerFe
+	subgroupOnly
+	identityRejection
+	orderRequirement
+}
+
+// Validate perfoms a self-check of the internal parameters stored for the given serializer.
+// It panics on failure.
+//
+// Note that users are not expected to call this; this is provided for internal usage to unify parameter setting functions.
+// It is exported for cross-package/reflect usage.
+func (s *pointSerializerMapToField) Validate() {
+	s.valuesSerializerFe.Validate()
+	s.subgroupOnly.Validate()
+	s.identityRejection.Validate()
+	s.orderRequirement.Validate()
+}
+
+// SerializeCurvePoint writes a single curve point to the given output.
+//
+// The format written is X/Y. Note that this is invariant under P -> P+A.
+func (s *pointSerializerMapToField) SerializeCurvePoint(output io.Writer, point curvePoints.CurvePointPtrInterfaceRead) (bytesWritten int, err bandersnatchErrors.SerializationError) {
+	errPlain := checkPointSerializability(point, true)
+	if errPlain != nil {
+		err = addErrorDataNoWrite(errPlain)
+		bytesWritten = 0
+		return
+	}
+	XOverY := curvePoints.MapToFieldElement(point)
+	bytesWritten, err = s.SerializeValues(output, &XOverY)
+	return
+}
+
+// DeserializeCurvePoint reads from input, interprets it and overwrites point.
+// On error, point is untouched.
+//
+// The format expected is X/Y.
+func (s *pointSerializerMapToField) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, point curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	defer func() { recordDeserializationError(input, err) }()
+	var XOverY fieldElements.FieldElement
+	bytesRead, err, XOverY = s.DeserializeValues(input)
+	if err != nil {
+		return
+	}
+	var P curvePoints.Point_axtw_subgroup
+	P, errConversionToCurvePoint := curvePoints.CurvePointFromMapFieldElement_subgroup(&XOverY, trustLevel)
+	if errConversionToCurvePoint != nil {
+		err = errorsWithData.NewErrorWithParametersFromData(errConversionToCurvePoint, "%w", &bandersnatchErrors.ReadErrorData{
+			PartialRead:  false,
+			BytesRead:    int(s.OutputLength()),
+			ActuallyRead: nil,
+		})
+		if trustLevel.Bool() {
+			panic(err) // not supposed to be reachable
+		}
+		return
+	}
+	if err = s.checkIdentity(&P, int(s.OutputLength())); err != nil {
+		return
+	}
+	if err = s.checkOrder(&P, int(s.OutputLength())); err != nil {
+		return
+	}
+	point.SetFrom(&P)
+	return
+}
+
+// IsCanonical checks whether data is the canonical encoding of a curve point; see curvePointDeserializer_basic for details.
+func (s *pointSerializerMapToField) IsCanonical(data []byte) (bool, error) {
+	return isCanonicalEncoding(s, data)
+}
+
+// Clone creates an independent copy of the received serializer, returning a pointer.
+//
+// Note that since serializers are immutable, library users should never need to call this;
+// this is an internal function that is exported due to cross-package and reflect usage.
+func (s *pointSerializerMapToField) Clone() (ret *pointSerializerMapToField) {
+	var sCopy pointSerializerMapToField = *s
+	return &sCopy
+}
+
+// WithParameter(param, newParam) creates a modified copy of the received serializer with the parameter determined by param replaced by newParam.
+//
+// Recognized params are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder"
+// Note that "SubgroupOnly" only accepts true.
+func (s *pointSerializerMapToField) WithParameter(param string, newParam interface{}) (newSerializer pointSerializerMapToField) {
+	return makeCopyWithParameters(s, param, newParam)
+}
+
+// WithEndianness creates a modified copy of the received serializer with the prescribed endianness for field element serialization.
+// It accepts only literal binary.LittleEndian, binary.BigEndian or any newEndianness satisfying the common.FieldElementEnianness interface (which extends binary.ByteOrder).
+//
+// Invalid inputs cause a panic.
+func (s *pointSerializerMapToField) WithEndianness(newEndianness binary.ByteOrder) pointSerializerMapToField {
+	return s.WithParameter("Endianness", newEndianness)
+}
+
+// OutputLength returns the number of bytes read/written per curve point.
+//
+// It returns 32 for this serializer type.
+func (s *pointSerializerMapToField) OutputLength() int32 { return 32 }
+
+// GetParameter returns the value of the internal parameter determined by parameterName
+//
+// recognized parameterNames are: "Endianness", "SubgroupOnly", "RejectIdentity", "RequireOrder".
+func (s *pointSerializerMapToField) GetParameter(parameterName string) interface{} {
+	return getSerializerParameter(s, parameterName)
+}
+
+// RecognizedParameters returns a list of all parameter names accepted by GetParameter and WithParameter.
+func (s *pointSerializerMapToField) RecognizedParameters() []string {
+	return concatParameterList(s.valuesSerializerFe.RecognizedParameters(), s.subgroupOnly.RecognizedParameters(), s.identityRejection.RecognizedParameters(), s.orderRequirement.RecognizedParameters())
+}
+
+// HasParameter checks whether the given parameter name is accepted by GetParameter and WithParameter.
+func (s *pointSerializerMapToField) HasParameter(parameterName string) bool {
+	return utils.ElementInList(parameterName, s.RecognizedParameters(), normalizeParameter)
+}
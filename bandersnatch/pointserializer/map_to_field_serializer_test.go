@@ -0,0 +1,78 @@
+package pointserializer
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+var _ curvePointDeserializer_basic = &pointSerializerMapToField{}
+var _ curvePointSerializer_basic = &pointSerializerMapToField{}
+var _ modifyableSerializer[pointSerializerMapToField, *pointSerializerMapToField] = &pointSerializerMapToField{}
+
+func TestMapToFieldSerializer(t *testing.T) {
+	s := pointSerializerMapToField{valuesSerializerFe: valuesSerializerFe{fieldElementEndianness: common.DefaultEndian}}
+	s.Validate()
+	testutils.FatalUnless(t, s.OutputLength() == 32, "")
+	testutils.FatalUnless(t, s.IsSubgroupOnly(), "")
+
+	// neutral element encodes as all-zero
+	var buf bytes.Buffer
+	neutral := curvePoints.NeutralElement_xtw_subgroup
+	_, err := s.SerializeCurvePoint(&buf, &neutral)
+	testutils.FatalUnless(t, err == nil, "Serialization of neutral element failed: %v", err)
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), make([]byte, 32)), "neutral element does not encode to all-zero")
+
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, endianness := range []common.FieldElementEndianness{common.LittleEndian, common.BigEndian} {
+		s2 := s.WithEndianness(endianness)
+		for i := 0; i < 50; i++ {
+			P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+			if i == 0 {
+				P.SetNeutral()
+			}
+			buf.Reset()
+			bytesWritten, errSerialize := s2.SerializeCurvePoint(&buf, &P)
+			testutils.FatalUnless(t, errSerialize == nil, "Serialization failed: %v", errSerialize)
+			testutils.FatalUnless(t, bytesWritten == 32 && buf.Len() == 32, "")
+			encoding := append([]byte(nil), buf.Bytes()...)
+			for _, trustLevel := range []common.IsInputTrusted{common.TrustedInput, common.UntrustedInput} {
+				var Q curvePoints.Point_xtw_subgroup
+				bytesRead, errDeserialize := s2.DeserializeCurvePoint(bytes.NewReader(encoding), trustLevel, &Q)
+				testutils.FatalUnless(t, errDeserialize == nil, "Deserialization failed: %v", errDeserialize)
+				testutils.FatalUnless(t, bytesRead == 32, "")
+				testutils.FatalUnless(t, Q.IsEqual(&P), "MapToField serializer did not round-trip")
+			}
+			ok, errCanonical := s2.IsCanonical(encoding)
+			testutils.FatalUnless(t, ok && errCanonical == nil, "encoding not reported as canonical")
+		}
+	}
+
+	// points outside the subgroup cannot be serialized
+	var P curvePoints.Point_xtw_full
+	P.Add(&curvePoints.SubgroupGenerator_xtw_subgroup, &curvePoints.InfinitePoint1_xtw)
+	buf.Reset()
+	_, err = s.SerializeCurvePoint(&buf, &P)
+	testutils.FatalUnless(t, err != nil, "Serialization of point outside subgroup did not fail")
+
+	// invalid encodings are rejected. Not all field elements are valid encodings, so we just check that errors do not leave the point modified.
+	var invalidSeen bool
+	for i := 0; i < 20; i++ {
+		var fe curvePoints.FieldElement
+		fe.SetRandomUnsafe(drng)
+		buf.Reset()
+		_, _ = fe.Serialize(&buf, common.DefaultEndian)
+		var Q curvePoints.Point_xtw_subgroup
+		Q.SetNeutral()
+		_, errDeserialize := s.DeserializeCurvePoint(&buf, common.UntrustedInput, &Q)
+		if errDeserialize != nil {
+			invalidSeen = true
+			testutils.FatalUnless(t, Q.IsNeutralElement(), "DeserializeCurvePoint modified point on error")
+		}
+	}
+	testutils.FatalUnless(t, invalidSeen, "")
+}
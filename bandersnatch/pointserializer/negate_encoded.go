@@ -0,0 +1,106 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+)
+
+// This file contains NegateEncodedSlice, which negates serialized curve points without reconstructing them.
+
+var (
+	ErrMisalignedEncodedSlice = errors.New(ErrorPrefix + "length of encoded slice is not a multiple of the serializer's output length")
+	ErrCannotNegateEncoding   = errors.New(ErrorPrefix + "negation of encoded points is not supported for this serializer type")
+)
+
+// negateEncoding writes the encoding of the negative of the point encoded in chunk to output, using s.
+// For all formats, negating a point (X,Y) -> (-X,Y) corresponds to a simple operation on the stored field elements / sign bits.
+func negateEncoding(s curvePointSerializer_basic, chunk []byte, output *bytes.Buffer) (err error) {
+	input := bytes.NewReader(chunk)
+	var errDeserialize bandersnatchErrors.DeserializationError
+	var errSerialize bandersnatchErrors.SerializationError
+	switch s := s.(type) {
+	case *pointSerializerXY:
+		var X, Y fieldElements.FieldElement
+		_, errDeserialize, X, Y = s.DeserializeValues(input)
+		if errDeserialize != nil {
+			return errDeserialize
+		}
+		X.NegEq()
+		_, errSerialize = s.SerializeValues(output, &X, &Y)
+	case *pointSerializerXAndSignY:
+		// Sign(Y) is unchanged by negation.
+		var X fieldElements.FieldElement
+		var signY bool
+		_, errDeserialize, X, signY = s.DeserializeValues(input)
+		if errDeserialize != nil {
+			return errDeserialize
+		}
+		X.NegEq()
+		_, errSerialize = s.SerializeValues(output, &X, signY)
+	case *pointSerializerYAndSignX:
+		// We flip Sign(X), except for X==0, where the sign bit is always unset. On the curve, X==0 is equivalent to Y^2 == 1.
+		var Y, YSquared fieldElements.FieldElement
+		var signX bool
+		_, errDeserialize, Y, signX = s.DeserializeValues(input)
+		if errDeserialize != nil {
+			return errDeserialize
+		}
+		YSquared.Square(&Y)
+		if !YSquared.IsOne() {
+			signX = !signX
+		}
+		_, errSerialize = s.SerializeValues(output, &Y, signX)
+	case *pointSerializerXTimesSignY:
+		var XSignY fieldElements.FieldElement
+		_, errDeserialize, XSignY = s.DeserializeValues(input)
+		if errDeserialize != nil {
+			return errDeserialize
+		}
+		XSignY.NegEq()
+		_, errSerialize = s.SerializeValues(output, &XSignY)
+	case *pointSerializerYXTimesSignY:
+		var YSignY, XSignY fieldElements.FieldElement
+		_, errDeserialize, YSignY, XSignY = s.DeserializeValues(input)
+		if errDeserialize != nil {
+			return errDeserialize
+		}
+		XSignY.NegEq()
+		_, errSerialize = s.SerializeValues(output, &YSignY, &XSignY)
+	default:
+		return fmt.Errorf("%w: %T", ErrCannotNegateEncoding, s)
+	}
+	if errSerialize != nil {
+		// cannot happen, since output is a bytes.Buffer
+		panic(errSerialize)
+	}
+	return nil
+}
+
+// NegateEncodedSlice takes a concatenation of encodings of curve points under the format given by s and replaces each encoding by the encoding of the negated point.
+//
+// This operates directly on the encoded field elements (e.g. X*Sign(Y) -> -X*Sign(Y) for the Banderwagon formats) and does not reconstruct the curve points.
+// In particular, it does not check that the encodings are actually valid curve points; invalid encodings are mapped to (equally invalid) encodings.
+// It does, however, check that len(data) is a multiple of s.OutputLength() and that the headers and field elements are well-formed.
+//
+// On error, data is untouched. The possible errors wrap ErrMisalignedEncodedSlice, ErrCannotNegateEncoding (for unsupported serializer types) or
+// the errors returned when reading the field elements and headers of the format.
+func NegateEncodedSlice(s curvePointSerializer_basic, data []byte) error {
+	chunkLength := int(s.OutputLength())
+	if len(data)%chunkLength != 0 {
+		return fmt.Errorf("%w: %v bytes given, output length is %v", ErrMisalignedEncodedSlice, len(data), chunkLength)
+	}
+	var negated bytes.Buffer
+	negated.Grow(len(data))
+	for start := 0; start < len(data); start += chunkLength {
+		err := negateEncoding(s, data[start:start+chunkLength], &negated)
+		if err != nil {
+			return err
+		}
+	}
+	copy(data, negated.Bytes())
+	return nil
+}
@@ -0,0 +1,83 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestNegateEncodedSlice(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for _, basicSerializer := range allBasicSerializers {
+		points := []curvePoints.Point_xtw_full{curvePoints.NeutralElement_xtw_full}
+		if !basicSerializer.IsSubgroupOnly() {
+			points = append(points, curvePoints.AffineOrderTwoPoint_xtw)
+		}
+		for i := 0; i < 20; i++ {
+			if basicSerializer.IsSubgroupOnly() {
+				var P curvePoints.Point_xtw_full
+				Psub := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+				P.SetFrom(&Psub)
+				points = append(points, P)
+			} else {
+				points = append(points, curvePoints.MakeRandomPointUnsafe_xtw_full(drng))
+			}
+		}
+
+		// reference: deserialize, negate and reserialize point by point
+		var encoded, expected bytes.Buffer
+		for i := range points {
+			_, err := basicSerializer.SerializeCurvePoint(&encoded, &points[i])
+			testutils.FatalUnless(t, err == nil, "Serialization failed for %T: %v", basicSerializer, err)
+			var negP curvePoints.Point_xtw_full
+			negP.Neg(&points[i])
+			_, err = basicSerializer.SerializeCurvePoint(&expected, &negP)
+			testutils.FatalUnless(t, err == nil, "Serialization failed for %T: %v", basicSerializer, err)
+		}
+		data := encoded.Bytes()
+		err := NegateEncodedSlice(basicSerializer, data)
+		testutils.FatalUnless(t, err == nil, "NegateEncodedSlice failed for %T: %v", basicSerializer, err)
+		testutils.FatalUnless(t, bytes.Equal(data, expected.Bytes()), "NegateEncodedSlice does not match negation for %T", basicSerializer)
+
+		// negating twice gives back the original
+		err = NegateEncodedSlice(basicSerializer, data)
+		testutils.FatalUnless(t, err == nil, "")
+		var original bytes.Buffer
+		for i := range points {
+			basicSerializer.SerializeCurvePoint(&original, &points[i])
+		}
+		testutils.FatalUnless(t, bytes.Equal(data, original.Bytes()), "Double negation is not the identity for %T", basicSerializer)
+
+		// empty input is fine
+		err = NegateEncodedSlice(basicSerializer, []byte{})
+		testutils.FatalUnless(t, err == nil, "")
+
+		// misaligned input
+		misaligned := copyByteSlice(data[0 : len(data)-1])
+		err = NegateEncodedSlice(basicSerializer, misaligned)
+		testutils.FatalUnless(t, errors.Is(err, ErrMisalignedEncodedSlice), "Unexpected error for misaligned input: %v", err)
+		testutils.FatalUnless(t, bytes.Equal(misaligned, data[0:len(data)-1]), "NegateEncodedSlice modified data on error")
+	}
+
+	// malformed headers are detected and leave data untouched
+	var buf bytes.Buffer
+	banderwagonShortBigEndian := basicBanderwagonShort.WithEndianness(common.BigEndian)
+	P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	banderwagonShortBigEndian.SerializeCurvePoint(&buf, &P)
+	banderwagonShortBigEndian.SerializeCurvePoint(&buf, &P)
+	data := buf.Bytes()
+	data[32] ^= 0x80 // header bit of the second point
+	dataCopy := copyByteSlice(data)
+	err := NegateEncodedSlice(&banderwagonShortBigEndian, data)
+	testutils.FatalUnless(t, err != nil, "NegateEncodedSlice did not detect wrong header")
+	testutils.FatalUnless(t, bytes.Equal(data, dataCopy), "NegateEncodedSlice modified data on error")
+
+	// unsupported serializers
+	err = NegateEncodedSlice(&pointSerializerFlagged{}, make([]byte, 32))
+	testutils.FatalUnless(t, errors.Is(err, ErrCannotNegateEncoding), "Unexpected error %v", err)
+}
@@ -3,6 +3,7 @@ package pointserializer
 import (
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 
@@ -38,12 +39,15 @@ var serializerParams = map[string]struct {
 	normalizeParameter("BitHeader"):         {getter: "GetBitHeader", setter: "SetBitHeaderFromBitHeader", vartype: utils.TypeOfType[common.BitHeader]()},
 	normalizeParameter("BitHeader2"):        {getter: "GetBitHeader2", setter: "SetBitHeader2", vartype: utils.TypeOfType[common.BitHeader]()},
 	normalizeParameter("SubgroupOnly"):      {getter: "IsSubgroupOnly", setter: "SetSubgroupRestriction", vartype: utils.TypeOfType[bool]()},
+	normalizeParameter("RejectIdentity"):    {getter: "RejectsIdentity", setter: "SetRejectIdentity", vartype: utils.TypeOfType[bool]()},
+	normalizeParameter("RequireOrder"):      {getter: "GetRequiredOrder", setter: "SetRequiredOrder", vartype: utils.TypeOfType[*big.Int]()},
 	normalizeParameter("GlobalSliceHeader"): {getter: "GetGlobalSliceHeader", setter: "SetGlobalSliceHeader", vartype: utils.TypeOfType[[]byte]()},
 	normalizeParameter("GlobalSliceFooter"): {getter: "GetGlobalSliceFooter", setter: "SetGlobalSliceFooter", vartype: utils.TypeOfType[[]byte]()},
 	normalizeParameter("PerPointHeader"):    {getter: "GetPerPointHeader", setter: "SetPerPointHeader", vartype: utils.TypeOfType[[]byte]()},
 	normalizeParameter("PerPointFooter"):    {getter: "GetPerPointFooter", setter: "SetPerPointFooter", vartype: utils.TypeOfType[[]byte]()},
 	normalizeParameter("SinglePointHeader"): {getter: "GetSinglePointHeader", setter: "SetSinglePointHeader", vartype: utils.TypeOfType[[]byte]()},
 	normalizeParameter("SinglePointFooter"): {getter: "GetSinglePointFooter", setter: "SetSinglePointFooter", vartype: utils.TypeOfType[[]byte]()},
+	normalizeParameter("DomainTag"):         {getter: "GetDomainTag", setter: "SetDomainTag", vartype: utils.TypeOfType[[]byte]()},
 }
 
 // ParameterAware is the interface satisfied by all (parts of) serializers that work with makeCopyWithParameters
@@ -59,10 +63,13 @@ func normalizeParameter(arg string) string {
 	return strings.ToLower(arg)
 }
 
-// concatParameterList concatenates two lists of parameters, removing duplicates modulo normalizeParameters.
+// concatParameterList concatenates lists of parameters, removing duplicates modulo normalizeParameters.
 // The intended use is to implement RecognizedParameters() by merging component lists of accepted parameters.
-func concatParameterList(list1 []string, list2 []string) []string {
-	return utils.ConcatenateListsWithoutDuplicates(list1, list2, normalizeParameter)
+func concatParameterList(lists ...[]string) (ret []string) {
+	for _, list := range lists {
+		ret = utils.ConcatenateListsWithoutDuplicates(ret, list, normalizeParameter)
+	}
+	return
 }
 
 // TOOD: This does not check argument types for getters and setters!
@@ -0,0 +1,76 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/fieldElements"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestSerializePointWithBit(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	msbIndex := 31 // index of the byte containing the msb
+	if basicBanderwagonShort.GetEndianness().StartsWithMSB() {
+		msbIndex = 0
+	}
+	for i := 0; i < 50; i++ {
+		var P curvePoints.Point_xtw_subgroup
+		if i == 0 {
+			P.SetNeutral()
+		} else {
+			P = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		}
+		var shortEncoding bytes.Buffer
+		_, errShort := basicBanderwagonShort.SerializeCurvePoint(&shortEncoding, &P)
+		testutils.FatalUnless(t, errShort == nil, "")
+
+		for _, bit := range []bool{false, true} {
+			var buf bytes.Buffer
+			bytesWritten, err := SerializePointWithBit(&buf, &P, bit)
+			testutils.FatalUnless(t, err == nil, "SerializePointWithBit failed: %v", err)
+			testutils.FatalUnless(t, bytesWritten == 32 && buf.Len() == 32, "SerializePointWithBit did not write 32 bytes")
+			encoding := append([]byte{}, buf.Bytes()...)
+
+			// The encoding differs from the short Banderwagon encoding only in the msb
+			testutils.FatalUnless(t, (encoding[msbIndex]&0x80 != 0) == bit, "Auxiliary bit not stored in msb")
+			expected := append([]byte{}, shortEncoding.Bytes()...)
+			if !bit {
+				expected[msbIndex] &= 0x7F
+			}
+			testutils.FatalUnless(t, bytes.Equal(encoding, expected), "SerializePointWithBit does not match short Banderwagon encoding outside the msb")
+
+			var Q curvePoints.Point_xtw_subgroup
+			bytesRead, bitRead, err := DeserializePointWithBit(bytes.NewReader(encoding), common.UntrustedInput, &Q)
+			testutils.FatalUnless(t, err == nil, "DeserializePointWithBit failed: %v", err)
+			testutils.FatalUnless(t, bytesRead == 32, "")
+			testutils.FatalUnless(t, bitRead == bit, "Auxiliary bit not round-tripped")
+			testutils.FatalUnless(t, Q.IsEqual(&P), "Point not round-tripped")
+		}
+	}
+
+	// errors
+	var NaP curvePoints.Point_xtw_subgroup
+	_, err := SerializePointWithBit(&bytes.Buffer{}, &NaP, true)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error %v", err)
+
+	var Q curvePoints.Point_xtw_subgroup = curvePoints.NeutralElement_xtw_subgroup
+	_, _, err = DeserializePointWithBit(bytes.NewReader(make([]byte, 20)), common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, err != nil, "DeserializePointWithBit did not fail on short input")
+
+	// 2 is not X*Sign(Y) for any subgroup point.
+	var two fieldElements.FieldElement
+	two.SetUInt64(2)
+	_, errCurve := curvePoints.CurvePointFromXTimesSignY_subgroup(&two, common.UntrustedInput)
+	testutils.FatalUnless(t, errCurve != nil, "")
+	var invalid bytes.Buffer
+	pointWithBitValuesSerializer.SerializeValues(&invalid, &two, true)
+	_, bitRead, err := DeserializePointWithBit(&invalid, common.UntrustedInput, &Q)
+	testutils.FatalUnless(t, err != nil && !bitRead, "DeserializePointWithBit did not fail on invalid point")
+	testutils.FatalUnless(t, Q.IsNeutralElement(), "DeserializePointWithBit modified point on error")
+}
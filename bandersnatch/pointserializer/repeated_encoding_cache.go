@@ -0,0 +1,60 @@
+package pointserializer
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains repeatedEncodingCache, which is used by slice deserialization to avoid reconstructing the same curve point repeatedly.
+
+// repeatedEncodingCache wraps a basic deserializer and remembers the last successfully decoded (bytes -> point) pair.
+// If the next encoding is byte-identical and the output point has the same type, we just copy the cached point instead of deserializing again.
+// This is a significant speedup for vectors with long runs of identical points (e.g. padding with the neutral element).
+//
+// Since deserialization is deterministic (given the encoding, trustLevel and the type of the output point), this is transparent.
+// The zero value is not usable; use newRepeatedEncodingCache.
+type repeatedEncodingCache struct {
+	deserializer curvePointDeserializer_basic
+	buf          []byte                             // encoding that is currently being read
+	last         []byte                             // encoding of cachedPoint
+	cachedPoint  curvePoints.CurvePointPtrInterface // nil if the cache is empty
+	cachedType   reflect.Type                       // type of the output point that cachedPoint was decoded into
+}
+
+// newRepeatedEncodingCache creates an (empty) cache for the given basic deserializer.
+func newRepeatedEncodingCache(deserializer curvePointDeserializer_basic) repeatedEncodingCache {
+	length := deserializer.OutputLength()
+	return repeatedEncodingCache{deserializer: deserializer, buf: make([]byte, length), last: make([]byte, length)}
+}
+
+// DeserializeCurvePoint has the same semantics as the DeserializeCurvePoint method of the wrapped deserializer, using the cache where possible.
+//
+// The only observable difference is that we always read a full encoding from input before interpreting it.
+// Consequently, if the encoding is invalid, the returned bytesRead counts the whole encoding, even if the wrapped deserializer would have stopped reading earlier.
+func (c *repeatedEncodingCache) DeserializeCurvePoint(input io.Reader, trustLevel common.IsInputTrusted, outputPoint curvePoints.CurvePointPtrInterfaceWrite) (bytesRead int, err bandersnatchErrors.DeserializationError) {
+	bytesRead, errRead := io.ReadFull(input, c.buf)
+	if errRead != nil {
+		// We let the wrapped deserializer process what we got in order to produce the appropriate error.
+		c.cachedPoint = nil
+		return c.deserializer.DeserializeCurvePoint(io.MultiReader(bytes.NewReader(c.buf[0:bytesRead]), input), trustLevel, outputPoint)
+	}
+	outputType := reflect.TypeOf(outputPoint)
+	if c.cachedPoint != nil && outputType == c.cachedType && bytes.Equal(c.buf, c.last) {
+		outputPoint.SetFrom(c.cachedPoint)
+		return
+	}
+	_, err = c.deserializer.DeserializeCurvePoint(bytes.NewReader(c.buf), trustLevel, outputPoint)
+	if err != nil {
+		c.cachedPoint = nil
+		return
+	}
+	c.buf, c.last = c.last, c.buf
+	c.cachedPoint = outputPoint.Clone()
+	c.cachedType = outputType
+	return
+}
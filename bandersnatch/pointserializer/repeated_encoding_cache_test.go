@@ -0,0 +1,145 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+type banderwagonMultiSerializer = multiSerializer[pointSerializerXTimesSignY, *pointSerializerXTimesSignY]
+type XYMultiSerializer = multiSerializer[pointSerializerXY, *pointSerializerXY]
+
+// serializeSliceForTest writes points as a slice (i.e. in the format read by DeserializeSlice), using the header serializer and basic serializer of ms.
+func serializeSliceForTest[BasicValue any, BasicPtr interface {
+	*BasicValue
+	modifyableSerializer_basic[BasicValue, BasicPtr]
+}](t testing.TB, ms *multiSerializer[BasicValue, BasicPtr], points []curvePoints.Point_xtw_full) []byte {
+	var buf bytes.Buffer
+	_, err := ms.headerSerializer.serializeGlobalSliceHeader(&buf, int32(len(points)))
+	if err != nil {
+		t.Fatalf("Could not serialize slice header: %v", err)
+	}
+	for i := range points {
+		ms.headerSerializer.serializePerPointHeader(&buf)
+		_, err = BasicPtr(&ms.basicSerializer).SerializeCurvePoint(&buf, &points[i])
+		if err != nil {
+			t.Fatalf("Could not serialize point %v: %v", i, err)
+		}
+		ms.headerSerializer.serializePerPointFooter(&buf)
+	}
+	ms.headerSerializer.serializeGlobalSliceFooter(&buf)
+	return buf.Bytes()
+}
+
+// makePaddedVector creates a vector with long runs of the neutral element and some repeated random points.
+func makePaddedVector(rnd *rand.Rand, length int) []curvePoints.Point_xtw_full {
+	ret := make([]curvePoints.Point_xtw_full, length)
+	var P curvePoints.Point_xtw_full
+	for i := range ret {
+		switch {
+		case i%32 == 0:
+			Psub := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(rnd)
+			P.SetFrom(&Psub)
+			ret[i] = P
+		case i%32 < 8:
+			ret[i] = P
+		default:
+			ret[i] = curvePoints.NeutralElement_xtw_full
+		}
+	}
+	return ret
+}
+
+func TestRepeatedEncodingCache(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	ms := banderwagonMultiSerializer{basicSerializer: basicBanderwagonShort, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+	cached := ms.WithRepeatedEncodingCache(true)
+	testutils.FatalUnless(t, !ms.cacheRepeatedEncodings, "WithRepeatedEncodingCache modified receiver")
+	testutils.FatalUnless(t, cached.Clone().(*banderwagonMultiSerializer).cacheRepeatedEncodings, "Clone does not preserve cache setting")
+	testutils.FatalUnless(t, !cached.WithRepeatedEncodingCache(false).(*banderwagonMultiSerializer).cacheRepeatedEncodings, "")
+
+	points := makePaddedVector(drng, 100)
+	data := serializeSliceForTest(t, &ms, points)
+
+	type Point = curvePoints.Point_xtw_subgroup
+	for _, deserializer := range []CurvePointSerializer{&ms, cached} {
+		output, bytesRead, err := deserializer.DeserializeSlice(bytes.NewReader(data), common.UntrustedInput, CreateNewSlice[Point, *Point])
+		testutils.FatalUnless(t, err == nil, "DeserializeSlice failed: %v", err)
+		testutils.FatalUnless(t, bytesRead == len(data), "")
+		result := output.([]Point)
+		testutils.FatalUnless(t, len(result) == len(points), "")
+		for i := range points {
+			testutils.FatalUnless(t, result[i].IsEqual(&points[i]), "DeserializeSlice gave wrong point at index %v", i)
+		}
+		// entries must be independent copies
+		result[1].SetNeutral()
+		testutils.FatalUnless(t, result[2].IsEqual(&points[2]), "Cached points are not independent")
+	}
+
+	// Invalid encodings after a run must be detected by both, with the same number of points deserialized.
+	corrupted := copyByteSlice(data)
+	const badIndex = 5
+	corrupted[simpleHeaderSliceLengthOverhead+32*badIndex+31] ^= 0x80 // header bit of point badIndex (little endian)
+	for _, deserializer := range []CurvePointSerializer{&ms, cached} {
+		_, _, err := deserializer.DeserializeSlice(bytes.NewReader(corrupted), common.UntrustedInput, CreateNewSlice[Point, *Point])
+		testutils.FatalUnless(t, err != nil, "DeserializeSlice did not detect invalid encoding")
+		testutils.FatalUnless(t, err.GetData().PointsDeserialized == badIndex, "Unexpected number of deserialized points %v", err.GetData().PointsDeserialized)
+	}
+
+	// Truncated input
+	for _, deserializer := range []CurvePointSerializer{&ms, cached} {
+		_, _, err := deserializer.DeserializeSlice(bytes.NewReader(data[0:len(data)-10]), common.UntrustedInput, CreateNewSlice[Point, *Point])
+		testutils.FatalUnless(t, err != nil && err.GetData().PointsDeserialized == len(points)-1, "Unexpected error on truncated input: %v", err)
+	}
+}
+
+// The cache must take the type of the output point into account: Whether an encoding is valid may depend on it.
+func TestRepeatedEncodingCacheMixedTypes(t *testing.T) {
+	ms := XYMultiSerializer{basicSerializer: ps_XY, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+	cached := ms.WithRepeatedEncodingCache(true)
+	points := []curvePoints.Point_xtw_full{curvePoints.AffineOrderTwoPoint_xtw, curvePoints.AffineOrderTwoPoint_xtw, curvePoints.AffineOrderTwoPoint_xtw}
+	data := serializeSliceForTest(t, &ms, points)
+
+	for _, deserializer := range []CurvePointSerializer{&ms, cached} {
+		var targets curvePoints.GenericPointSlice = []curvePoints.CurvePointPtrInterface{&curvePoints.Point_xtw_full{}, &curvePoints.Point_axtw_full{}, &curvePoints.Point_xtw_subgroup{}}
+		sliceMaker := func(length int32) (any, curvePoints.CurvePointSlice, error) {
+			return nil, targets, nil
+		}
+		_, _, err := deserializer.DeserializeSlice(bytes.NewReader(data), common.UntrustedInput, sliceMaker)
+		testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrNotInSubgroup), "Unexpected error %v", err)
+		testutils.FatalUnless(t, err.GetData().PointsDeserialized == 2, "")
+		testutils.FatalUnless(t, targets[0].IsEqual(&curvePoints.AffineOrderTwoPoint_xtw) && targets[1].IsEqual(&curvePoints.AffineOrderTwoPoint_xtw), "")
+	}
+}
+
+func BenchmarkRepeatedEncodingCache(b *testing.B) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	ms := banderwagonMultiSerializer{basicSerializer: basicBanderwagonShort, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+	points := makePaddedVector(drng, 256)
+	data := serializeSliceForTest(b, &ms, points)
+	output := make([]curvePoints.Point_xtw_subgroup, len(points))
+	for _, enable := range []bool{false, true} {
+		name := "uncached"
+		if enable {
+			name = "cached"
+		}
+		deserializer := ms.WithRepeatedEncodingCache(enable)
+		b.Run(name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _, err := deserializer.DeserializeSlice(bytes.NewReader(data), common.UntrustedInput, UseExistingSlice(output))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -115,6 +115,7 @@ type CurvePointDeserializerModifyable interface {
 	CurvePointDeserializer
 	WithParameter(parameterName string, newParam any) CurvePointDeserializerModifyable
 	WithEndianness(newEndianness binary.ByteOrder) CurvePointDeserializerModifyable
+	WithRepeatedEncodingCache(enable bool) CurvePointDeserializerModifyable
 	Clone() CurvePointDeserializerModifyable
 }
 
@@ -138,9 +139,11 @@ type CurvePointSerializer interface {
 	DeserializeCurvePoints(inputStream io.Reader, trustLevel common.IsInputTrusted, outputPoints curvePoints.CurvePointSlice) (bytesRead int, err BatchDeserializationError)
 	DeserializeSlice(inputStream io.Reader, trustLevel common.IsInputTrusted, sliceMaker DeserializeSliceMaker) (output any, bytesRead int, err BatchDeserializationError)
 
+	SerializeCurvePoints(outputStream io.Writer, inputPoints curvePoints.CurvePointSlice) (bytesWritten int, err BatchSerializationError)
+	SerializeSlice(outputStream io.Writer, inputSlice curvePoints.CurvePointSlice) (bytesWritten int, err BatchSerializationError)
+
 	// SerializePoints(outputStream io.Writer, inputPoints bandersnatch.CurvePointSlice) (bytesWritten int, err bandersnatchErrors.BatchSerializationError) // SerializeBatch(os, points) is equivalent (if no error occurs) to calling Serialize(os, point[i]) for all i. NOTE: This provides the same functionality as SerializePoints, but with a different argument type.
 	// SerializeBatch(outputStream io.Writer, inputPoints ...bandersnatch.CurvePointPtrInterfaceRead) (bytesWritten int, err error) // SerializePoints(os, &x1, &x2, ...) is equivalent (if not error occurs, at least) to Serialize(os, &x1), Serialize(os, &x1), ... NOTE: Using SerializePoints(os, points...) with ...-notation might not work due to the need to convert []concrete Point type to []CurvePointPtrInterface. Use SerializeBatch to avoid this.
-	// SerializeSlice(outputStream io.Writer, inputSlice bandersnatch.CurvePointSlice) (bytesWritten int, err bandersnatchErrors.BatchSerializationError)   // SerializeSlice(os, points) serializes a slice of points to outputStream. As opposed to SerializeBatch and SerializePoints, the number of points written is stored in the output stream and can NOT be read back individually, but only by DeserializeSlice
 }
 
 // Note: WithParameter, WithEndianness and Clone "forget" their types.
@@ -151,6 +154,7 @@ type CurvePointSerializerModifyable interface {
 	// modifyableSerializer[SelfValue, SelfPtr]
 	WithParameter(parameterName string, newParam any) CurvePointSerializerModifyable
 	WithEndianness(newEndianness binary.ByteOrder) CurvePointSerializerModifyable
+	WithRepeatedEncodingCache(enable bool) CurvePointSerializerModifyable
 	Clone() CurvePointSerializerModifyable
 }
 
@@ -158,16 +162,18 @@ type multiDeserializer[BasicValue any, BasicPtr interface {
 	*BasicValue
 	modifyableDeserializer_basic[BasicValue, BasicPtr]
 }] struct {
-	basicDeserializer  BasicValue               // Due to immutability, having a pointer would be fine as well.
-	headerDeserializer simpleHeaderDeserializer // we could do struct embeding here (well, not with generics...), but some methods are defined on both members, so we prefer explicit forwarding for clarity.
+	basicDeserializer      BasicValue               // Due to immutability, having a pointer would be fine as well.
+	headerDeserializer     simpleHeaderDeserializer // we could do struct embeding here (well, not with generics...), but some methods are defined on both members, so we prefer explicit forwarding for clarity.
+	cacheRepeatedEncodings bool                     // if set, slice deserialization skips reconstruction of consecutive byte-identical points. See repeatedEncodingCache.
 }
 
 type multiSerializer[BasicValue any, BasicPtr interface {
 	*BasicValue
 	modifyableSerializer_basic[BasicValue, BasicPtr]
 }] struct {
-	basicSerializer  BasicValue             // Due to immutability, having a pointer would be fine as well.
-	headerSerializer simpleHeaderSerializer // we could do struct embeding here (well, not with generics...), but some methods are defined on both members, so we prefer explicit forwarding for clarity.
+	basicSerializer        BasicValue             // Due to immutability, having a pointer would be fine as well.
+	headerSerializer       simpleHeaderSerializer // we could do struct embeding here (well, not with generics...), but some methods are defined on both members, so we prefer explicit forwarding for clarity.
+	cacheRepeatedEncodings bool                   // if set, slice deserialization skips reconstruction of consecutive byte-identical points. See repeatedEncodingCache.
 }
 
 type BatchSerializationErrorData struct {
@@ -214,6 +220,7 @@ func (md *multiDeserializer[BasicValue, BasicPtr]) makeCopy() multiDeserializer[
 	var ret multiDeserializer[BasicValue, BasicPtr]
 	ret.basicDeserializer = *BasicPtr(&md.basicDeserializer).Clone()
 	ret.headerDeserializer = *md.headerDeserializer.Clone()
+	ret.cacheRepeatedEncodings = md.cacheRepeatedEncodings
 	return ret
 }
 
@@ -225,6 +232,7 @@ func (md *multiSerializer[BasicValue, BasicPtr]) makeCopy() multiSerializer[Basi
 	var ret multiSerializer[BasicValue, BasicPtr]
 	ret.basicSerializer = *BasicPtr(&md.basicSerializer).Clone()
 	ret.headerSerializer = *md.headerSerializer.Clone()
+	ret.cacheRepeatedEncodings = md.cacheRepeatedEncodings
 	return ret
 }
 
@@ -402,6 +410,26 @@ func (md *multiSerializer[BasicValue, BasicPtr]) WithEndianness(newEndianness bi
 	return &mdcopy
 }
 
+// WithRepeatedEncodingCache returns a copy of the given deserializer with caching of repeated encodings in DeserializeSlice enabled or disabled.
+//
+// If enabled, DeserializeSlice remembers the last decoded point and skips reconstruction if the next encoding is byte-identical, which speeds up vectors with long runs of the same point.
+// The result is the same as without the cache. The only difference is that on encountering an invalid encoding, the bytesRead reported include the full encoding of that point.
+func (md *multiDeserializer[BasicValue, BasicPtr]) WithRepeatedEncodingCache(enable bool) CurvePointDeserializerModifyable {
+	mdcopy := md.makeCopy()
+	mdcopy.cacheRepeatedEncodings = enable
+	return &mdcopy
+}
+
+// WithRepeatedEncodingCache returns a copy of the given serializer with caching of repeated encodings in DeserializeSlice enabled or disabled.
+//
+// If enabled, DeserializeSlice remembers the last decoded point and skips reconstruction if the next encoding is byte-identical, which speeds up vectors with long runs of the same point.
+// The result is the same as without the cache. The only difference is that on encountering an invalid encoding, the bytesRead reported include the full encoding of that point.
+func (md *multiSerializer[BasicValue, BasicPtr]) WithRepeatedEncodingCache(enable bool) CurvePointSerializerModifyable {
+	mdcopy := md.makeCopy()
+	mdcopy.cacheRepeatedEncodings = enable
+	return &mdcopy
+}
+
 // DeserializeCurvePoint deserializes a single curve point from input stream, (over-)writing to ouputPoint.
 // trustLevel indicates whether the input is to be trusted that the data represents any (subgroup)point at all.
 //
@@ -702,7 +730,13 @@ func DeserializeCurvePoints_Variadic[PtrType curvePoints.CurvePointPtrInterface]
 
 // main loop of DeserializeSlice, separate function for historical reasons.
 
-func deserializeSlice_mainloop(inputStream io.Reader, trustLevel common.IsInputTrusted, targetSlice curvePoints.CurvePointSlice, deserializer_header headerDeserializer, deserializer_point curvePointDeserializer_basic, size32 int32) (bytesRead int, err BatchDeserializationError) {
+// If cacheRepeatedEncodings is set, byte-identical consecutive encodings are only reconstructed once, see repeatedEncodingCache.
+func deserializeSlice_mainloop(inputStream io.Reader, trustLevel common.IsInputTrusted, targetSlice curvePoints.CurvePointSlice, deserializer_header headerDeserializer, deserializer_point curvePointDeserializer_basic, size32 int32, cacheRepeatedEncodings bool) (bytesRead int, err BatchDeserializationError) {
+	deserializePoint := deserializer_point.DeserializeCurvePoint
+	if cacheRepeatedEncodings {
+		cache := newRepeatedEncodingCache(deserializer_point)
+		deserializePoint = cache.DeserializeCurvePoint
+	}
 	var bytesJustRead int
 	var errNonBatch bandersnatchErrors.DeserializationError
 	size := int(size32) // i in the loop below should be int (because of type-unsafe inclusion in BatchDeserializationErrorData)
@@ -715,7 +749,7 @@ func deserializeSlice_mainloop(inputStream io.Reader, trustLevel common.IsInputT
 			return
 		}
 		// Read/consume actual point:
-		bytesJustRead, errNonBatch = deserializer_point.DeserializeCurvePoint(inputStream, trustLevel, targetSlice.GetByIndex(i))
+		bytesJustRead, errNonBatch = deserializePoint(inputStream, trustLevel, targetSlice.GetByIndex(i))
 		bytesRead += bytesJustRead
 		if errNonBatch != nil {
 			err = errorsWithData.NewErrorWithGuaranteedParameters[BatchDeserializationErrorData](errNonBatch, ErrorPrefix+"slice deserialization failed after successfully reading %v{PointsDeserialized} points. The error was %w", "PointsDeserialized", i, FIELDNAME_PARTIAL_READ, true)
@@ -779,7 +813,7 @@ func (md *multiDeserializer[BasicValue, BasicPtr]) DeserializeSlice(inputStream
 	}
 
 	var bytesJustRead int
-	bytesJustRead, err = deserializeSlice_mainloop(inputStream, trustLevel, outputPointSlice, &md.headerDeserializer, BasicPtr(&md.basicDeserializer), size)
+	bytesJustRead, err = deserializeSlice_mainloop(inputStream, trustLevel, outputPointSlice, &md.headerDeserializer, BasicPtr(&md.basicDeserializer), size, md.cacheRepeatedEncodings)
 	bytesRead += bytesJustRead
 	if err != nil {
 		return
@@ -791,7 +825,7 @@ func (md *multiDeserializer[BasicValue, BasicPtr]) DeserializeSlice(inputStream
 		return
 	}
 
-	testutils.Assert(bytesRead <= math.MaxInt32)
+	testutils.DebugAssert(bytesRead <= math.MaxInt32)
 
 	return
 }
@@ -839,7 +873,7 @@ func (md *multiSerializer[BasicValue, BasicPtr]) DeserializeSlice(inputStream io
 	}
 
 	var bytesJustRead int
-	bytesJustRead, err = deserializeSlice_mainloop(inputStream, trustLevel, outputPointSlice, &md.headerSerializer, BasicPtr(&md.basicSerializer), size)
+	bytesJustRead, err = deserializeSlice_mainloop(inputStream, trustLevel, outputPointSlice, &md.headerSerializer, BasicPtr(&md.basicSerializer), size, md.cacheRepeatedEncodings)
 	bytesRead += bytesJustRead
 	if err != nil {
 		return
@@ -851,7 +885,7 @@ func (md *multiSerializer[BasicValue, BasicPtr]) DeserializeSlice(inputStream io
 		return
 	}
 
-	testutils.Assert(bytesRead <= math.MaxInt32)
+	testutils.DebugAssert(bytesRead <= math.MaxInt32)
 
 	return
 }
@@ -931,7 +965,164 @@ func UseExistingSlice[PointType any, PointTypePtr interface {
 	}
 }
 
+// normalizeForBatchSerialization returns read-only views of the points in inputPoints, whose internal representations have been normalized to Z==1 in batch.
+//
+// The serializers for single points need affine coordinates, so serializing the returned points instead of the original ones requires only (at most) two field inversions in total:
+// one for points of subgroup-only type and one for the others. The original points are not modified.
+// If inputPoints contains a NaP, we only normalize the points before the first NaP and the remaining entries refer to the original points.
+// This way, serializing the returned points fails at exactly the same place and with the same error as serializing the original ones.
+func normalizeForBatchSerialization(inputPoints curvePoints.CurvePointSlice) (normalizedPoints []curvePoints.CurvePointPtrInterfaceRead) {
+	L := inputPoints.Len()
+	normalizedPoints = make([]curvePoints.CurvePointPtrInterfaceRead, L)
+
+	// Determine how many points we normalize and how many of those have a subgroup-only type. This is needed to allocate the copies in advance, since we take pointers into them.
+	var numToNormalize int = L
+	var numSubgroupOnly int
+	for i := 0; i < L; i++ {
+		point := inputPoints.GetByIndex(i)
+		if point.IsNaP() {
+			numToNormalize = i
+			break
+		}
+		if point.CanOnlyRepresentSubgroup() {
+			numSubgroupOnly++
+		}
+	}
+
+	// Points of subgroup-only type are copied to Point_xtw_subgroup rather than Point_xtw_full, because the latter would lose the information that the point is in the subgroup.
+	fullCopies := make(curvePoints.CurvePointSlice_xtw_full, numToNormalize-numSubgroupOnly)
+	subgroupCopies := make(curvePoints.CurvePointSlice_xtw_subgroup, numSubgroupOnly)
+	var indexFull, indexSubgroup int
+	for i := 0; i < numToNormalize; i++ {
+		point := inputPoints.GetByIndex(i)
+		if point.CanOnlyRepresentSubgroup() {
+			subgroupCopies[indexSubgroup].SetFrom(point)
+			normalizedPoints[i] = &subgroupCopies[indexSubgroup]
+			indexSubgroup++
+		} else {
+			fullCopies[indexFull].SetFrom(point)
+			normalizedPoints[i] = &fullCopies[indexFull]
+			indexFull++
+		}
+	}
+	for i := numToNormalize; i < L; i++ {
+		normalizedPoints[i] = inputPoints.GetByIndex(i)
+	}
+
+	// Points at infinity are set to a standard representation with Z==0 here; the individual serializers deal with them.
+	fullCopies.NormalizeSlice()
+	subgroupCopies.NormalizeSlice()
+	return
+}
+
+// SerializeCurvePoints(outputStream, inputPoints) serializes the given points to outputStream in order.
+// If no error occurs, this is equivalent to calling SerializeCurvePoint(outputStream, inputPoint) for each point of inputPoints in order, i.e. the number of points is not written.
+// Use SerializeSlice if you want to store the number of points in-band.
+//
+// As opposed to calling SerializeCurvePoint in a loop, the conversion to affine coordinates is done for all points at once, which saves (almost) all field inversions.
+// L := inputPoints.Len() times serializer.OutputLength() must fit into an int32, else we panic.
+//
+// On error, the BatchSerializationError contains (among other data) via the errorsWithData framework fields PointsSerialized and PartialWrite.
+// PointsSerialized is the number of points that were completely written to outputStream. PartialWrite is set to true if we encountered a write error that is not aligned with data encoding points.
 func (md *multiSerializer[BasicValue, BasicPtr]) SerializeCurvePoints(outputStream io.Writer, inputPoints curvePoints.CurvePointSlice) (bytesWritten int, err BatchSerializationError) {
-	panic(0)
-	// return
+	L := inputPoints.Len()
+	if L > math.MaxInt32 {
+		panic(fmt.Errorf(ErrorPrefix+"trying to batch-serialize %v, which is more than MaxInt32 points with SerializeCurvePoints", L))
+	}
+	if int64(L)*int64(md.OutputLength()) > math.MaxInt32 {
+		panic(fmt.Errorf(ErrorPrefix+"trying to batch-serialize %v points, each writing %v bytes. The total number of bytes written might exceed MaxInt32. Bailing out", L, md.OutputLength()))
+	}
+	normalizedPoints := normalizeForBatchSerialization(inputPoints)
+	for i := 0; i < L; i++ {
+		bytesJustWritten, errSingle := md.SerializeCurvePoint(outputStream, normalizedPoints[i])
+		bytesWritten += bytesJustWritten
+		if errSingle != nil {
+			// Turns an EOF into an UnexpectedEOF if i != 0.
+			if i != 0 {
+				bandersnatchErrors.UnexpectEOF2(&errSingle)
+			}
+
+			// the index i gives the correct value for the PointsSerialized error data. The other data (including PartialWrite) is actually correct.
+			err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errSingle, ErrorPrefix+"batch serialization failed after serializing %v{PointsSerialized} points with error %w", FIELDNAME_POINTSSERIALIZED, i)
+			return
+		}
+	}
+	return
+}
+
+// SerializeSlice writes the given slice of curve points to outputStream.
+// As opposed to SerializeCurvePoints, the slice length is written in-band and the slice is treated as a single (de)serialization object.
+// The output can be read back with DeserializeSlice.
+//
+// As for SerializeCurvePoints, the conversion to affine coordinates is done for all points at once.
+//
+// On error, the BatchSerializationError contains a PointsSerialized field (accessible via errorsWithData), indicating how many points were completely written to outputStream.
+// Note that PartialWrite is set whenever anything was written, since the slice is a single object.
+func (md *multiSerializer[BasicValue, BasicPtr]) SerializeSlice(outputStream io.Writer, inputSlice curvePoints.CurvePointSlice) (bytesWritten int, err BatchSerializationError) {
+	L := inputSlice.Len()
+	if L > math.MaxInt32 {
+		panic(fmt.Errorf(ErrorPrefix+"trying to serialize a slice of length %v, which is more than MaxInt32, with SerializeSlice", L))
+	}
+	size := int32(L)
+	if _, overflowErr := md.SliceOutputLength(size); overflowErr != nil {
+		panic(fmt.Errorf(ErrorPrefix+"trying to serialize a slice of %v points. The total number of bytes written would exceed MaxInt32. Bailing out: %v", L, overflowErr))
+	}
+	var errNonBatch bandersnatchErrors.SerializationError // error returned from individual serialization routines
+
+	bytesWritten, errNonBatch = md.headerSerializer.serializeGlobalSliceHeader(outputStream, size)
+	if errNonBatch != nil {
+		err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errNonBatch, ErrorPrefix+" slice serialization could not write header (including size). Error was: %w", FIELDNAME_PARTIAL_WRITE, bytesWritten != 0, FIELDNAME_POINTSSERIALIZED, 0)
+		return
+	}
+
+	var bytesJustWritten int
+	bytesJustWritten, err = serializeSlice_mainloop(outputStream, normalizeForBatchSerialization(inputSlice), &md.headerSerializer, BasicPtr(&md.basicSerializer))
+	bytesWritten += bytesJustWritten
+	if err != nil {
+		return
+	}
+
+	bytesJustWritten, errNonBatch = md.headerSerializer.serializeGlobalSliceFooter(outputStream)
+	bytesWritten += bytesJustWritten
+	if errNonBatch != nil {
+		err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errNonBatch, ErrorPrefix+" slice serialization could not write footer. Error was: %w", FIELDNAME_PARTIAL_WRITE, true, FIELDNAME_POINTSSERIALIZED, L)
+		return
+	}
+
+	testutils.DebugAssert(bytesWritten <= math.MaxInt32)
+
+	return
+}
+
+// main loop of SerializeSlice, mirroring deserializeSlice_mainloop.
+func serializeSlice_mainloop(outputStream io.Writer, inputPoints []curvePoints.CurvePointPtrInterfaceRead, serializer_header headerSerializer, serializer_point curvePointSerializer_basic) (bytesWritten int, err BatchSerializationError) {
+	var bytesJustWritten int
+	var errNonBatch bandersnatchErrors.SerializationError
+	for i := range inputPoints {
+		// Write per-point header
+		bytesJustWritten, errNonBatch = serializer_header.serializePerPointHeader(outputStream)
+		bytesWritten += bytesJustWritten
+		if errNonBatch != nil {
+			bandersnatchErrors.UnexpectEOF2(&errNonBatch)
+			err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errNonBatch, ErrorPrefix+"slice serialization failed when writing per-point header after writing %v{PointsSerialized} points. Error was %w", FIELDNAME_POINTSSERIALIZED, i, FIELDNAME_PARTIAL_WRITE, true)
+			return
+		}
+		// Write actual point
+		bytesJustWritten, errNonBatch = serializer_point.SerializeCurvePoint(outputStream, inputPoints[i])
+		bytesWritten += bytesJustWritten
+		if errNonBatch != nil {
+			bandersnatchErrors.UnexpectEOF2(&errNonBatch)
+			err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errNonBatch, ErrorPrefix+"slice serialization failed after successfully writing %v{PointsSerialized} points. The error was %w", FIELDNAME_POINTSSERIALIZED, i, FIELDNAME_PARTIAL_WRITE, true)
+			return
+		}
+		// Write per-point footer. Note that PointsSerialized is set to i+1.
+		bytesJustWritten, errNonBatch = serializer_header.serializePerPointFooter(outputStream)
+		bytesWritten += bytesJustWritten
+		if errNonBatch != nil {
+			bandersnatchErrors.UnexpectEOF2(&errNonBatch)
+			err = errorsWithData.NewErrorWithGuaranteedParameters[BatchSerializationErrorData](errNonBatch, ErrorPrefix+"slice serialization failed when writing per-point footer after writing %v{PointsSerialized} points. Error was %w", FIELDNAME_POINTSSERIALIZED, i+1, FIELDNAME_PARTIAL_WRITE, true)
+			return
+		}
+	}
+	return
 }
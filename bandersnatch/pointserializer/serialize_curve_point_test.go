@@ -1,9 +1,14 @@
 package pointserializer
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"math/rand"
 	"testing"
 
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
 	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
 	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
 )
@@ -14,12 +19,12 @@ var _ CurvePointSerializerModifyable = &multiSerializer[pointSerializerXY, *poin
 var _ CurvePointDeserializerModifyable = &multiDeserializer[pointSerializerXY, *pointSerializerXY]{}
 
 var _ DeserializeSliceMaker = UseExistingSlice([]curvePoints.Point_axtw_subgroup{})
-var _ DeserializeSliceMaker = CreateNewSlice[curvePoints.Point_axtw_subgroup]
+var _ DeserializeSliceMaker = CreateNewSlice[curvePoints.Point_axtw_subgroup, *curvePoints.Point_axtw_subgroup]
 
 func TestCreateNewSlice(t *testing.T) {
 	type Point = curvePoints.Point_axtw_subgroup
 	const length = 31
-	var sliceMaker DeserializeSliceMaker = CreateNewSlice[Point]
+	var sliceMaker DeserializeSliceMaker = CreateNewSlice[Point, *Point]
 
 	output, _, _ := sliceMaker(-1)
 	outputReal := output.([]Point)
@@ -107,3 +112,136 @@ func TestUseExistingSlice(t *testing.T) {
 	// fmt.Printf("%v\n", err)
 
 }
+
+// SerializeSlice must give the same output as serializing each point individually (with slice headers) and be readable by DeserializeSlice.
+// Points of different types and points at infinity must be handled correctly by the batch normalization.
+func TestSerializeSlice(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const num = 20
+
+	// random points with non-trivial Z coordinates
+	points := make([]curvePoints.Point_xtw_full, num)
+	for i := range points {
+		P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		points[i].SetFrom(&P)
+		points[i].DoubleEq()
+		points[i].AddEq(&curvePoints.AffineOrderTwoPoint_xtw)
+	}
+	ms := XYMultiSerializer{basicSerializer: ps_XY, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+	expected := serializeSliceForTest(t, &ms, points)
+
+	var buf bytes.Buffer
+	bytesWritten, err := ms.SerializeSlice(&buf, curvePoints.CurvePointSlice_xtw_full(points))
+	testutils.FatalUnless(t, err == nil, "SerializeSlice failed: %v", err)
+	testutils.FatalUnless(t, bytesWritten == len(expected), "SerializeSlice reported wrong number of bytes written")
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expected), "SerializeSlice does not match individual serialization")
+	expectedLen, _ := ms.SliceOutputLength(num)
+	testutils.FatalUnless(t, bytesWritten == int(expectedLen), "SerializeSlice does not match SliceOutputLength")
+
+	output, bytesRead, errDeserialize := ms.DeserializeSlice(bytes.NewReader(buf.Bytes()), common.UntrustedInput, CreateNewSlice[curvePoints.Point_xtw_full, *curvePoints.Point_xtw_full])
+	testutils.FatalUnless(t, errDeserialize == nil, "DeserializeSlice failed: %v", errDeserialize)
+	testutils.FatalUnless(t, bytesRead == bytesWritten, "")
+	result := output.([]curvePoints.Point_xtw_full)
+	for i := range points {
+		testutils.FatalUnless(t, result[i].IsEqual(&points[i]), "Roundtrip failed at index %v", i)
+	}
+
+	// Mixed types, including subgroup-only types.
+	Psub := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	var Pefgh curvePoints.Point_efgh_subgroup
+	Pefgh.Double(&Psub)
+	var Paxtw curvePoints.Point_axtw_full
+	Paxtw.SetFrom(&points[0])
+	mixed := curvePoints.GenericPointSlice{&Psub, &Pefgh, &Paxtw, &points[1]}
+	var expectedBuf bytes.Buffer
+	_, _ = ms.headerSerializer.serializeGlobalSliceHeader(&expectedBuf, int32(len(mixed)))
+	for _, P := range mixed {
+		_, errSingle := ps_XY.SerializeCurvePoint(&expectedBuf, P)
+		testutils.FatalUnless(t, errSingle == nil, "Could not serialize point: %v", errSingle)
+	}
+	buf.Reset()
+	_, err = ms.SerializeSlice(&buf, mixed)
+	testutils.FatalUnless(t, err == nil, "SerializeSlice failed on mixed types: %v", err)
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expectedBuf.Bytes()), "SerializeSlice does not match individual serialization for mixed types")
+
+	// Points at infinity, using a basic serializer that can handle them.
+	Pinfinity1 := curvePoints.InfinitePoint1_xtw
+	Pinfinity2 := curvePoints.InfinitePoint2_xtw
+	withInfinity := curvePoints.GenericPointSlice{&Psub, &Pinfinity1, &Pefgh, &Pinfinity2}
+	expectedBuf.Reset()
+	for _, P := range withInfinity {
+		_, errSingle := (&pointSerializerFlagged{}).SerializeCurvePoint(&expectedBuf, P)
+		testutils.FatalUnless(t, errSingle == nil, "Could not serialize point: %v", errSingle)
+	}
+	buf.Reset()
+	_, err = serializeSlice_mainloop(&buf, normalizeForBatchSerialization(withInfinity), &basicSimpleHeaderSerializer, &pointSerializerFlagged{})
+	testutils.FatalUnless(t, err == nil, "Serialization failed on points at infinity: %v", err)
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expectedBuf.Bytes()), "Batch serialization does not match individual serialization for points at infinity")
+}
+
+// Serializing several points at once must give the same result as serializing them one by one.
+func TestSerializeCurvePoints(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const num = 10
+	points := make([]curvePoints.Point_xtw_subgroup, num)
+	for i := range points {
+		points[i] = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		points[i].DoubleEq()
+	}
+	ms := banderwagonMultiSerializer{basicSerializer: basicBanderwagonShort, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+
+	var expected bytes.Buffer
+	for i := range points {
+		_, err := ms.SerializeCurvePoint(&expected, &points[i])
+		testutils.FatalUnless(t, err == nil, "SerializeCurvePoint failed: %v", err)
+	}
+	var buf bytes.Buffer
+	bytesWritten, err := ms.SerializeCurvePoints(&buf, curvePoints.CurvePointSlice_xtw_subgroup(points))
+	testutils.FatalUnless(t, err == nil, "SerializeCurvePoints failed: %v", err)
+	testutils.FatalUnless(t, bytesWritten == expected.Len(), "")
+	testutils.FatalUnless(t, bytes.Equal(buf.Bytes(), expected.Bytes()), "SerializeCurvePoints does not match SerializeCurvePoint")
+
+	result := make([]curvePoints.Point_xtw_subgroup, num)
+	_, errDeserialize := ms.DeserializeCurvePoints(bytes.NewReader(buf.Bytes()), common.UntrustedInput, curvePoints.CurvePointSlice_xtw_subgroup(result))
+	testutils.FatalUnless(t, errDeserialize == nil, "DeserializeCurvePoints failed: %v", errDeserialize)
+	for i := range points {
+		testutils.FatalUnless(t, result[i].IsEqual(&points[i]), "Roundtrip failed at index %v", i)
+	}
+}
+
+// On errors, SerializeSlice and SerializeCurvePoints must report the number of points that were completely written.
+func TestBatchSerializationErrors(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	const num = 8
+	points := make([]curvePoints.Point_xtw_subgroup, num)
+	for i := range points {
+		points[i] = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	ms := banderwagonMultiSerializer{basicSerializer: basicBanderwagonShort, headerSerializer: basicSimpleHeaderSerializer}
+	ms.Validate()
+	designatedErr := errors.New("designated error")
+
+	// write error in the middle of point 3
+	faultyBuffer := testutils.NewFaultyBuffer(simpleHeaderSliceLengthOverhead+32*3+5, designatedErr)
+	_, err := ms.SerializeSlice(faultyBuffer, curvePoints.CurvePointSlice_xtw_subgroup(points))
+	testutils.FatalUnless(t, errors.Is(err, designatedErr), "Unexpected error %v", err)
+	testutils.FatalUnless(t, err.GetData().PointsSerialized == 3, "Unexpected PointsSerialized: %v", err.GetData().PointsSerialized)
+	testutils.FatalUnless(t, err.GetData().PartialWrite, "")
+
+	// write error aligned with point boundaries
+	faultyBuffer = testutils.NewFaultyBuffer(32*5, io.EOF)
+	_, err = ms.SerializeCurvePoints(faultyBuffer, curvePoints.CurvePointSlice_xtw_subgroup(points))
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Unexpected error %v", err)
+	testutils.FatalUnless(t, err.GetData().PointsSerialized == 5, "Unexpected PointsSerialized: %v", err.GetData().PointsSerialized)
+	testutils.FatalUnless(t, !err.GetData().PartialWrite, "")
+
+	// NaP in the middle
+	points[4] = curvePoints.Point_xtw_subgroup{}
+	var buf bytes.Buffer
+	_, err = ms.SerializeSlice(&buf, curvePoints.CurvePointSlice_xtw_subgroup(points))
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "Unexpected error %v", err)
+	testutils.FatalUnless(t, err.GetData().PointsSerialized == 4, "Unexpected PointsSerialized: %v", err.GetData().PointsSerialized)
+	testutils.FatalUnless(t, buf.Len() == simpleHeaderSliceLengthOverhead+32*4, "")
+}
@@ -0,0 +1,87 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains the canonical wire format for Schnorr-style signatures (R, s), consisting of a curve point R and a scalar s.
+
+// ErrScalarOutOfRange is returned (wrapped) when deserializing a scalar that is not in the range 0 <= s < GroupOrder.
+var ErrScalarOutOfRange = errors.New(ErrorPrefix + "deserialized scalar is not in the range 0 <= s < GroupOrder")
+
+// signatureScalarLength is the length in bytes of the scalar part of a SignatureEncoding.
+const signatureScalarLength = 32
+
+// SignatureEncodingLength is the length in bytes of a serialized SignatureEncoding.
+const SignatureEncodingLength = 32 + signatureScalarLength
+
+// SignatureEncoding is a Schnorr-style signature (R, S), where R is a point in the prime-order subgroup and S is a scalar.
+//
+// The serialized format is the 64-byte concatenation of R in the short Banderwagon format and S as a 32-byte little-endian number, reduced modulo GroupOrder.
+// Deserialization only accepts S in the range 0 <= S < GroupOrder, so every signature has a unique encoding.
+type SignatureEncoding struct {
+	R curvePoints.Point_xtw_subgroup
+	S *big.Int
+}
+
+// Serialize writes the signature to output in the format described for SignatureEncoding. S is reduced modulo GroupOrder; sig itself is not modified.
+//
+// Possible errors are io errors and an error wrapping ErrCannotSerializeNaP if R is a NaP.
+// S must not be nil; we panic otherwise.
+func (sig *SignatureEncoding) Serialize(output io.Writer) (int, error) {
+	if sig.S == nil {
+		panic(ErrorPrefix + "SignatureEncoding.Serialize called with S == nil")
+	}
+	var buf bytes.Buffer
+	buf.Grow(SignatureEncodingLength)
+	if _, errSerialize := basicBanderwagonShort.SerializeCurvePoint(&buf, &sig.R); errSerialize != nil {
+		return 0, errSerialize
+	}
+	var reduced big.Int
+	curvePoints.ReduceScalar(&reduced, sig.S)
+	var scalarBytes [signatureScalarLength]byte
+	reduced.FillBytes(scalarBytes[:])
+	// FillBytes writes in big-endian order; we use little-endian.
+	for i, j := 0, signatureScalarLength-1; i < j; i, j = i+1, j-1 {
+		scalarBytes[i], scalarBytes[j] = scalarBytes[j], scalarBytes[i]
+	}
+	buf.Write(scalarBytes[:])
+	return output.Write(buf.Bytes())
+}
+
+// Deserialize reads a signature in the format described for SignatureEncoding from input and writes it to sig.
+// trustLevel refers to R; the range check for S is always performed.
+//
+// Possible errors are io errors, any error that the short Banderwagon deserializer may return for R, and an error wrapping ErrScalarOutOfRange.
+// On error, sig is untouched.
+func (sig *SignatureEncoding) Deserialize(input io.Reader, trustLevel common.IsInputTrusted) (bytesRead int, err error) {
+	var buf [SignatureEncodingLength]byte
+	bytesRead, err = io.ReadFull(input, buf[:])
+	if err != nil {
+		return
+	}
+	var R curvePoints.Point_xtw_subgroup
+	if _, errDeserialize := basicBanderwagonShort.DeserializeCurvePoint(bytes.NewReader(buf[0:32]), trustLevel, &R); errDeserialize != nil {
+		err = errDeserialize
+		return
+	}
+	var scalarBytes [signatureScalarLength]byte
+	for i := 0; i < signatureScalarLength; i++ {
+		scalarBytes[i] = buf[SignatureEncodingLength-1-i] // reverse little-endian to big-endian for SetBytes
+	}
+	S := new(big.Int).SetBytes(scalarBytes[:])
+	if S.Cmp(common.GroupOrder_Int) >= 0 {
+		err = fmt.Errorf("%w: read scalar was %v", ErrScalarOutOfRange, S)
+		return
+	}
+	sig.R = R
+	sig.S = S
+	return
+}
@@ -0,0 +1,77 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/bandersnatchErrors"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestSignatureEncoding(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		var sig SignatureEncoding
+		sig.R = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+		sig.S = new(big.Int).Rand(drng, common.GroupOrder_Int)
+		if i == 1 {
+			sig.S.Add(sig.S, common.GroupOrder_Int) // unreduced scalar
+		}
+		var buf bytes.Buffer
+		bytesWritten, err := sig.Serialize(&buf)
+		testutils.FatalUnless(t, err == nil, "Serialization failed: %v", err)
+		testutils.FatalUnless(t, bytesWritten == SignatureEncodingLength && buf.Len() == SignatureEncodingLength, "Serialization wrote wrong number of bytes")
+
+		// R is in short Banderwagon format, S is little endian.
+		encoding := buf.Bytes()
+		RExpected := curvePoints.DecafEncode(&sig.R)
+		testutils.FatalUnless(t, bytes.Equal(encoding[0:32], RExpected[:]), "R not encoded in short Banderwagon format")
+		var SReduced big.Int
+		SReduced.Mod(sig.S, common.GroupOrder_Int)
+		SBytes := SReduced.FillBytes(make([]byte, 32))
+		for j := 0; j < 32; j++ {
+			testutils.FatalUnless(t, encoding[32+j] == SBytes[31-j], "S not encoded as reduced little-endian number")
+		}
+
+		var sig2 SignatureEncoding
+		bytesRead, err := sig2.Deserialize(bytes.NewReader(encoding), common.UntrustedInput)
+		testutils.FatalUnless(t, err == nil, "Deserialization failed: %v", err)
+		testutils.FatalUnless(t, bytesRead == SignatureEncodingLength, "Deserialization read wrong number of bytes")
+		testutils.FatalUnless(t, sig2.R.IsEqual(&sig.R), "R did not round-trip")
+		testutils.FatalUnless(t, sig2.S.Cmp(&SReduced) == 0, "S did not round-trip")
+	}
+
+	// out-of-range S: GroupOrder itself and 2^256-1
+	var sig SignatureEncoding
+	sig.R = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	sig.S = big.NewInt(1)
+	var buf bytes.Buffer
+	_, err := sig.Serialize(&buf)
+	testutils.FatalUnless(t, err == nil, "Serialization failed: %v", err)
+	encoding := buf.Bytes()
+	orderBytes := common.GroupOrder_Int.FillBytes(make([]byte, 32))
+	for _, outOfRange := range [][]byte{orderBytes, bytes.Repeat([]byte{0xFF}, 32)} {
+		for j := 0; j < 32; j++ {
+			encoding[32+j] = outOfRange[31-j]
+		}
+		sigCopy := sig
+		_, err = sigCopy.Deserialize(bytes.NewReader(encoding), common.UntrustedInput)
+		testutils.FatalUnless(t, errors.Is(err, ErrScalarOutOfRange), "out-of-range scalar not rejected: %v", err)
+		testutils.FatalUnless(t, sigCopy.S == sig.S, "Deserialize modified signature on error")
+	}
+
+	// truncated input
+	_, err = sig.Deserialize(bytes.NewReader(encoding[0:40]), common.UntrustedInput)
+	testutils.FatalUnless(t, err != nil, "truncated input not rejected")
+
+	// NaP
+	var nap SignatureEncoding
+	nap.S = big.NewInt(0)
+	_, err = nap.Serialize(&buf)
+	testutils.FatalUnless(t, errors.Is(err, bandersnatchErrors.ErrCannotSerializeNaP), "NaP not rejected: %v", err)
+}
@@ -0,0 +1,42 @@
+package pointserializer
+
+import (
+	"errors"
+	"io"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains Transcode, which converts a stream of curve points from one format to another.
+
+// Transcode reads curve points from in using the deserializer from and writes them to out using the serializer to, point by point.
+// This is intended for migrating stored data between formats, e.g. when changing endianness.
+// trustLevel has the same meaning as for DeserializeCurvePoint.
+//
+// Transcode stops when in is exhausted. Running out of input exactly at a point boundary is not an error; anything else (including running out of data in the middle of a point) is.
+// n is the number of points that were successfully transcoded, i.e. fully written to out.
+//
+// If from can represent points outside the subgroup, but to cannot, encountering such a point gives the error from to.SerializeCurvePoint.
+func Transcode(in io.Reader, out io.Writer, from curvePointDeserializer_basic, to curvePointSerializer_basic, trustLevel common.IsInputTrusted) (n int, err error) {
+	var point curvePoints.CurvePointPtrInterface
+	if from.IsSubgroupOnly() {
+		point = &curvePoints.Point_xtw_subgroup{}
+	} else {
+		point = &curvePoints.Point_xtw_full{}
+	}
+	for {
+		bytesRead, errDeserialize := from.DeserializeCurvePoint(in, trustLevel, point)
+		if errDeserialize != nil {
+			if bytesRead == 0 && errors.Is(errDeserialize, io.EOF) {
+				return n, nil
+			}
+			return n, errDeserialize
+		}
+		_, errSerialize := to.SerializeCurvePoint(out, point)
+		if errSerialize != nil {
+			return n, errSerialize
+		}
+		n++
+	}
+}
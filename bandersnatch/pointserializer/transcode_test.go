@@ -0,0 +1,69 @@
+package pointserializer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestTranscode(t *testing.T) {
+	const numPoints = 10
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	points := make([]curvePoints.Point_xtw_subgroup, numPoints)
+	for i := range points {
+		points[i] = curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	}
+	serializeAll := func(s curvePointSerializer_basic) []byte {
+		var buf bytes.Buffer
+		for i := range points {
+			_, err := s.SerializeCurvePoint(&buf, &points[i])
+			testutils.FatalUnless(t, err == nil, "")
+		}
+		return buf.Bytes()
+	}
+
+	banderwagonShortBigEndian := basicBanderwagonShort.WithEndianness(common.BigEndian)
+	banderwagonShortLittleEndian := basicBanderwagonShort.WithEndianness(common.LittleEndian)
+	for _, pair := range [][2]curvePointSerializer_basic{
+		{&basicBanderwagonShort, &basicBanderwagonLong},
+		{&banderwagonShortBigEndian, &banderwagonShortLittleEndian},
+		{&ps_XY, &ps_XSY},
+	} {
+		from, to := pair[0], pair[1]
+		input := serializeAll(from)
+		var transcoded bytes.Buffer
+		n, err := Transcode(bytes.NewReader(input), &transcoded, from, to, common.UntrustedInput)
+		testutils.FatalUnless(t, err == nil, "Transcode failed: %v", err)
+		testutils.FatalUnless(t, n == numPoints, "Transcode reported %v points, expected %v", n, numPoints)
+		testutils.FatalUnless(t, bytes.Equal(transcoded.Bytes(), serializeAll(to)), "Transcode output does not match direct serialization")
+
+		// and back
+		var back bytes.Buffer
+		n, err = Transcode(&transcoded, &back, to, from, common.UntrustedInput)
+		testutils.FatalUnless(t, err == nil && n == numPoints, "Transcoding back failed: %v", err)
+		testutils.FatalUnless(t, bytes.Equal(back.Bytes(), input), "Transcode did not round-trip")
+	}
+
+	// empty input
+	var out bytes.Buffer
+	n, err := Transcode(bytes.NewReader(nil), &out, &basicBanderwagonShort, &basicBanderwagonLong, common.UntrustedInput)
+	testutils.FatalUnless(t, err == nil && n == 0 && out.Len() == 0, "")
+
+	// truncated input
+	input := serializeAll(&basicBanderwagonShort)
+	n, err = Transcode(bytes.NewReader(input[0:len(input)-1]), &out, &basicBanderwagonShort, &basicBanderwagonLong, common.UntrustedInput)
+	testutils.FatalUnless(t, errors.Is(err, io.ErrUnexpectedEOF), "Unexpected error %v", err)
+	testutils.FatalUnless(t, n == numPoints-1, "")
+
+	// points outside the subgroup cannot be written in subgroup-only formats
+	var fullInput bytes.Buffer
+	ps_XY.SerializeCurvePoint(&fullInput, &curvePoints.AffineOrderTwoPoint_xtw)
+	n, err = Transcode(&fullInput, &out, &ps_XY, &basicBanderwagonShort, common.UntrustedInput)
+	testutils.FatalUnless(t, err != nil && n == 0, "Transcode did not fail for point outside subgroup")
+}
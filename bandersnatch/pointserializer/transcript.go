@@ -0,0 +1,93 @@
+package pointserializer
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/big"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+)
+
+// This file contains Transcript, a stateful Fiat-Shamir transcript that binds curve points and scalars into challenges.
+
+// Transcript accumulates labeled curve points and scalars into a hash and derives challenge scalars from it (Fiat-Shamir transform).
+//
+// Every entry written to the hash is of the form kind || len(label) || label || value, where kind is a single byte
+// distinguishing points, scalars and challenges and len(label) is a 4-byte big-endian length prefix.
+// This makes the hash input an unambiguous encoding of the sequence of Append/Challenge calls.
+// Points are written in the short Banderwagon format, scalars as 32-byte big-endian numbers after reduction modulo GroupOrder.
+//
+// Transcripts must be created with NewTranscript. A Transcript takes ownership of the hash it wraps; users must not write to it directly.
+type Transcript struct {
+	h hash.Hash
+}
+
+// kinds of entries in a Transcript
+const (
+	transcriptKindPoint     byte = 1
+	transcriptKindScalar    byte = 2
+	transcriptKindChallenge byte = 3
+)
+
+// transcriptScalarLength is the length in bytes of a serialized scalar in a transcript.
+const transcriptScalarLength = 32
+
+// transcriptChallengeLength is the number of hash output bytes we reduce modulo GroupOrder to obtain a challenge.
+// We use 64 bytes (more than twice the bitlength of GroupOrder), so the bias from the reduction is negligible.
+const transcriptChallengeLength = 64
+
+// NewTranscript creates a new transcript writing to h. h is reset.
+func NewTranscript(h hash.Hash) *Transcript {
+	h.Reset()
+	return &Transcript{h: h}
+}
+
+// appendLabel writes the kind byte and the length-prefixed label to the hash.
+func (tr *Transcript) appendLabel(kind byte, label string) {
+	var prefix [5]byte
+	prefix[0] = kind
+	binary.BigEndian.PutUint32(prefix[1:5], uint32(len(label)))
+	tr.h.Write(prefix[:]) // writes to a hash.Hash never fail
+	tr.h.Write([]byte(label))
+}
+
+// AppendPoint appends the point p with the given label to the transcript.
+//
+// It panics if p is a NaP.
+func (tr *Transcript) AppendPoint(label string, p *curvePoints.Point_xtw_subgroup) {
+	tr.appendLabel(transcriptKindPoint, label)
+	_, err := basicBanderwagonShort.SerializeCurvePoint(tr.h, p)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// AppendScalar appends the scalar s with the given label to the transcript. s is reduced modulo GroupOrder first, so congruent scalars give the same transcript.
+// s itself is not modified.
+func (tr *Transcript) AppendScalar(label string, s *big.Int) {
+	tr.appendLabel(transcriptKindScalar, label)
+	var reduced big.Int
+	curvePoints.ReduceScalar(&reduced, s)
+	var buf [transcriptScalarLength]byte
+	reduced.FillBytes(buf[:])
+	tr.h.Write(buf[:])
+}
+
+// ChallengeScalar derives a challenge in the range 0 <= . < GroupOrder from the current state of the transcript and the given label.
+//
+// The challenge and its label are themselves bound into the transcript, so subsequent challenges depend on all previous ones.
+func (tr *Transcript) ChallengeScalar(label string) *big.Int {
+	tr.appendLabel(transcriptKindChallenge, label)
+	// We obtain transcriptChallengeLength bytes by repeatedly taking the digest and feeding it back into the hash.
+	output := make([]byte, 0, transcriptChallengeLength+tr.h.Size())
+	for len(output) < transcriptChallengeLength {
+		digest := tr.h.Sum(nil)
+		tr.h.Write(digest)
+		output = append(output, digest...)
+	}
+	var ret big.Int
+	ret.SetBytes(output[0:transcriptChallengeLength])
+	ret.Mod(&ret, common.GroupOrder_Int)
+	return &ret
+}
@@ -0,0 +1,81 @@
+package pointserializer
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/common"
+	"github.com/GottfriedHerold/Bandersnatch/bandersnatch/curvePoints"
+	"github.com/GottfriedHerold/Bandersnatch/internal/testutils"
+)
+
+func TestTranscript(t *testing.T) {
+	var drng *rand.Rand = rand.New(rand.NewSource(1))
+	P := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	Q := curvePoints.MakeRandomPointUnsafe_xtw_subgroup(drng)
+	s := big.NewInt(12345)
+
+	challengeFor := func(build func(tr *Transcript)) *big.Int {
+		tr := NewTranscript(sha256.New())
+		build(tr)
+		return tr.ChallengeScalar("c")
+	}
+
+	reference := challengeFor(func(tr *Transcript) {
+		tr.AppendPoint("P", &P)
+		tr.AppendPoint("Q", &Q)
+		tr.AppendScalar("s", s)
+	})
+	testutils.FatalUnless(t, reference.Sign() >= 0 && reference.Cmp(common.GroupOrder_Int) < 0, "Challenge out of range")
+
+	// identical transcripts give identical challenges
+	again := challengeFor(func(tr *Transcript) {
+		tr.AppendPoint("P", &P)
+		tr.AppendPoint("Q", &Q)
+		tr.AppendScalar("s", s)
+	})
+	testutils.FatalUnless(t, reference.Cmp(again) == 0, "Identical transcripts gave different challenges")
+
+	// congruent scalars give identical challenges
+	sCongruent := new(big.Int).Add(s, common.GroupOrder_Int)
+	again = challengeFor(func(tr *Transcript) {
+		tr.AppendPoint("P", &P)
+		tr.AppendPoint("Q", &Q)
+		tr.AppendScalar("s", sCongruent)
+	})
+	testutils.FatalUnless(t, reference.Cmp(again) == 0, "Congruent scalars gave different challenges")
+
+	// Any change to order, labels, values or entry kinds must change the challenge
+	for i, variant := range []func(tr *Transcript){
+		func(tr *Transcript) { tr.AppendPoint("Q", &Q); tr.AppendPoint("P", &P); tr.AppendScalar("s", s) },
+		func(tr *Transcript) { tr.AppendPoint("P", &Q); tr.AppendPoint("Q", &P); tr.AppendScalar("s", s) },
+		func(tr *Transcript) { tr.AppendPoint("P", &P); tr.AppendPoint("R", &Q); tr.AppendScalar("s", s) },
+		func(tr *Transcript) { tr.AppendPoint("PQ", &P); tr.AppendPoint("", &Q); tr.AppendScalar("s", s) },
+		func(tr *Transcript) {
+			tr.AppendPoint("P", &P)
+			tr.AppendPoint("Q", &Q)
+			tr.AppendScalar("s", big.NewInt(12346))
+		},
+		func(tr *Transcript) { tr.AppendScalar("s", s); tr.AppendPoint("P", &P); tr.AppendPoint("Q", &Q) },
+		func(tr *Transcript) { tr.AppendPoint("P", &P); tr.AppendPoint("Q", &Q) },
+	} {
+		c := challengeFor(variant)
+		testutils.FatalUnless(t, reference.Cmp(c) != 0, "Variant %v gave the same challenge", i)
+	}
+
+	// successive challenges differ and depend on the label
+	tr1 := NewTranscript(sha256.New())
+	tr2 := NewTranscript(sha256.New())
+	c1 := tr1.ChallengeScalar("c")
+	c2 := tr1.ChallengeScalar("c")
+	testutils.FatalUnless(t, c1.Cmp(c2) != 0, "Successive challenges are equal")
+	c3 := tr2.ChallengeScalar("d")
+	testutils.FatalUnless(t, c1.Cmp(c3) != 0, "Challenge does not depend on label")
+
+	// NaPs cannot be appended
+	var NaP curvePoints.Point_xtw_subgroup
+	didPanic := testutils.CheckPanic(tr1.AppendPoint, "P", &NaP)
+	testutils.FatalUnless(t, didPanic, "AppendPoint did not panic on NaP")
+}
@@ -387,6 +387,10 @@ func (s *valuesSerializerHeaderFe) HasParameter(parameterName string) bool {
 //*******************************************************************************************************************************
 
 // valuesSerializerFeCompressedBit is a simple serializer for a field element + 1 extra bit. The extra bit is squeezed into the field element.
+//
+// The extra bit is placed in the msb of the field element, viewed as a 256-bit number, before converting to bytes according to fieldElementEndianness.
+// Consequently, the bit ends up in the most significant byte, which is the last byte written for little endian and the first byte for big endian.
+// This is what SerializeWithPrefix / DeserializeAndGetPrefix do; we must not put the bit into a fixed byte position of the output.
 type valuesSerializerFeCompressedBit struct {
 	fieldElementEndianness // endianness for field element (de)serialization.
 }
@@ -0,0 +1,18 @@
+//go:build !noassertions
+
+package testutils
+
+// This file is selected unless the noassertions build tag is set; see assertions_inactive.go for the alternative.
+
+// AssertionsActive is a constant whose value depends on build flags;
+// it is true unless the noassertions build tag is set, in which case DebugAssert is a no-op.
+const AssertionsActive = true
+
+// DebugAssert(condition) is used for internal consistency checks in performance-critical code paths.
+// If AssertionsActive is true (the default), it behaves exactly like Assert, i.e. it panics if condition is false.
+// If the noassertions build tag is set, it does nothing.
+//
+// Note that the arguments are still evaluated in either case, so condition should be cheap to compute.
+func DebugAssert(condition bool, err ...interface{}) {
+	Assert(condition, err...)
+}
@@ -0,0 +1,16 @@
+//go:build noassertions
+
+package testutils
+
+// This file is selected if the noassertions build tag is set. It turns DebugAssert into a no-op, so release builds have no overhead from these checks.
+
+// NOTE: Godoc does not seem to recognize build tags properly, so this might show up twice.
+
+// AssertionsActive is a constant whose value depends on build flags;
+// it is true unless the noassertions build tag is set, in which case DebugAssert is a no-op.
+const AssertionsActive = false
+
+// DebugAssert(condition) is used for internal consistency checks in performance-critical code paths.
+// Since the noassertions build tag is set, it does nothing.
+func DebugAssert(condition bool, err ...interface{}) {
+}
@@ -0,0 +1,20 @@
+package testutils
+
+import "testing"
+
+// Run this both with and without -tags=noassertions
+func TestDebugAssert(t *testing.T) {
+	forcedInconsistency := func() {
+		DebugAssert(1+1 == 3, "forced inconsistency")
+	}
+	didPanic := CheckPanic(forcedInconsistency)
+	if AssertionsActive && !didPanic {
+		t.Fatalf("DebugAssert did not catch inconsistency even though assertions are active")
+	}
+	if !AssertionsActive && didPanic {
+		t.Fatalf("DebugAssert panicked even though assertions are inactive")
+	}
+	if CheckPanic(DebugAssert, true) {
+		t.Fatalf("DebugAssert panicked on true condition")
+	}
+}